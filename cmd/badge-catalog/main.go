@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	configs "gym-badges-api/config/gym-badges-server"
+	badgeDAO "gym-badges-api/internal/repository/badge/postgresql"
+	catalogService "gym-badges-api/internal/service/catalog"
+	toolsLogging "gym-badges-api/tools/logging"
+	"os"
+)
+
+// Validates the badge catalog file and reports its diff against the deployed badges. With -apply the badges
+// that differ are created and updated, so running it again changes nothing
+func main() {
+
+	file := flag.String("file", "scripts/catalog/badges.yml", "Badge catalog file")
+	apply := flag.Bool("apply", false, "Create and update the deployed badges that differ from the catalog")
+	offline := flag.Bool("offline", false, "Only validate the catalog, without connecting to the database")
+	flag.Parse()
+
+	configs.LoadConfig()
+
+	ctxLog := toolsLogging.BuildLogger()
+
+	content, err := os.ReadFile(*file)
+	if err != nil {
+		ctxLog.Fatalln(err)
+	}
+
+	// The validation does not need the database
+	catalog, err := catalogService.NewCatalogService(nil).LoadCatalog(content, ctxLog)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Catalog %s is not valid:\n%s\n", *file, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Catalog %s is valid, %d badges\n", *file, len(catalog.Badges))
+
+	if *offline {
+		return
+	}
+
+	service := catalogService.NewCatalogService(badgeDAO.NewBadgeDAO())
+
+	diff, err := service.DiffCatalog(catalog, ctxLog)
+	if err != nil {
+		ctxLog.Fatalln(err)
+	}
+
+	fmt.Print(diff)
+
+	if !*apply || diff.Empty() {
+		return
+	}
+
+	if err := service.ApplyCatalog(diff, ctxLog); err != nil {
+		ctxLog.Fatalln(err)
+	}
+
+	fmt.Println("Catalog applied")
+}
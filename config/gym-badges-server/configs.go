@@ -4,6 +4,7 @@ import (
 	"gym-badges-api/internal/repository/config/postgresql"
 	toolsConfig "gym-badges-api/tools/config"
 	toolsLogging "gym-badges-api/tools/logging"
+	"time"
 )
 
 var (
@@ -11,12 +12,48 @@ var (
 )
 
 type BasicConfiguration struct {
-	Port             int    `default:"8080" envconfig:"APP_PORT"`
-	SessionDuration  int    `default:"31536000" envconfig:"SESSION_DURATION"` // One year
-	JWTKey           string `default:"GymBadges" envconfig:"JWT_KEY"`
-	LogLevel         string `default:"DEBUG" envconfig:"LOG_LEVEL"`
-	FriendsPageSize  int32  `default:"3" envconfig:"FRIENDS_PAGE_SIZE"`
-	RankingsPageSize int32  `default:"10" envconfig:"RANKINGS_PAGE_SIZE"`
+	Port                  int    `default:"8080" envconfig:"APP_PORT"`
+	SessionDuration       int    `default:"31536000" envconfig:"SESSION_DURATION"` // One year
+	JWTKey                string `default:"GymBadges" envconfig:"JWT_KEY"`
+	LogLevel              string `default:"DEBUG" envconfig:"LOG_LEVEL"`
+	FriendsPageSize       int32  `default:"3" envconfig:"FRIENDS_PAGE_SIZE"`
+	RankingsPageSize      int32  `default:"10" envconfig:"RANKINGS_PAGE_SIZE"`
+	WorkoutsPageSize      int32  `default:"10" envconfig:"WORKOUTS_PAGE_SIZE"`
+	ExercisesPageSize     int32  `default:"20" envconfig:"EXERCISES_PAGE_SIZE"`
+	BadgeTimelinePageSize int32  `default:"20" envconfig:"BADGE_TIMELINE_PAGE_SIZE"`
+	ClosestBadgesSize     int    `default:"3" envconfig:"CLOSEST_BADGES_SIZE"`
+	GoalExperience        int64  `default:"500" envconfig:"GOAL_EXPERIENCE"`
+	PublicURL             string `default:"http://localhost:8080" envconfig:"PUBLIC_URL"` // Base of the calendar subscription URLs
+	FreezeExperience      int64  `default:"2500" envconfig:"FREEZE_EXPERIENCE"`           // Experience needed for each streak freeze
+	MaxVacationWeeks      int    `default:"4" envconfig:"MAX_VACATION_WEEKS"`             // Per year
+	QRCodePeriod          int64  `default:"30" envconfig:"QR_CODE_PERIOD"`                // Seconds each check-in QR code is valid
+	// How a weekly goal change inside a started period is applied: "next_period" or "locked"
+	GoalChangeMode string `default:"next_period" envconfig:"GOAL_CHANGE_MODE"`
+	// Uploaded badge images are stored in the directory and served under the URL path, only PNG and JPEG
+	BadgeImagesDir  string `default:"./images/badge" envconfig:"BADGE_IMAGES_DIR"`
+	BadgeImagesPath string `default:"/image/badge" envconfig:"BADGE_IMAGES_PATH"`
+	// Uploaded proofs of the badge claims, and the friends vouching needed to approve a claim
+	ClaimProofsDir    string `default:"./proofs" envconfig:"CLAIM_PROOFS_DIR"`
+	ClaimProofsPath   string `default:"/proofs" envconfig:"CLAIM_PROOFS_PATH"`
+	ClaimProofMaxSize int64  `default:"52428800" envconfig:"CLAIM_PROOF_MAX_SIZE"` // 50 MB
+	ClaimVouches      int    `default:"2" envconfig:"CLAIM_VOUCHES"`
+	// Biggest history file accepted by the imports, an Apple Health export easily takes hundreds of megabytes
+	ImportMaxSize int64 `default:"268435456" envconfig:"IMPORT_MAX_SIZE"` // 256 MB
+	// Locales of the badge translations. The badges of the catalog are in the default one, the fallback
+	Locales       []string `default:"en,es" envconfig:"LOCALES"`
+	DefaultLocale string   `default:"en" envconfig:"DEFAULT_LOCALE"`
+	// Refresh of the badge statistics. Active users attended the gym in the last days
+	BadgeStatsInterval time.Duration `default:"1h" envconfig:"BADGE_STATS_INTERVAL"`
+	ActiveUserDays     int           `default:"30" envconfig:"ACTIVE_USER_DAYS"`
+	// Evaluation of the streaks of every user, so the ended periods break them or use freezes without a write
+	StreakInterval time.Duration `default:"1h" envconfig:"STREAK_INTERVAL"`
+	// Evaluation of the global rank badges, as a user moves in the global ranking when the others gain experience
+	RankBadgesInterval time.Duration `default:"15m" envconfig:"RANK_BADGES_INTERVAL"`
+	// Asynchronous delivery of the domain events, failed handlers are retried with an exponential backoff
+	EventWorkers    int           `default:"4" envconfig:"EVENT_WORKERS"`
+	EventQueueSize  int           `default:"1000" envconfig:"EVENT_QUEUE_SIZE"`
+	EventMaxRetries int           `default:"3" envconfig:"EVENT_MAX_RETRIES"`
+	EventRetryDelay time.Duration `default:"1s" envconfig:"EVENT_RETRY_DELAY"`
 }
 
 func LoadConfig() {
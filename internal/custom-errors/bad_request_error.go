@@ -0,0 +1,25 @@
+package custom_errors
+
+import (
+	"fmt"
+)
+
+var (
+	BadRequest = BadRequestError{CustomError{Message: "Bad Request"}}
+)
+
+type BadRequestError struct {
+	CustomError
+}
+
+func (e BadRequestError) Error() string {
+	return e.Message
+}
+
+// BuildBadRequestError Builds a BadRequestError with the supplied message, and the corresponding code.
+func BuildBadRequestError(message string, args ...any) BadRequestError {
+	if len(args) == 0 {
+		return BadRequestError{CustomError{Message: message}}
+	}
+	return BadRequestError{CustomError{Message: fmt.Sprintf(message, args...)}}
+}
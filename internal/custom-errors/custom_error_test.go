@@ -57,4 +57,18 @@ var _ = Describe("ERRORS: Custom Errors Test Suite", func() {
 
 	})
 
+	Context("Bad Request Error", func() {
+
+		It("BuildBadRequestError", func() {
+			err := BuildBadRequestError("bad request")
+			Expect(err.Error()).To(Equal("bad request"))
+		})
+
+		It("BuildBadRequestError with parameters", func() {
+			err := BuildBadRequestError("bad request %d", http.StatusBadRequest)
+			Expect(err.Error()).To(Equal("bad request 400"))
+		})
+
+	})
+
 })
@@ -8,6 +8,7 @@ import (
 	"gym-badges-api/models"
 	op "gym-badges-api/restapi/operations/badges"
 	toolsLogging "gym-badges-api/tools/logging"
+	"io"
 	"net/http"
 
 	"github.com/go-openapi/runtime/middleware"
@@ -51,7 +52,7 @@ func (h badgesHandler) GetBadgesByUserID(params op.GetBadgesByUserIDParams) midd
 
 	ctxLog.Infof("BADGES_HANDLER: Getting badges for user: %s", params.UserID)
 
-	response, err := h.badgeService.GetBadgesByUserID(params.UserID, ctxLog)
+	response, err := h.badgeService.GetBadgesByUserID(params.UserID, params.AcceptLanguage, ctxLog)
 	if err != nil {
 		switch {
 		case errors.As(err, &customErrors.Unauthorized):
@@ -64,6 +65,25 @@ func (h badgesHandler) GetBadgesByUserID(params op.GetBadgesByUserIDParams) midd
 	return op.NewGetBadgesByUserIDOK().WithPayload(response)
 }
 
+func (h badgesHandler) GetBadgeStats(params op.GetBadgeStatsParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("BADGES_HANDLER: Getting badge statistics for user: %s", params.AuthUserID)
+
+	response, err := h.badgeService.GetBadgeStats(ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewGetBadgeStatsUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		default:
+			return op.NewGetBadgeStatsInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGetBadgeStatsOK().WithPayload(response)
+}
+
 func (h badgesHandler) AddBadge(params op.AddBadgeParams) middleware.Responder {
 
 	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
@@ -84,6 +104,11 @@ func (h badgesHandler) AddBadge(params op.AddBadgeParams) middleware.Responder {
 			return op.NewAddBadgeNotFound().WithPayload(&notFoundErrorResponse)
 		case errors.As(err, &customErrors.Forbidden):
 			return op.NewAddBadgeForbidden().WithPayload(&forbiddenErrorResponse)
+		case errors.As(err, &customErrors.Conflict):
+			return op.NewAddBadgeConflict().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusConflict),
+				Message: err.Error(),
+			})
 		default:
 			return op.NewAddBadgeInternalServerError().WithPayload(&internalServerErrorResponse)
 		}
@@ -119,3 +144,564 @@ func (h badgesHandler) DeleteBadge(params op.DeleteBadgeParams) middleware.Respo
 
 	return op.NewDeleteBadgeOK()
 }
+
+func (h badgesHandler) GetBadgeTimeline(params op.GetBadgeTimelineParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("BADGES_HANDLER: Getting badge timeline for user: %s page: %d", params.UserID, params.Page)
+
+	response, err := h.badgeService.GetBadgeTimeline(params.UserID, params.Page, params.AcceptLanguage, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewGetBadgeTimelineUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewGetBadgeTimelineNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewGetBadgeTimelineInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGetBadgeTimelineOK().WithPayload(response)
+}
+
+func (h badgesHandler) GetClosestBadges(params op.GetClosestBadgesParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("BADGES_HANDLER: Getting closest badges for user: %s", params.UserID)
+
+	response, err := h.badgeService.GetClosestBadges(params.UserID, params.AcceptLanguage, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewGetClosestBadgesUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewGetClosestBadgesNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewGetClosestBadgesInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGetClosestBadgesOK().WithPayload(response)
+}
+
+// *******************************************************************
+// CATALOG ADMINISTRATION
+// *******************************************************************
+
+func (h badgesHandler) CreateBadge(params op.CreateBadgeParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("BADGES_HANDLER: Creating badge %d by admin %s", params.Input.ID, params.AuthUserID)
+
+	response, err := h.badgeService.CreateBadge(params.AuthUserID, params.Input, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.BadRequest):
+			return op.NewCreateBadgeBadRequest().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusBadRequest),
+				Message: err.Error(),
+			})
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewCreateBadgeUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.Forbidden):
+			return op.NewCreateBadgeForbidden().WithPayload(&forbiddenErrorResponse)
+		case errors.As(err, &customErrors.Conflict):
+			return op.NewCreateBadgeConflict().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusConflict),
+				Message: err.Error(),
+			})
+		default:
+			return op.NewCreateBadgeInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewCreateBadgeCreated().WithPayload(response)
+}
+
+func (h badgesHandler) EditBadge(params op.EditBadgeParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("BADGES_HANDLER: Editing badge %d by admin %s", params.BadgeID, params.AuthUserID)
+
+	response, err := h.badgeService.EditBadge(params.AuthUserID, int16(params.BadgeID), params.Input, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.BadRequest):
+			return op.NewEditBadgeBadRequest().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusBadRequest),
+				Message: err.Error(),
+			})
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewEditBadgeUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.Forbidden):
+			return op.NewEditBadgeForbidden().WithPayload(&forbiddenErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewEditBadgeNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewEditBadgeInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewEditBadgeOK().WithPayload(response)
+}
+
+func (h badgesHandler) ReparentBadge(params op.ReparentBadgeParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("BADGES_HANDLER: Moving badge %d by admin %s", params.BadgeID, params.AuthUserID)
+
+	var parentBadgeID int16
+	if params.Input.ParentBadgeID != nil {
+		parentBadgeID = int16(*params.Input.ParentBadgeID)
+	}
+
+	response, err := h.badgeService.ReparentBadge(params.AuthUserID, int16(params.BadgeID), parentBadgeID, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.BadRequest):
+			return op.NewReparentBadgeBadRequest().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusBadRequest),
+				Message: err.Error(),
+			})
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewReparentBadgeUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.Forbidden):
+			return op.NewReparentBadgeForbidden().WithPayload(&forbiddenErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewReparentBadgeNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewReparentBadgeInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewReparentBadgeOK().WithPayload(response)
+}
+
+func (h badgesHandler) RetireBadge(params op.RetireBadgeParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("BADGES_HANDLER: Retiring badge %d by admin %s", params.BadgeID, params.AuthUserID)
+
+	err := h.badgeService.RetireBadge(params.AuthUserID, int16(params.BadgeID), ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewRetireBadgeUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.Forbidden):
+			return op.NewRetireBadgeForbidden().WithPayload(&forbiddenErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewRetireBadgeNotFound().WithPayload(&notFoundErrorResponse)
+		case errors.As(err, &customErrors.Conflict):
+			return op.NewRetireBadgeConflict().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusConflict),
+				Message: err.Error(),
+			})
+		default:
+			return op.NewRetireBadgeInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewRetireBadgeOK()
+}
+
+func (h badgesHandler) ReorderBadges(params op.ReorderBadgesParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("BADGES_HANDLER: Sorting badges by admin %s", params.AuthUserID)
+
+	var parentBadgeID int16
+	if params.Input.ParentBadgeID != nil {
+		parentBadgeID = int16(*params.Input.ParentBadgeID)
+	}
+
+	badgeIDs := make([]int16, len(params.Input.BadgeIds))
+	for i, id := range params.Input.BadgeIds {
+		badgeIDs[i] = int16(id)
+	}
+
+	err := h.badgeService.ReorderBadges(params.AuthUserID, parentBadgeID, badgeIDs, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.BadRequest):
+			return op.NewReorderBadgesBadRequest().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusBadRequest),
+				Message: err.Error(),
+			})
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewReorderBadgesUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.Forbidden):
+			return op.NewReorderBadgesForbidden().WithPayload(&forbiddenErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewReorderBadgesNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewReorderBadgesInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewReorderBadgesOK()
+}
+
+func (h badgesHandler) UploadBadgeImage(params op.UploadBadgeImageParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("BADGES_HANDLER: Uploading image of badge %d by admin %s", params.BadgeID, params.AuthUserID)
+
+	defer params.Image.Close()
+
+	response, err := h.badgeService.UploadBadgeImage(params.AuthUserID, int16(params.BadgeID), params.Image, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.BadRequest):
+			return op.NewUploadBadgeImageBadRequest().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusBadRequest),
+				Message: err.Error(),
+			})
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewUploadBadgeImageUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.Forbidden):
+			return op.NewUploadBadgeImageForbidden().WithPayload(&forbiddenErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewUploadBadgeImageNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewUploadBadgeImageInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewUploadBadgeImageOK().WithPayload(response)
+}
+
+func (h badgesHandler) GetBadgeAudit(params op.GetBadgeAuditParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("BADGES_HANDLER: Getting audit of badge %d for admin %s", params.BadgeID, params.AuthUserID)
+
+	response, err := h.badgeService.GetBadgeAudit(params.AuthUserID, int16(params.BadgeID), ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewGetBadgeAuditUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.Forbidden):
+			return op.NewGetBadgeAuditForbidden().WithPayload(&forbiddenErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewGetBadgeAuditNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewGetBadgeAuditInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGetBadgeAuditOK().WithPayload(response)
+}
+
+func (h badgesHandler) SetBadgeRule(params op.SetBadgeRuleParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("BADGES_HANDLER: Setting rule of badge %d by admin %s", params.BadgeID, params.AuthUserID)
+
+	response, err := h.badgeService.SetBadgeRule(params.AuthUserID, int16(params.BadgeID), params.Input, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.BadRequest):
+			return op.NewSetBadgeRuleBadRequest().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusBadRequest),
+				Message: err.Error(),
+			})
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewSetBadgeRuleUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.Forbidden):
+			return op.NewSetBadgeRuleForbidden().WithPayload(&forbiddenErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewSetBadgeRuleNotFound().WithPayload(&notFoundErrorResponse)
+		case errors.As(err, &customErrors.Conflict):
+			return op.NewSetBadgeRuleConflict().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusConflict),
+				Message: err.Error(),
+			})
+		default:
+			return op.NewSetBadgeRuleInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewSetBadgeRuleOK().WithPayload(response)
+}
+
+func (h badgesHandler) DeleteBadgeRule(params op.DeleteBadgeRuleParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("BADGES_HANDLER: Deleting rule of badge %d by admin %s", params.BadgeID, params.AuthUserID)
+
+	err := h.badgeService.DeleteBadgeRule(params.AuthUserID, int16(params.BadgeID), ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewDeleteBadgeRuleUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.Forbidden):
+			return op.NewDeleteBadgeRuleForbidden().WithPayload(&forbiddenErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewDeleteBadgeRuleNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewDeleteBadgeRuleInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewDeleteBadgeRuleOK()
+}
+
+func (h badgesHandler) GetBadgeTranslations(params op.GetBadgeTranslationsParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("BADGES_HANDLER: Getting translations of badge %d for admin %s", params.BadgeID, params.AuthUserID)
+
+	response, err := h.badgeService.GetBadgeTranslations(params.AuthUserID, int16(params.BadgeID), ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewGetBadgeTranslationsUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.Forbidden):
+			return op.NewGetBadgeTranslationsForbidden().WithPayload(&forbiddenErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewGetBadgeTranslationsNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewGetBadgeTranslationsInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGetBadgeTranslationsOK().WithPayload(response)
+}
+
+func (h badgesHandler) SetBadgeTranslation(params op.SetBadgeTranslationParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("BADGES_HANDLER: Translating badge %d to %s by admin %s", params.BadgeID, params.Locale, params.AuthUserID)
+
+	response, err := h.badgeService.SetBadgeTranslation(params.AuthUserID, int16(params.BadgeID), params.Locale, params.Input, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.BadRequest):
+			return op.NewSetBadgeTranslationBadRequest().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusBadRequest),
+				Message: err.Error(),
+			})
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewSetBadgeTranslationUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.Forbidden):
+			return op.NewSetBadgeTranslationForbidden().WithPayload(&forbiddenErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewSetBadgeTranslationNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewSetBadgeTranslationInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewSetBadgeTranslationOK().WithPayload(response)
+}
+
+func (h badgesHandler) DeleteBadgeTranslation(params op.DeleteBadgeTranslationParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("BADGES_HANDLER: Deleting %s translation of badge %d by admin %s", params.Locale, params.BadgeID, params.AuthUserID)
+
+	err := h.badgeService.DeleteBadgeTranslation(params.AuthUserID, int16(params.BadgeID), params.Locale, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewDeleteBadgeTranslationUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.Forbidden):
+			return op.NewDeleteBadgeTranslationForbidden().WithPayload(&forbiddenErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewDeleteBadgeTranslationNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewDeleteBadgeTranslationInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewDeleteBadgeTranslationOK()
+}
+
+func (h badgesHandler) GrantBadge(params op.GrantBadgeParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("BADGES_HANDLER: Granting badge %d to user %s by admin %s", params.BadgeID, params.UserID, params.AuthUserID)
+
+	err := h.badgeService.GrantBadge(params.AuthUserID, int16(params.BadgeID), params.UserID, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewGrantBadgeUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.Forbidden):
+			return op.NewGrantBadgeForbidden().WithPayload(&forbiddenErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewGrantBadgeNotFound().WithPayload(&notFoundErrorResponse)
+		case errors.As(err, &customErrors.Conflict):
+			return op.NewGrantBadgeConflict().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusConflict),
+				Message: err.Error(),
+			})
+		default:
+			return op.NewGrantBadgeInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGrantBadgeOK()
+}
+
+// *******************************************************************
+// BADGE CLAIMS
+// *******************************************************************
+
+func (h badgesHandler) ClaimBadge(params op.ClaimBadgeParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("BADGES_HANDLER: Claiming badge %d for user %s", params.BadgeID, params.UserID)
+
+	var proof io.Reader
+	if params.Proof != nil {
+		defer params.Proof.Close()
+		proof = params.Proof
+	}
+
+	// An user can only edit his own info
+	if params.AuthUserID != params.UserID {
+		return op.NewClaimBadgeUnauthorized().WithPayload(&unauthorizedErrorResponse)
+	}
+
+	response, err := h.badgeService.ClaimBadge(params.UserID, int16(params.BadgeID), params.WitnessID, proof, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.BadRequest):
+			return op.NewClaimBadgeBadRequest().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusBadRequest),
+				Message: err.Error(),
+			})
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewClaimBadgeUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.Forbidden):
+			return op.NewClaimBadgeForbidden().WithPayload(&forbiddenErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewClaimBadgeNotFound().WithPayload(&notFoundErrorResponse)
+		case errors.As(err, &customErrors.Conflict):
+			return op.NewClaimBadgeConflict().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusConflict),
+				Message: err.Error(),
+			})
+		default:
+			return op.NewClaimBadgeInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewClaimBadgeCreated().WithPayload(response)
+}
+
+func (h badgesHandler) GetBadgeClaims(params op.GetBadgeClaimsParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("BADGES_HANDLER: Getting badge claims of user %s for user %s", params.UserID, params.AuthUserID)
+
+	response, err := h.badgeService.GetBadgeClaims(params.AuthUserID, params.UserID, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewGetBadgeClaimsUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.Forbidden):
+			return op.NewGetBadgeClaimsForbidden().WithPayload(&forbiddenErrorResponse)
+		default:
+			return op.NewGetBadgeClaimsInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGetBadgeClaimsOK().WithPayload(response)
+}
+
+func (h badgesHandler) VouchBadgeClaim(params op.VouchBadgeClaimParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("BADGES_HANDLER: Vouching for badge claim %d by user %s", params.ClaimID, params.AuthUserID)
+
+	response, err := h.badgeService.VouchBadgeClaim(params.AuthUserID, params.ClaimID, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewVouchBadgeClaimUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.Forbidden):
+			return op.NewVouchBadgeClaimForbidden().WithPayload(&forbiddenErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewVouchBadgeClaimNotFound().WithPayload(&notFoundErrorResponse)
+		case errors.As(err, &customErrors.Conflict):
+			return op.NewVouchBadgeClaimConflict().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusConflict),
+				Message: err.Error(),
+			})
+		default:
+			return op.NewVouchBadgeClaimInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewVouchBadgeClaimOK().WithPayload(response)
+}
+
+func (h badgesHandler) GetPendingBadgeClaims(params op.GetPendingBadgeClaimsParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("BADGES_HANDLER: Getting pending badge claims for moderator %s", params.AuthUserID)
+
+	response, err := h.badgeService.GetPendingBadgeClaims(params.AuthUserID, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewGetPendingBadgeClaimsUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.Forbidden):
+			return op.NewGetPendingBadgeClaimsForbidden().WithPayload(&forbiddenErrorResponse)
+		default:
+			return op.NewGetPendingBadgeClaimsInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGetPendingBadgeClaimsOK().WithPayload(response)
+}
+
+func (h badgesHandler) ReviewBadgeClaim(params op.ReviewBadgeClaimParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("BADGES_HANDLER: Reviewing badge claim %d by moderator %s", params.ClaimID, params.AuthUserID)
+
+	response, err := h.badgeService.ReviewBadgeClaim(params.AuthUserID, params.ClaimID, *params.Input.Approve, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewReviewBadgeClaimUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.Forbidden):
+			return op.NewReviewBadgeClaimForbidden().WithPayload(&forbiddenErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewReviewBadgeClaimNotFound().WithPayload(&notFoundErrorResponse)
+		case errors.As(err, &customErrors.Conflict):
+			return op.NewReviewBadgeClaimConflict().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusConflict),
+				Message: err.Error(),
+			})
+		default:
+			return op.NewReviewBadgeClaimInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewReviewBadgeClaimOK().WithPayload(response)
+}
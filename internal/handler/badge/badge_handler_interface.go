@@ -8,6 +8,27 @@ import (
 
 type IBadgeHandler interface {
 	GetBadgesByUserID(params badges.GetBadgesByUserIDParams) middleware.Responder
+	GetBadgeStats(params badges.GetBadgeStatsParams) middleware.Responder
 	AddBadge(params badges.AddBadgeParams) middleware.Responder
 	DeleteBadge(params badges.DeleteBadgeParams) middleware.Responder
+	GetBadgeTimeline(params badges.GetBadgeTimelineParams) middleware.Responder
+	GetClosestBadges(params badges.GetClosestBadgesParams) middleware.Responder
+	CreateBadge(params badges.CreateBadgeParams) middleware.Responder
+	EditBadge(params badges.EditBadgeParams) middleware.Responder
+	ReparentBadge(params badges.ReparentBadgeParams) middleware.Responder
+	RetireBadge(params badges.RetireBadgeParams) middleware.Responder
+	ReorderBadges(params badges.ReorderBadgesParams) middleware.Responder
+	UploadBadgeImage(params badges.UploadBadgeImageParams) middleware.Responder
+	GetBadgeAudit(params badges.GetBadgeAuditParams) middleware.Responder
+	SetBadgeRule(params badges.SetBadgeRuleParams) middleware.Responder
+	DeleteBadgeRule(params badges.DeleteBadgeRuleParams) middleware.Responder
+	GetBadgeTranslations(params badges.GetBadgeTranslationsParams) middleware.Responder
+	SetBadgeTranslation(params badges.SetBadgeTranslationParams) middleware.Responder
+	DeleteBadgeTranslation(params badges.DeleteBadgeTranslationParams) middleware.Responder
+	GrantBadge(params badges.GrantBadgeParams) middleware.Responder
+	ClaimBadge(params badges.ClaimBadgeParams) middleware.Responder
+	GetBadgeClaims(params badges.GetBadgeClaimsParams) middleware.Responder
+	VouchBadgeClaim(params badges.VouchBadgeClaimParams) middleware.Responder
+	GetPendingBadgeClaims(params badges.GetPendingBadgeClaimsParams) middleware.Responder
+	ReviewBadgeClaim(params badges.ReviewBadgeClaimParams) middleware.Responder
 }
@@ -7,6 +7,7 @@ import (
 	"gym-badges-api/models"
 	op "gym-badges-api/restapi/operations/badges"
 	toolsTesting "gym-badges-api/tools/testing"
+	"gym-badges-api/tools/utils"
 	"net/http"
 	"testing"
 
@@ -59,7 +60,7 @@ var _ = Describe("HANDLER: Badge Test Suite", func() {
 
 		DescribeTable("Checking get badges by user_id handler cases", func(input Params) {
 
-			mockBadgeService.EXPECT().GetBadgesByUserID(gomock.Any(), gomock.Any()).
+			mockBadgeService.EXPECT().GetBadgesByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 				Times(1).
 				Return(input.ServiceResponse, input.ServiceError)
 
@@ -135,4 +136,494 @@ var _ = Describe("HANDLER: Badge Test Suite", func() {
 
 	})
 
+	Context("POST /badges/{user_id}", func() {
+
+		var (
+			params op.AddBadgeParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewAddBadgeParams()
+			params.HTTPRequest = new(http.Request)
+			params.UserID = "admin"
+			params.AuthUserID = "admin"
+			params.Input = &models.AddDeleteBadgeRequest{BadgeID: 2}
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceError     error
+		}
+
+		DescribeTable("Checking add badge handler cases", func(input Params) {
+
+			mockBadgeService.EXPECT().AddBadge("admin", int16(2), gomock.Any()).
+				Times(1).
+				Return(input.ServiceError)
+
+			response := handler.AddBadge(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewAddBadgeOK(),
+				ServiceError:     nil,
+			}),
+			Entry("CASE: Forbidden Error Response (403)", Params{
+				ExpectedResponse: op.NewAddBadgeForbidden().WithPayload(&models.GenericResponse{
+					Code:    "403",
+					Message: "Forbidden",
+				}),
+				ServiceError: customErrors.BuildForbiddenError("forbidden"),
+			}),
+			Entry("CASE: Conflict Error Response (409)", Params{
+				ExpectedResponse: op.NewAddBadgeConflict().WithPayload(&models.GenericResponse{
+					Code:    "409",
+					Message: "Badge 2 already achieved.",
+				}),
+				ServiceError: customErrors.BuildConflictError("Badge 2 already achieved."),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewAddBadgeInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceError: errors.New("panic"),
+			}),
+		)
+
+	})
+
+	Context("GET /badges/{user_id}/timeline", func() {
+
+		var (
+			params op.GetBadgeTimelineParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewGetBadgeTimelineParams()
+			params.HTTPRequest = new(http.Request)
+			params.AuthUserID = "admin"
+			params.UserID = "user"
+			params.Page = 1
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.BadgeTimelineResponse
+			ServiceError     error
+		}
+
+		DescribeTable("Checking get badge timeline handler cases", func(input Params) {
+
+			mockBadgeService.EXPECT().GetBadgeTimeline("user", int32(1), gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.GetBadgeTimeline(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewGetBadgeTimelineOK().WithPayload(&models.BadgeTimelineResponse{
+					Awards: []*models.BadgeAward{{BadgeID: 2, Name: "Badge 2", Source: "auto", ExpGranted: 200}},
+				}),
+				ServiceResponse: &models.BadgeTimelineResponse{
+					Awards: []*models.BadgeAward{{BadgeID: 2, Name: "Badge 2", Source: "auto", ExpGranted: 200}},
+				},
+				ServiceError: nil,
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewGetBadgeTimelineNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildNotFoundError("User not found"),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewGetBadgeTimelineInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    errors.New("panic"),
+			}),
+		)
+
+	})
+
+	Context("GET /badges/{user_id}/closest", func() {
+
+		var (
+			params op.GetClosestBadgesParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewGetClosestBadgesParams()
+			params.HTTPRequest = new(http.Request)
+			params.AuthUserID = "admin"
+			params.UserID = "user"
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.ClosestBadgesResponse
+			ServiceError     error
+		}
+
+		DescribeTable("Checking get closest badges handler cases", func(input Params) {
+
+			mockBadgeService.EXPECT().GetClosestBadges("user", gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.GetClosestBadges(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewGetClosestBadgesOK().WithPayload(&models.ClosestBadgesResponse{
+					Badges: []*models.Badge{{ID: 2, Name: "Badge 2", Progress: &models.BadgeProgress{Current: 37, Target: 100, Percent: 37, Unit: "gym sessions"}}},
+				}),
+				ServiceResponse: &models.ClosestBadgesResponse{
+					Badges: []*models.Badge{{ID: 2, Name: "Badge 2", Progress: &models.BadgeProgress{Current: 37, Target: 100, Percent: 37, Unit: "gym sessions"}}},
+				},
+				ServiceError: nil,
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewGetClosestBadgesNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildNotFoundError("User not found"),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewGetClosestBadgesInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    errors.New("panic"),
+			}),
+		)
+
+	})
+
+	Context("GET /badge-stats", func() {
+
+		var (
+			params op.GetBadgeStatsParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewGetBadgeStatsParams()
+			params.HTTPRequest = new(http.Request)
+			params.AuthUserID = "admin"
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.BadgeStatsResponse
+			ServiceError     error
+		}
+
+		DescribeTable("Checking get badge stats handler cases", func(input Params) {
+
+			mockBadgeService.EXPECT().GetBadgeStats(gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.GetBadgeStats(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewGetBadgeStatsOK().WithPayload(&models.BadgeStatsResponse{
+					ActiveUsers: 8,
+					Badges:      []*models.BadgeStats{{BadgeID: 3, Holders: 1, ActivePercentage: 12.5}},
+				}),
+				ServiceResponse: &models.BadgeStatsResponse{
+					ActiveUsers: 8,
+					Badges:      []*models.BadgeStats{{BadgeID: 3, Holders: 1, ActivePercentage: 12.5}},
+				},
+				ServiceError: nil,
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewGetBadgeStatsInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    errors.New("panic"),
+			}),
+		)
+
+	})
+
+	Context("PUT /admin/badges/{badge_id}/parent", func() {
+
+		var (
+			params op.ReparentBadgeParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewReparentBadgeParams()
+			params.HTTPRequest = new(http.Request)
+			params.AuthUserID = "admin"
+			params.BadgeID = 2
+			params.Input = &models.ReparentBadgeRequest{}
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.AdminBadge
+			ServiceError     error
+		}
+
+		DescribeTable("Checking reparent badge handler cases", func(input Params) {
+
+			// A missing parent moves the badge to the root
+			mockBadgeService.EXPECT().ReparentBadge("admin", int16(2), int16(0), gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.ReparentBadge(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewReparentBadgeOK().WithPayload(&models.AdminBadge{ID: 2, Name: "Badge 2"}),
+				ServiceResponse:  &models.AdminBadge{ID: 2, Name: "Badge 2"},
+				ServiceError:     nil,
+			}),
+			Entry("CASE: Bad Request Error Response (400)", Params{
+				ExpectedResponse: op.NewReparentBadgeBadRequest().WithPayload(&models.GenericResponse{
+					Code:    "400",
+					Message: "Badge 2 cannot be below itself.",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildBadRequestError("Badge 2 cannot be below itself."),
+			}),
+			Entry("CASE: Forbidden Error Response (403)", Params{
+				ExpectedResponse: op.NewReparentBadgeForbidden().WithPayload(&models.GenericResponse{
+					Code:    "403",
+					Message: "Forbidden",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildForbiddenError("forbidden"),
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewReparentBadgeNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildNotFoundError("not found"),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewReparentBadgeInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    errors.New("panic"),
+			}),
+		)
+
+	})
+
+	Context("PUT /admin/badges/{badge_id}/translations/{locale}", func() {
+
+		var (
+			params op.SetBadgeTranslationParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewSetBadgeTranslationParams()
+			params.HTTPRequest = new(http.Request)
+			params.AuthUserID = "admin"
+			params.BadgeID = 1
+			params.Locale = "es"
+			params.Input = &models.BadgeTranslationRequest{Name: utils.NewString("Haz cinco flexiones")}
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.BadgeTranslation
+			ServiceError     error
+		}
+
+		DescribeTable("Checking set badge translation handler cases", func(input Params) {
+
+			mockBadgeService.EXPECT().SetBadgeTranslation("admin", int16(1), "es", params.Input, gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.SetBadgeTranslation(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewSetBadgeTranslationOK().WithPayload(&models.BadgeTranslation{Locale: "es", Name: "Haz cinco flexiones"}),
+				ServiceResponse:  &models.BadgeTranslation{Locale: "es", Name: "Haz cinco flexiones"},
+				ServiceError:     nil,
+			}),
+			Entry("CASE: Bad Request Error Response (400)", Params{
+				ExpectedResponse: op.NewSetBadgeTranslationBadRequest().WithPayload(&models.GenericResponse{
+					Code:    "400",
+					Message: "Locale es is not supported.",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildBadRequestError("Locale es is not supported."),
+			}),
+			Entry("CASE: Forbidden Error Response (403)", Params{
+				ExpectedResponse: op.NewSetBadgeTranslationForbidden().WithPayload(&models.GenericResponse{
+					Code:    "403",
+					Message: "Forbidden",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildForbiddenError("forbidden"),
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewSetBadgeTranslationNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildNotFoundError("not found"),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewSetBadgeTranslationInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    errors.New("panic"),
+			}),
+		)
+
+	})
+
+	Context("DELETE /admin/badges/{badge_id}", func() {
+
+		var (
+			params op.RetireBadgeParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewRetireBadgeParams()
+			params.HTTPRequest = new(http.Request)
+			params.AuthUserID = "admin"
+			params.BadgeID = 2
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceError     error
+		}
+
+		DescribeTable("Checking retire badge handler cases", func(input Params) {
+
+			mockBadgeService.EXPECT().RetireBadge("admin", int16(2), gomock.Any()).
+				Times(1).
+				Return(input.ServiceError)
+
+			response := handler.RetireBadge(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewRetireBadgeOK(),
+				ServiceError:     nil,
+			}),
+			Entry("CASE: Forbidden Error Response (403)", Params{
+				ExpectedResponse: op.NewRetireBadgeForbidden().WithPayload(&models.GenericResponse{
+					Code:    "403",
+					Message: "Forbidden",
+				}),
+				ServiceError: customErrors.BuildForbiddenError("forbidden"),
+			}),
+			Entry("CASE: Conflict Error Response (409)", Params{
+				ExpectedResponse: op.NewRetireBadgeConflict().WithPayload(&models.GenericResponse{
+					Code:    "409",
+					Message: "Badge 2 cannot be retired while badge 4 depends on it.",
+				}),
+				ServiceError: customErrors.BuildConflictError("Badge 2 cannot be retired while badge 4 depends on it."),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewRetireBadgeInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceError: errors.New("panic"),
+			}),
+		)
+
+	})
+
+	Context("PUT /admin/badge-claims/{claim_id}", func() {
+
+		var (
+			params op.ReviewBadgeClaimParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewReviewBadgeClaimParams()
+			params.HTTPRequest = new(http.Request)
+			params.AuthUserID = "moderator"
+			params.ClaimID = 7
+			params.Input = &models.ReviewBadgeClaimRequest{Approve: utils.NewBool(false)}
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.BadgeClaim
+			ServiceError     error
+		}
+
+		DescribeTable("Checking review badge claim handler cases", func(input Params) {
+
+			mockBadgeService.EXPECT().ReviewBadgeClaim("moderator", int64(7), false, gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.ReviewBadgeClaim(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewReviewBadgeClaimOK().WithPayload(&models.BadgeClaim{ID: 7, Status: "rejected"}),
+				ServiceResponse:  &models.BadgeClaim{ID: 7, Status: "rejected"},
+				ServiceError:     nil,
+			}),
+			Entry("CASE: Forbidden Error Response (403)", Params{
+				ExpectedResponse: op.NewReviewBadgeClaimForbidden().WithPayload(&models.GenericResponse{
+					Code:    "403",
+					Message: "Forbidden",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildForbiddenError("forbidden"),
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewReviewBadgeClaimNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildNotFoundError("Badge claim not found"),
+			}),
+			Entry("CASE: Conflict Error Response (409)", Params{
+				ExpectedResponse: op.NewReviewBadgeClaimConflict().WithPayload(&models.GenericResponse{
+					Code:    "409",
+					Message: "The claim is already rejected.",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildConflictError("The claim is already rejected."),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewReviewBadgeClaimInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    errors.New("panic"),
+			}),
+		)
+
+	})
+
 })
@@ -0,0 +1,166 @@
+package exercise_handler
+
+import (
+	"errors"
+	"fmt"
+	customErrors "gym-badges-api/internal/custom-errors"
+	exerciseService "gym-badges-api/internal/service/exercise"
+	"gym-badges-api/models"
+	op "gym-badges-api/restapi/operations/exercises"
+	toolsLogging "gym-badges-api/tools/logging"
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+var (
+	unauthorizedErrorResponse = models.GenericResponse{
+		Code:    fmt.Sprint(http.StatusUnauthorized),
+		Message: http.StatusText(http.StatusUnauthorized),
+	}
+
+	notFoundErrorResponse = models.GenericResponse{
+		Code:    fmt.Sprint(http.StatusNotFound),
+		Message: http.StatusText(http.StatusNotFound),
+	}
+
+	internalServerErrorResponse = models.GenericResponse{
+		Code:    fmt.Sprint(http.StatusInternalServerError),
+		Message: http.StatusText(http.StatusInternalServerError),
+	}
+)
+
+func NewExerciseHandler(exerciseService exerciseService.IExerciseService) IExerciseHandler {
+	return &exerciseHandler{
+		exerciseService: exerciseService,
+	}
+}
+
+type exerciseHandler struct {
+	exerciseService exerciseService.IExerciseService
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func (h exerciseHandler) SearchExercises(params op.SearchExercisesParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("EXERCISE_HANDLER: Searching exercises for user: %s page: %d", params.UserID, params.Page)
+
+	// Custom exercises are only visible to their creator
+	if params.AuthUserID != params.UserID {
+		return op.NewSearchExercisesUnauthorized().WithPayload(&unauthorizedErrorResponse)
+	}
+
+	var category int32
+	if params.Category != nil {
+		category = *params.Category
+	}
+
+	response, err := h.exerciseService.SearchExercises(params.UserID, valueOrEmpty(params.Name),
+		valueOrEmpty(params.MuscleGroup), valueOrEmpty(params.Equipment), category, params.Page, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewSearchExercisesUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		default:
+			return op.NewSearchExercisesInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewSearchExercisesOK().WithPayload(response)
+}
+
+func (h exerciseHandler) GetExercise(params op.GetExerciseParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("EXERCISE_HANDLER: Getting exercise %d for user: %s", params.ExerciseID, params.UserID)
+
+	// Custom exercises are only visible to their creator
+	if params.AuthUserID != params.UserID {
+		return op.NewGetExerciseUnauthorized().WithPayload(&unauthorizedErrorResponse)
+	}
+
+	response, err := h.exerciseService.GetExercise(params.UserID, params.ExerciseID, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewGetExerciseUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewGetExerciseNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewGetExerciseInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGetExerciseOK().WithPayload(response)
+}
+
+func (h exerciseHandler) AddExercise(params op.AddExerciseParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("EXERCISE_HANDLER: Adding custom exercise to user: %s", params.UserID)
+
+	// An user can only add custom exercises to himself
+	if params.AuthUserID != params.UserID {
+		return op.NewAddExerciseUnauthorized().WithPayload(&unauthorizedErrorResponse)
+	}
+
+	response, err := h.exerciseService.CreateExercise(params.UserID, params.Input, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.BadRequest):
+			return op.NewAddExerciseBadRequest().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusBadRequest),
+				Message: err.Error(),
+			})
+		case errors.As(err, &customErrors.Conflict):
+			return op.NewAddExerciseConflict().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusConflict),
+				Message: err.Error(),
+			})
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewAddExerciseUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewAddExerciseNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewAddExerciseInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewAddExerciseCreated().WithPayload(response)
+}
+
+func (h exerciseHandler) DeleteExercise(params op.DeleteExerciseParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("EXERCISE_HANDLER: Deleting exercise %d of user: %s", params.ExerciseID, params.UserID)
+
+	// An user can only delete his own custom exercises
+	if params.AuthUserID != params.UserID {
+		return op.NewDeleteExerciseUnauthorized().WithPayload(&unauthorizedErrorResponse)
+	}
+
+	err := h.exerciseService.DeleteExercise(params.UserID, params.ExerciseID, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewDeleteExerciseUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewDeleteExerciseNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewDeleteExerciseInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewDeleteExerciseOK()
+}
@@ -0,0 +1,14 @@
+package exercise_handler
+
+import (
+	"gym-badges-api/restapi/operations/exercises"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+type IExerciseHandler interface {
+	SearchExercises(params exercises.SearchExercisesParams) middleware.Responder
+	GetExercise(params exercises.GetExerciseParams) middleware.Responder
+	AddExercise(params exercises.AddExerciseParams) middleware.Responder
+	DeleteExercise(params exercises.DeleteExerciseParams) middleware.Responder
+}
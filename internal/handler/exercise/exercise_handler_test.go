@@ -0,0 +1,187 @@
+package exercise_handler
+
+import (
+	"errors"
+	customErrors "gym-badges-api/internal/custom-errors"
+	"gym-badges-api/mocks/service"
+	"gym-badges-api/models"
+	op "gym-badges-api/restapi/operations/exercises"
+	toolsTesting "gym-badges-api/tools/testing"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandlerExerciseSuite(t *testing.T) {
+	toolsTesting.ConfigureTestSuite(t, "HANDLER: Exercise Test Suite")
+}
+
+var _ = Describe("HANDLER: Exercise Test Suite", func() {
+
+	var (
+		mockCtrl            *gomock.Controller
+		mockExerciseService *service.MockIExerciseService
+		handler             IExerciseHandler
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockExerciseService = service.NewMockIExerciseService(mockCtrl)
+
+		handler = NewExerciseHandler(mockExerciseService)
+	})
+
+	AfterEach(func() {
+		defer mockCtrl.Finish()
+
+	})
+
+	Context("GET /exercises/{user_id}", func() {
+
+		var (
+			params op.SearchExercisesParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewSearchExercisesParams()
+			params.HTTPRequest = new(http.Request)
+			params.UserID = "admin"
+			params.AuthUserID = "admin"
+			params.Page = 1
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.ExercisesResponse
+			ServiceError     error
+		}
+
+		DescribeTable("Checking search exercises handler cases", func(input Params) {
+
+			mockExerciseService.EXPECT().SearchExercises(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.SearchExercises(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewSearchExercisesOK().WithPayload(&models.ExercisesResponse{
+					Exercises: []*models.Exercise{
+						{
+							ID:           21,
+							Name:         "Squat",
+							MuscleGroups: []string{"quadriceps", "glutes"},
+							Equipment:    "barbell",
+							Category:     -4,
+							CategoryName: "legs",
+						},
+					},
+				}),
+				ServiceResponse: &models.ExercisesResponse{
+					Exercises: []*models.Exercise{
+						{
+							ID:           21,
+							Name:         "Squat",
+							MuscleGroups: []string{"quadriceps", "glutes"},
+							Equipment:    "barbell",
+							Category:     -4,
+							CategoryName: "legs",
+						},
+					},
+				},
+				ServiceError: nil,
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewSearchExercisesInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    errors.New("panic"),
+			}),
+		)
+
+	})
+
+	Context("POST /exercises/{user_id}", func() {
+
+		var (
+			params op.AddExerciseParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewAddExerciseParams()
+			params.HTTPRequest = new(http.Request)
+			params.UserID = "admin"
+			params.AuthUserID = "admin"
+			params.Input = &models.CreateExerciseRequest{Name: "Zercher squat"}
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.Exercise
+			ServiceError     error
+		}
+
+		DescribeTable("Checking add exercise handler cases", func(input Params) {
+
+			mockExerciseService.EXPECT().CreateExercise(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.AddExercise(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Created Response (201)", Params{
+				ExpectedResponse: op.NewAddExerciseCreated().WithPayload(&models.Exercise{
+					ID:     40,
+					Name:   "Zercher squat",
+					Custom: true,
+				}),
+				ServiceResponse: &models.Exercise{
+					ID:     40,
+					Name:   "Zercher squat",
+					Custom: true,
+				},
+				ServiceError: nil,
+			}),
+			Entry("CASE: Bad Request Error Response (400)", Params{
+				ExpectedResponse: op.NewAddExerciseBadRequest().WithPayload(&models.GenericResponse{
+					Code:    "400",
+					Message: "At least one muscle group is required.",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildBadRequestError("At least one muscle group is required."),
+			}),
+			Entry("CASE: Conflict Error Response (409)", Params{
+				ExpectedResponse: op.NewAddExerciseConflict().WithPayload(&models.GenericResponse{
+					Code:    "409",
+					Message: "exercise Zercher squat already exists",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildConflictError("exercise %s already exists", "Zercher squat"),
+			}),
+		)
+
+		It("CASE: Unauthorized Error Response (401) when adding to another user", func() {
+
+			params.AuthUserID = "other"
+
+			mockExerciseService.EXPECT().CreateExercise(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			response := handler.AddExercise(params)
+			Expect(response).To(BeEquivalentTo(op.NewAddExerciseUnauthorized().WithPayload(&models.GenericResponse{
+				Code:    "401",
+				Message: "Unauthorized",
+			})))
+		})
+
+	})
+
+})
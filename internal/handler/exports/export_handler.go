@@ -0,0 +1,153 @@
+package exports_handler
+
+import (
+	"errors"
+	"fmt"
+	customErrors "gym-badges-api/internal/custom-errors"
+	exportService "gym-badges-api/internal/service/exports"
+	"gym-badges-api/models"
+	op "gym-badges-api/restapi/operations/exports"
+	toolsLogging "gym-badges-api/tools/logging"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+var (
+	unauthorizedErrorResponse = models.GenericResponse{
+		Code:    fmt.Sprint(http.StatusUnauthorized),
+		Message: http.StatusText(http.StatusUnauthorized),
+	}
+
+	notFoundErrorResponse = models.GenericResponse{
+		Code:    fmt.Sprint(http.StatusNotFound),
+		Message: http.StatusText(http.StatusNotFound),
+	}
+
+	internalServerErrorResponse = models.GenericResponse{
+		Code:    fmt.Sprint(http.StatusInternalServerError),
+		Message: http.StatusText(http.StatusInternalServerError),
+	}
+)
+
+func NewExportHandler(exportService exportService.IExportService) IExportHandler {
+	return &exportHandler{
+		exportService: exportService,
+	}
+}
+
+type exportHandler struct {
+	exportService exportService.IExportService
+}
+
+func (h exportHandler) ExportCSV(params op.ExportCSVParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("EXPORT_HANDLER: Exporting %s of user: %s", params.Data, params.UserID)
+
+	// An user can only export his own data
+	if params.AuthUserID != params.UserID {
+		return op.NewExportCSVUnauthorized().WithPayload(&unauthorizedErrorResponse)
+	}
+
+	var from, to time.Time
+	if params.From != nil {
+		from = time.Time(*params.From)
+	}
+	if params.To != nil {
+		to = time.Time(*params.To)
+	}
+
+	response, err := h.exportService.ExportCSV(params.UserID, params.Data, from, to, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.BadRequest):
+			return op.NewExportCSVBadRequest().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusBadRequest),
+				Message: err.Error(),
+			})
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewExportCSVUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewExportCSVNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewExportCSVInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewExportCSVOK().WithPayload(response)
+}
+
+func (h exportHandler) GetCalendarSubscription(params op.GetCalendarSubscriptionParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("EXPORT_HANDLER: Getting calendar subscription of user: %s", params.UserID)
+
+	// The subscription URL is a secret of its owner
+	if params.AuthUserID != params.UserID {
+		return op.NewGetCalendarSubscriptionUnauthorized().WithPayload(&unauthorizedErrorResponse)
+	}
+
+	response, err := h.exportService.GetCalendarSubscription(params.UserID, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewGetCalendarSubscriptionUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewGetCalendarSubscriptionNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewGetCalendarSubscriptionInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGetCalendarSubscriptionOK().WithPayload(response)
+}
+
+func (h exportHandler) ResetCalendarSubscription(params op.ResetCalendarSubscriptionParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("EXPORT_HANDLER: Resetting calendar subscription of user: %s", params.UserID)
+
+	// The subscription URL is a secret of its owner
+	if params.AuthUserID != params.UserID {
+		return op.NewResetCalendarSubscriptionUnauthorized().WithPayload(&unauthorizedErrorResponse)
+	}
+
+	response, err := h.exportService.ResetCalendarSubscription(params.UserID, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewResetCalendarSubscriptionUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewResetCalendarSubscriptionNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewResetCalendarSubscriptionInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewResetCalendarSubscriptionOK().WithPayload(response)
+}
+
+func (h exportHandler) GetAttendanceCalendar(params op.GetAttendanceCalendarParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	// The token is a credential, so it is not logged
+	ctxLog.Infof("EXPORT_HANDLER: Getting attendance calendar feed")
+
+	response, err := h.exportService.GetAttendanceCalendar(params.Token, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewGetAttendanceCalendarNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewGetAttendanceCalendarInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGetAttendanceCalendarOK().WithPayload(response)
+}
@@ -0,0 +1,14 @@
+package exports_handler
+
+import (
+	"gym-badges-api/restapi/operations/exports"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+type IExportHandler interface {
+	ExportCSV(params exports.ExportCSVParams) middleware.Responder
+	GetCalendarSubscription(params exports.GetCalendarSubscriptionParams) middleware.Responder
+	ResetCalendarSubscription(params exports.ResetCalendarSubscriptionParams) middleware.Responder
+	GetAttendanceCalendar(params exports.GetAttendanceCalendarParams) middleware.Responder
+}
@@ -0,0 +1,305 @@
+package exports_handler
+
+import (
+	"errors"
+	customErrors "gym-badges-api/internal/custom-errors"
+	"gym-badges-api/mocks/service"
+	"gym-badges-api/models"
+	op "gym-badges-api/restapi/operations/exports"
+	toolsTesting "gym-badges-api/tools/testing"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandlerExportSuite(t *testing.T) {
+	toolsTesting.ConfigureTestSuite(t, "HANDLER: Export Test Suite")
+}
+
+var _ = Describe("HANDLER: Export Test Suite", func() {
+
+	var (
+		mockCtrl          *gomock.Controller
+		mockExportService *service.MockIExportService
+		handler           IExportHandler
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockExportService = service.NewMockIExportService(mockCtrl)
+
+		handler = NewExportHandler(mockExportService)
+	})
+
+	AfterEach(func() {
+		defer mockCtrl.Finish()
+
+	})
+
+	Context("GET /exports/{user_id}/csv/{data}", func() {
+
+		var (
+			params op.ExportCSVParams
+			from   time.Time
+		)
+
+		BeforeEach(func() {
+			from = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+			date := strfmt.Date(from)
+
+			params = op.NewExportCSVParams()
+			params.HTTPRequest = new(http.Request)
+			params.UserID = "admin"
+			params.AuthUserID = "admin"
+			params.Data = "weight"
+			params.From = &date
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  string
+			ServiceError     error
+		}
+
+		DescribeTable("Checking export CSV handler cases", func(input Params) {
+
+			mockExportService.EXPECT().ExportCSV("admin", "weight", from, time.Time{}, gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.ExportCSV(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewExportCSVOK().WithPayload("date,weight\n2024-03-01,80\n"),
+				ServiceResponse:  "date,weight\n2024-03-01,80\n",
+				ServiceError:     nil,
+			}),
+			Entry("CASE: Bad Request Error Response (400)", Params{
+				ExpectedResponse: op.NewExportCSVBadRequest().WithPayload(&models.GenericResponse{
+					Code:    "400",
+					Message: "The range cannot end before it starts.",
+				}),
+				ServiceError: customErrors.BuildBadRequestError("The range cannot end before it starts."),
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewExportCSVNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceError: customErrors.BuildNotFoundError("not found"),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewExportCSVInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceError: errors.New("panic"),
+			}),
+		)
+
+		It("CASE: Unauthorized Error Response (401) when exporting another user", func() {
+
+			params.AuthUserID = "other"
+
+			mockExportService.EXPECT().ExportCSV(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			response := handler.ExportCSV(params)
+			Expect(response).To(BeEquivalentTo(op.NewExportCSVUnauthorized().WithPayload(&models.GenericResponse{
+				Code:    "401",
+				Message: "Unauthorized",
+			})))
+		})
+
+	})
+
+	Context("GET /exports/{user_id}/calendar", func() {
+
+		var (
+			params op.GetCalendarSubscriptionParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewGetCalendarSubscriptionParams()
+			params.HTTPRequest = new(http.Request)
+			params.UserID = "admin"
+			params.AuthUserID = "admin"
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.CalendarSubscription
+			ServiceError     error
+		}
+
+		DescribeTable("Checking get calendar subscription handler cases", func(input Params) {
+
+			mockExportService.EXPECT().GetCalendarSubscription("admin", gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.GetCalendarSubscription(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewGetCalendarSubscriptionOK().WithPayload(&models.CalendarSubscription{
+					URL: "http://localhost:8080/calendar/secret/attendance.ics",
+				}),
+				ServiceResponse: &models.CalendarSubscription{
+					URL: "http://localhost:8080/calendar/secret/attendance.ics",
+				},
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewGetCalendarSubscriptionNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceError: customErrors.BuildNotFoundError("not found"),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewGetCalendarSubscriptionInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceError: errors.New("panic"),
+			}),
+		)
+
+		It("CASE: Unauthorized Error Response (401) when getting the URL of another user", func() {
+
+			params.AuthUserID = "other"
+
+			mockExportService.EXPECT().GetCalendarSubscription(gomock.Any(), gomock.Any()).Times(0)
+
+			response := handler.GetCalendarSubscription(params)
+			Expect(response).To(BeEquivalentTo(op.NewGetCalendarSubscriptionUnauthorized().WithPayload(&models.GenericResponse{
+				Code:    "401",
+				Message: "Unauthorized",
+			})))
+		})
+
+	})
+
+	Context("POST /exports/{user_id}/calendar", func() {
+
+		var (
+			params op.ResetCalendarSubscriptionParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewResetCalendarSubscriptionParams()
+			params.HTTPRequest = new(http.Request)
+			params.UserID = "admin"
+			params.AuthUserID = "admin"
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.CalendarSubscription
+			ServiceError     error
+		}
+
+		DescribeTable("Checking reset calendar subscription handler cases", func(input Params) {
+
+			mockExportService.EXPECT().ResetCalendarSubscription("admin", gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.ResetCalendarSubscription(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewResetCalendarSubscriptionOK().WithPayload(&models.CalendarSubscription{
+					URL: "http://localhost:8080/calendar/new/attendance.ics",
+				}),
+				ServiceResponse: &models.CalendarSubscription{
+					URL: "http://localhost:8080/calendar/new/attendance.ics",
+				},
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewResetCalendarSubscriptionNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceError: customErrors.BuildNotFoundError("not found"),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewResetCalendarSubscriptionInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceError: errors.New("panic"),
+			}),
+		)
+
+		It("CASE: Unauthorized Error Response (401) when resetting the URL of another user", func() {
+
+			params.AuthUserID = "other"
+
+			mockExportService.EXPECT().ResetCalendarSubscription(gomock.Any(), gomock.Any()).Times(0)
+
+			response := handler.ResetCalendarSubscription(params)
+			Expect(response).To(BeEquivalentTo(op.NewResetCalendarSubscriptionUnauthorized().WithPayload(&models.GenericResponse{
+				Code:    "401",
+				Message: "Unauthorized",
+			})))
+		})
+
+	})
+
+	Context("GET /calendar/{token}/attendance.ics", func() {
+
+		var (
+			params op.GetAttendanceCalendarParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewGetAttendanceCalendarParams()
+			params.HTTPRequest = new(http.Request)
+			params.Token = "secret"
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  string
+			ServiceError     error
+		}
+
+		DescribeTable("Checking attendance calendar handler cases", func(input Params) {
+
+			mockExportService.EXPECT().GetAttendanceCalendar("secret", gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.GetAttendanceCalendar(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewGetAttendanceCalendarOK().WithPayload("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"),
+				ServiceResponse:  "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n",
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewGetAttendanceCalendarNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceError: customErrors.BuildNotFoundError("not found"),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewGetAttendanceCalendarInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceError: errors.New("panic"),
+			}),
+		)
+
+	})
+
+})
@@ -0,0 +1,101 @@
+package files_handler
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Upload Directory of uploaded files served under a URL path. Only the files with a known extension are served,
+// with the content type of the extension
+type Upload struct {
+	Path  string
+	Dir   string
+	Types map[string]string // Content type by extension, without the dot
+}
+
+var (
+	// ImageTypes Formats of the uploaded badge images
+	ImageTypes = map[string]string{
+		"png": "image/png",
+		"jpg": "image/jpeg",
+	}
+
+	// ProofTypes Formats of the uploaded proofs of the badge claims
+	ProofTypes = map[string]string{
+		"png":  "image/png",
+		"jpg":  "image/jpeg",
+		"mp4":  "video/mp4",
+		"webm": "video/webm",
+	}
+)
+
+func NewFilesHandler(uploads ...Upload) IFilesHandler {
+	return &filesHandler{
+		uploads: uploads,
+	}
+}
+
+type filesHandler struct {
+	uploads []Upload
+}
+
+func (h filesHandler) Middleware(next http.Handler) http.Handler {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		for _, upload := range h.uploads {
+
+			prefix := strings.TrimSuffix(upload.Path, "/") + "/"
+			if !strings.HasPrefix(r.URL.Path, prefix) {
+				continue
+			}
+
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				w.Header().Set("Allow", "GET, HEAD")
+				http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+				return
+			}
+
+			serveUpload(w, r, upload, strings.TrimPrefix(r.URL.Path, prefix))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// serveUpload writes the file of the upload directory. The name cannot leave the directory
+func serveUpload(w http.ResponseWriter, r *http.Request, upload Upload, name string) {
+
+	contentType, ok := upload.Types[strings.TrimPrefix(filepath.Ext(name), ".")]
+	if !ok || name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		http.NotFound(w, r)
+		return
+	}
+
+	file, err := os.Open(filepath.Join(upload.Dir, name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			http.NotFound(w, r)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		http.NotFound(w, r)
+		return
+	}
+
+	// The stored extension sets the type, so the browsers never sniff the content
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	http.ServeContent(w, r, name, info.ModTime(), file)
+}
@@ -0,0 +1,10 @@
+package files_handler
+
+import (
+	"net/http"
+)
+
+type IFilesHandler interface {
+	// Serves the uploaded files under their URL paths, and passes any other request to the next handler
+	Middleware(next http.Handler) http.Handler
+}
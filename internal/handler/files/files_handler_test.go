@@ -0,0 +1,65 @@
+package files_handler
+
+import (
+	toolsTesting "gym-badges-api/tools/testing"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestHandlerFilesSuite(t *testing.T) {
+	toolsTesting.ConfigureTestSuite(t, "HANDLER: Files Test Suite")
+}
+
+var _ = Describe("HANDLER: Files Test Suite", func() {
+
+	var (
+		dir     string
+		handler http.Handler
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+
+		Expect(os.WriteFile(filepath.Join(dir, "2.png"), []byte("\x89PNG\r\n\x1a\n"), 0644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "3.svg"), []byte("<svg onload=alert(1)>"), 0644)).To(Succeed())
+
+		next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		})
+
+		handler = NewFilesHandler(Upload{Path: "/image/badge", Dir: dir, Types: ImageTypes}).Middleware(next)
+	})
+
+	serve := func(method string, path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(method, path, nil))
+		return recorder
+	}
+
+	It("CASE: Uploaded files are served with the type of their extension", func() {
+
+		response := serve(http.MethodGet, "/image/badge/2.png?v=1700000000")
+		Expect(response.Code).To(Equal(http.StatusOK))
+		Expect(response.Header().Get("Content-Type")).To(Equal("image/png"))
+		Expect(response.Header().Get("X-Content-Type-Options")).To(Equal("nosniff"))
+		Expect(response.Body.String()).To(Equal("\x89PNG\r\n\x1a\n"))
+	})
+
+	DescribeTable("Checking files that are not served", func(method string, path string, code int) {
+
+		Expect(serve(method, path).Code).To(Equal(code))
+	},
+		Entry("CASE: Unknown extension", http.MethodGet, "/image/badge/3.svg", http.StatusNotFound),
+		Entry("CASE: Missing file", http.MethodGet, "/image/badge/4.png", http.StatusNotFound),
+		Entry("CASE: Outside of the directory", http.MethodGet, "/image/badge/..%2F2.png", http.StatusNotFound),
+		Entry("CASE: Not a read", http.MethodPost, "/image/badge/2.png", http.StatusMethodNotAllowed),
+		Entry("CASE: Other paths go to the API", http.MethodGet, "/badges/admin", http.StatusTeapot),
+	)
+
+})
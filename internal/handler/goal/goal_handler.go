@@ -0,0 +1,192 @@
+package goal_handler
+
+import (
+	"errors"
+	"fmt"
+	"gym-badges-api/internal/constants"
+	customErrors "gym-badges-api/internal/custom-errors"
+	goalService "gym-badges-api/internal/service/goal"
+	"gym-badges-api/models"
+	op "gym-badges-api/restapi/operations/goals"
+	toolsLogging "gym-badges-api/tools/logging"
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+var (
+	unauthorizedErrorResponse = models.GenericResponse{
+		Code:    fmt.Sprint(http.StatusUnauthorized),
+		Message: http.StatusText(http.StatusUnauthorized),
+	}
+
+	notFoundErrorResponse = models.GenericResponse{
+		Code:    fmt.Sprint(http.StatusNotFound),
+		Message: http.StatusText(http.StatusNotFound),
+	}
+
+	internalServerErrorResponse = models.GenericResponse{
+		Code:    fmt.Sprint(http.StatusInternalServerError),
+		Message: http.StatusText(http.StatusInternalServerError),
+	}
+)
+
+func NewGoalHandler(goalService goalService.IGoalService) IGoalHandler {
+	return &goalHandler{
+		goalService: goalService,
+	}
+}
+
+type goalHandler struct {
+	goalService goalService.IGoalService
+}
+
+func (h goalHandler) GetGoals(params op.GetGoalsParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("GOAL_HANDLER: Getting goals for user: %s", params.UserID)
+
+	// Goals are only visible to their owner
+	if params.AuthUserID != params.UserID {
+		return op.NewGetGoalsUnauthorized().WithPayload(&unauthorizedErrorResponse)
+	}
+
+	status := constants.EmptyString
+	if params.Status != nil {
+		status = *params.Status
+	}
+
+	response, err := h.goalService.GetGoals(params.UserID, status, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewGetGoalsUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewGetGoalsNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewGetGoalsInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGetGoalsOK().WithPayload(response)
+}
+
+func (h goalHandler) GetGoal(params op.GetGoalParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("GOAL_HANDLER: Getting goal %d for user: %s", params.GoalID, params.UserID)
+
+	// Goals are only visible to their owner
+	if params.AuthUserID != params.UserID {
+		return op.NewGetGoalUnauthorized().WithPayload(&unauthorizedErrorResponse)
+	}
+
+	response, err := h.goalService.GetGoal(params.UserID, params.GoalID, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewGetGoalUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewGetGoalNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewGetGoalInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGetGoalOK().WithPayload(response)
+}
+
+func (h goalHandler) AddGoal(params op.AddGoalParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("GOAL_HANDLER: Adding goal to user: %s", params.UserID)
+
+	// An user can only set goals to himself
+	if params.AuthUserID != params.UserID {
+		return op.NewAddGoalUnauthorized().WithPayload(&unauthorizedErrorResponse)
+	}
+
+	response, err := h.goalService.CreateGoal(params.UserID, params.Input, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.BadRequest):
+			return op.NewAddGoalBadRequest().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusBadRequest),
+				Message: err.Error(),
+			})
+		case errors.As(err, &customErrors.Conflict):
+			return op.NewAddGoalConflict().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusConflict),
+				Message: err.Error(),
+			})
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewAddGoalUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewAddGoalNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewAddGoalInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewAddGoalCreated().WithPayload(response)
+}
+
+func (h goalHandler) EditGoal(params op.EditGoalParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("GOAL_HANDLER: Editing goal %d of user: %s", params.GoalID, params.UserID)
+
+	// An user can only edit his own goals
+	if params.AuthUserID != params.UserID {
+		return op.NewEditGoalUnauthorized().WithPayload(&unauthorizedErrorResponse)
+	}
+
+	response, err := h.goalService.EditGoal(params.UserID, params.GoalID, params.Input, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.BadRequest):
+			return op.NewEditGoalBadRequest().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusBadRequest),
+				Message: err.Error(),
+			})
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewEditGoalUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewEditGoalNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewEditGoalInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewEditGoalOK().WithPayload(response)
+}
+
+func (h goalHandler) DeleteGoal(params op.DeleteGoalParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("GOAL_HANDLER: Deleting goal %d of user: %s", params.GoalID, params.UserID)
+
+	// An user can only delete his own goals
+	if params.AuthUserID != params.UserID {
+		return op.NewDeleteGoalUnauthorized().WithPayload(&unauthorizedErrorResponse)
+	}
+
+	err := h.goalService.DeleteGoal(params.UserID, params.GoalID, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewDeleteGoalUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewDeleteGoalNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewDeleteGoalInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewDeleteGoalOK()
+}
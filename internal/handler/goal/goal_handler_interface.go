@@ -0,0 +1,15 @@
+package goal_handler
+
+import (
+	"gym-badges-api/restapi/operations/goals"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+type IGoalHandler interface {
+	GetGoals(params goals.GetGoalsParams) middleware.Responder
+	GetGoal(params goals.GetGoalParams) middleware.Responder
+	AddGoal(params goals.AddGoalParams) middleware.Responder
+	EditGoal(params goals.EditGoalParams) middleware.Responder
+	DeleteGoal(params goals.DeleteGoalParams) middleware.Responder
+}
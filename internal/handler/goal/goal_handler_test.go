@@ -0,0 +1,303 @@
+package goal_handler
+
+import (
+	"errors"
+	customErrors "gym-badges-api/internal/custom-errors"
+	"gym-badges-api/mocks/service"
+	"gym-badges-api/models"
+	op "gym-badges-api/restapi/operations/goals"
+	toolsTesting "gym-badges-api/tools/testing"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandlerGoalSuite(t *testing.T) {
+	toolsTesting.ConfigureTestSuite(t, "HANDLER: Goal Test Suite")
+}
+
+var _ = Describe("HANDLER: Goal Test Suite", func() {
+
+	var (
+		mockCtrl        *gomock.Controller
+		mockGoalService *service.MockIGoalService
+		handler         IGoalHandler
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockGoalService = service.NewMockIGoalService(mockCtrl)
+
+		handler = NewGoalHandler(mockGoalService)
+	})
+
+	AfterEach(func() {
+		defer mockCtrl.Finish()
+
+	})
+
+	Context("GET /goals/{user_id}", func() {
+
+		var (
+			params op.GetGoalsParams
+		)
+
+		BeforeEach(func() {
+			status := "active"
+			params = op.NewGetGoalsParams()
+			params.HTTPRequest = new(http.Request)
+			params.UserID = "admin"
+			params.AuthUserID = "admin"
+			params.Status = &status
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.GoalsResponse
+			ServiceError     error
+		}
+
+		DescribeTable("Checking get goals handler cases", func(input Params) {
+
+			mockGoalService.EXPECT().GetGoals(gomock.Any(), "active", gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.GetGoals(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewGetGoalsOK().WithPayload(&models.GoalsResponse{
+					Goals: []*models.Goal{
+						{ID: 1, Type: "weight", StartValue: 90, Target: 80, Progress: 60, Status: "active"},
+					},
+				}),
+				ServiceResponse: &models.GoalsResponse{
+					Goals: []*models.Goal{
+						{ID: 1, Type: "weight", StartValue: 90, Target: 80, Progress: 60, Status: "active"},
+					},
+				},
+				ServiceError: nil,
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewGetGoalsNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildNotFoundError("not found"),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewGetGoalsInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    errors.New("panic"),
+			}),
+		)
+
+		It("CASE: Unauthorized Error Response (401) when getting the goals of another user", func() {
+
+			params.AuthUserID = "other"
+
+			mockGoalService.EXPECT().GetGoals(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			response := handler.GetGoals(params)
+			Expect(response).To(BeEquivalentTo(op.NewGetGoalsUnauthorized().WithPayload(&models.GenericResponse{
+				Code:    "401",
+				Message: "Unauthorized",
+			})))
+		})
+
+	})
+
+	Context("POST /goals/{user_id}", func() {
+
+		var (
+			params op.AddGoalParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewAddGoalParams()
+			params.HTTPRequest = new(http.Request)
+			params.UserID = "admin"
+			params.AuthUserID = "admin"
+			params.Input = &models.GoalRequest{Type: "lift", Exercise: "Squat", Target: 140}
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.Goal
+			ServiceError     error
+		}
+
+		DescribeTable("Checking add goal handler cases", func(input Params) {
+
+			mockGoalService.EXPECT().CreateGoal(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.AddGoal(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Created Response (201)", Params{
+				ExpectedResponse: op.NewAddGoalCreated().WithPayload(&models.Goal{
+					ID:         2,
+					Type:       "lift",
+					Exercise:   "Squat",
+					StartValue: 120,
+					Target:     140,
+					Status:     "active",
+				}),
+				ServiceResponse: &models.Goal{
+					ID:         2,
+					Type:       "lift",
+					Exercise:   "Squat",
+					StartValue: 120,
+					Target:     140,
+					Status:     "active",
+				},
+				ServiceError: nil,
+			}),
+			Entry("CASE: Bad Request Error Response (400)", Params{
+				ExpectedResponse: op.NewAddGoalBadRequest().WithPayload(&models.GenericResponse{
+					Code:    "400",
+					Message: "Lift goals need an exercise.",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildBadRequestError("Lift goals need an exercise."),
+			}),
+			Entry("CASE: Conflict Error Response (409)", Params{
+				ExpectedResponse: op.NewAddGoalConflict().WithPayload(&models.GenericResponse{
+					Code:    "409",
+					Message: "There is already an active Squat goal.",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildConflictError("There is already an active %s goal.", "Squat"),
+			}),
+		)
+
+		It("CASE: Unauthorized Error Response (401) when adding to another user", func() {
+
+			params.AuthUserID = "other"
+
+			mockGoalService.EXPECT().CreateGoal(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			response := handler.AddGoal(params)
+			Expect(response).To(BeEquivalentTo(op.NewAddGoalUnauthorized().WithPayload(&models.GenericResponse{
+				Code:    "401",
+				Message: "Unauthorized",
+			})))
+		})
+
+	})
+
+	Context("PUT /goals/{user_id}/{goal_id}", func() {
+
+		var (
+			params op.EditGoalParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewEditGoalParams()
+			params.HTTPRequest = new(http.Request)
+			params.UserID = "admin"
+			params.AuthUserID = "admin"
+			params.GoalID = 1
+			params.Input = &models.EditGoalRequest{Target: 82, Status: "abandoned"}
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.Goal
+			ServiceError     error
+		}
+
+		DescribeTable("Checking edit goal handler cases", func(input Params) {
+
+			mockGoalService.EXPECT().EditGoal(gomock.Any(), int64(1), gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.EditGoal(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewEditGoalOK().WithPayload(&models.Goal{
+					ID: 1, Type: "weight", StartValue: 90, Target: 82, Status: "abandoned",
+				}),
+				ServiceResponse: &models.Goal{
+					ID: 1, Type: "weight", StartValue: 90, Target: 82, Status: "abandoned",
+				},
+				ServiceError: nil,
+			}),
+			Entry("CASE: Bad Request Error Response (400)", Params{
+				ExpectedResponse: op.NewEditGoalBadRequest().WithPayload(&models.GenericResponse{
+					Code:    "400",
+					Message: "Achieved goals cannot be changed.",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildBadRequestError("Achieved goals cannot be changed."),
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewEditGoalNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildNotFoundError("not found"),
+			}),
+		)
+
+	})
+
+	Context("DELETE /goals/{user_id}/{goal_id}", func() {
+
+		var (
+			params op.DeleteGoalParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewDeleteGoalParams()
+			params.HTTPRequest = new(http.Request)
+			params.UserID = "admin"
+			params.AuthUserID = "admin"
+			params.GoalID = 1
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceError     error
+		}
+
+		DescribeTable("Checking delete goal handler cases", func(input Params) {
+
+			mockGoalService.EXPECT().DeleteGoal(gomock.Any(), int64(1), gomock.Any()).
+				Times(1).
+				Return(input.ServiceError)
+
+			response := handler.DeleteGoal(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewDeleteGoalOK(),
+				ServiceError:     nil,
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewDeleteGoalNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceError: customErrors.BuildNotFoundError("not found"),
+			}),
+		)
+
+	})
+
+})
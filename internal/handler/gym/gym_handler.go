@@ -0,0 +1,122 @@
+package gym_handler
+
+import (
+	"errors"
+	"fmt"
+	customErrors "gym-badges-api/internal/custom-errors"
+	gymService "gym-badges-api/internal/service/gym"
+	"gym-badges-api/models"
+	op "gym-badges-api/restapi/operations/gyms"
+	toolsLogging "gym-badges-api/tools/logging"
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+var (
+	unauthorizedErrorResponse = models.GenericResponse{
+		Code:    fmt.Sprint(http.StatusUnauthorized),
+		Message: http.StatusText(http.StatusUnauthorized),
+	}
+
+	notFoundErrorResponse = models.GenericResponse{
+		Code:    fmt.Sprint(http.StatusNotFound),
+		Message: http.StatusText(http.StatusNotFound),
+	}
+
+	internalServerErrorResponse = models.GenericResponse{
+		Code:    fmt.Sprint(http.StatusInternalServerError),
+		Message: http.StatusText(http.StatusInternalServerError),
+	}
+)
+
+func NewGymHandler(gymService gymService.IGymService) IGymHandler {
+	return &gymHandler{
+		gymService: gymService,
+	}
+}
+
+type gymHandler struct {
+	gymService gymService.IGymService
+}
+
+func (h gymHandler) GetGyms(params op.GetGymsParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("GYM_HANDLER: Getting gyms for user: %s", params.AuthUserID)
+
+	response, err := h.gymService.GetGyms(ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewGetGymsUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		default:
+			return op.NewGetGymsInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGetGymsOK().WithPayload(response)
+}
+
+func (h gymHandler) GetGymQRCode(params op.GetGymQRCodeParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("GYM_HANDLER: Getting QR code of gym: %d", params.GymID)
+
+	response, err := h.gymService.GetGymQRCode(params.GymID, params.GymKey, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewGetGymQRCodeUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewGetGymQRCodeNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewGetGymQRCodeInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGetGymQRCodeOK().WithPayload(response)
+}
+
+func (h gymHandler) CheckIn(params op.CheckInParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("GYM_HANDLER: Checking in user: %s at gym: %d", params.UserID, params.GymID)
+
+	// An user can only check in himself
+	if params.AuthUserID != params.UserID {
+		return op.NewCheckInUnauthorized().WithPayload(&unauthorizedErrorResponse)
+	}
+
+	response, err := h.gymService.CheckIn(params.UserID, params.GymID, params.Input, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.BadRequest):
+			return op.NewCheckInBadRequest().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusBadRequest),
+				Message: err.Error(),
+			})
+		case errors.As(err, &customErrors.Forbidden):
+			return op.NewCheckInForbidden().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusForbidden),
+				Message: err.Error(),
+			})
+		case errors.As(err, &customErrors.Conflict):
+			return op.NewCheckInConflict().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusConflict),
+				Message: err.Error(),
+			})
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewCheckInUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewCheckInNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewCheckInInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewCheckInOK().WithPayload(response)
+}
@@ -0,0 +1,13 @@
+package gym_handler
+
+import (
+	"gym-badges-api/restapi/operations/gyms"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+type IGymHandler interface {
+	GetGyms(params gyms.GetGymsParams) middleware.Responder
+	GetGymQRCode(params gyms.GetGymQRCodeParams) middleware.Responder
+	CheckIn(params gyms.CheckInParams) middleware.Responder
+}
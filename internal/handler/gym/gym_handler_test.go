@@ -0,0 +1,249 @@
+package gym_handler
+
+import (
+	"errors"
+	customErrors "gym-badges-api/internal/custom-errors"
+	"gym-badges-api/mocks/service"
+	"gym-badges-api/models"
+	op "gym-badges-api/restapi/operations/gyms"
+	toolsTesting "gym-badges-api/tools/testing"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandlerGymSuite(t *testing.T) {
+	toolsTesting.ConfigureTestSuite(t, "HANDLER: Gym Test Suite")
+}
+
+var _ = Describe("HANDLER: Gym Test Suite", func() {
+
+	var (
+		mockCtrl       *gomock.Controller
+		mockGymService *service.MockIGymService
+		handler        IGymHandler
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockGymService = service.NewMockIGymService(mockCtrl)
+
+		handler = NewGymHandler(mockGymService)
+	})
+
+	AfterEach(func() {
+		defer mockCtrl.Finish()
+
+	})
+
+	Context("GET /gyms", func() {
+
+		var (
+			params op.GetGymsParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewGetGymsParams()
+			params.HTTPRequest = new(http.Request)
+			params.AuthUserID = "admin"
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.GymsResponse
+			ServiceError     error
+		}
+
+		DescribeTable("Checking get gyms handler cases", func(input Params) {
+
+			mockGymService.EXPECT().GetGyms(gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.GetGyms(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewGetGymsOK().WithPayload(&models.GymsResponse{
+					Gyms: []*models.Gym{
+						{ID: 1, Name: "Central Gym", Latitude: 40.4168, Longitude: -3.7038, Radius: 100},
+					},
+				}),
+				ServiceResponse: &models.GymsResponse{
+					Gyms: []*models.Gym{
+						{ID: 1, Name: "Central Gym", Latitude: 40.4168, Longitude: -3.7038, Radius: 100},
+					},
+				},
+				ServiceError: nil,
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewGetGymsInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    errors.New("panic"),
+			}),
+		)
+
+	})
+
+	Context("GET /gyms/{gym_id}/qr-code", func() {
+
+		var (
+			params op.GetGymQRCodeParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewGetGymQRCodeParams()
+			params.HTTPRequest = new(http.Request)
+			params.GymID = 1
+			params.GymKey = "secret"
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.GymQrCode
+			ServiceError     error
+		}
+
+		DescribeTable("Checking get gym QR code handler cases", func(input Params) {
+
+			mockGymService.EXPECT().GetGymQRCode(int64(1), "secret", gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.GetGymQRCode(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewGetGymQRCodeOK().WithPayload(&models.GymQrCode{Code: "1.100.abc"}),
+				ServiceResponse:  &models.GymQrCode{Code: "1.100.abc"},
+				ServiceError:     nil,
+			}),
+			Entry("CASE: Unauthorized Error Response (401)", Params{
+				ExpectedResponse: op.NewGetGymQRCodeUnauthorized().WithPayload(&models.GenericResponse{
+					Code:    "401",
+					Message: "Unauthorized",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildUnauthorizedError("wrong key"),
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewGetGymQRCodeNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildNotFoundError("not found"),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewGetGymQRCodeInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    errors.New("panic"),
+			}),
+		)
+
+	})
+
+	Context("POST /gyms/{gym_id}/check-in/{user_id}", func() {
+
+		var (
+			params op.CheckInParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewCheckInParams()
+			params.HTTPRequest = new(http.Request)
+			params.GymID = 1
+			params.UserID = "admin"
+			params.AuthUserID = "admin"
+			params.Input = &models.CheckInRequest{QrCode: "1.100.abc"}
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.VerifiedAttendance
+			ServiceError     error
+		}
+
+		DescribeTable("Checking check in handler cases", func(input Params) {
+
+			mockGymService.EXPECT().CheckIn("admin", int64(1), params.Input, gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.CheckIn(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewCheckInOK().WithPayload(&models.VerifiedAttendance{
+					Date: "2024-01-01", GymID: 1, Method: "qr",
+				}),
+				ServiceResponse: &models.VerifiedAttendance{Date: "2024-01-01", GymID: 1, Method: "qr"},
+				ServiceError:    nil,
+			}),
+			Entry("CASE: Bad Request Error Response (400)", Params{
+				ExpectedResponse: op.NewCheckInBadRequest().WithPayload(&models.GenericResponse{
+					Code:    "400",
+					Message: "no proof",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildBadRequestError("no proof"),
+			}),
+			Entry("CASE: Forbidden Error Response (403)", Params{
+				ExpectedResponse: op.NewCheckInForbidden().WithPayload(&models.GenericResponse{
+					Code:    "403",
+					Message: "expired code",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildForbiddenError("expired code"),
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewCheckInNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildNotFoundError("not found"),
+			}),
+			Entry("CASE: Conflict Error Response (409)", Params{
+				ExpectedResponse: op.NewCheckInConflict().WithPayload(&models.GenericResponse{
+					Code:    "409",
+					Message: "already verified",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildConflictError("already verified"),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewCheckInInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    errors.New("panic"),
+			}),
+		)
+
+		It("CASE: Unauthorized Error Response (401) when checking in another user", func() {
+
+			params.AuthUserID = "other"
+
+			mockGymService.EXPECT().CheckIn(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			response := handler.CheckIn(params)
+			Expect(response).To(BeEquivalentTo(op.NewCheckInUnauthorized().WithPayload(&models.GenericResponse{
+				Code:    "401",
+				Message: "Unauthorized",
+			})))
+		})
+
+	})
+})
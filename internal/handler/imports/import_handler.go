@@ -0,0 +1,76 @@
+package imports_handler
+
+import (
+	"errors"
+	"fmt"
+	customErrors "gym-badges-api/internal/custom-errors"
+	importService "gym-badges-api/internal/service/imports"
+	"gym-badges-api/models"
+	op "gym-badges-api/restapi/operations/imports"
+	toolsLogging "gym-badges-api/tools/logging"
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+var (
+	unauthorizedErrorResponse = models.GenericResponse{
+		Code:    fmt.Sprint(http.StatusUnauthorized),
+		Message: http.StatusText(http.StatusUnauthorized),
+	}
+
+	notFoundErrorResponse = models.GenericResponse{
+		Code:    fmt.Sprint(http.StatusNotFound),
+		Message: http.StatusText(http.StatusNotFound),
+	}
+
+	internalServerErrorResponse = models.GenericResponse{
+		Code:    fmt.Sprint(http.StatusInternalServerError),
+		Message: http.StatusText(http.StatusInternalServerError),
+	}
+)
+
+func NewImportHandler(importService importService.IImportService) IImportHandler {
+	return &importHandler{
+		importService: importService,
+	}
+}
+
+type importHandler struct {
+	importService importService.IImportService
+}
+
+func (h importHandler) ImportHistory(params op.ImportHistoryParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("IMPORT_HANDLER: Importing %s history to user: %s", params.Format, params.UserID)
+
+	defer params.File.Close()
+
+	// An user can only import history to himself
+	if params.AuthUserID != params.UserID {
+		return op.NewImportHistoryUnauthorized().WithPayload(&unauthorizedErrorResponse)
+	}
+
+	dryRun := params.DryRun != nil && *params.DryRun
+
+	response, err := h.importService.ImportHistory(params.UserID, params.Format, params.File, dryRun, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.BadRequest):
+			return op.NewImportHistoryBadRequest().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusBadRequest),
+				Message: err.Error(),
+			})
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewImportHistoryUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewImportHistoryNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewImportHistoryInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewImportHistoryOK().WithPayload(response)
+}
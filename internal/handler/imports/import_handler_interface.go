@@ -0,0 +1,11 @@
+package imports_handler
+
+import (
+	"gym-badges-api/restapi/operations/imports"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+type IImportHandler interface {
+	ImportHistory(params imports.ImportHistoryParams) middleware.Responder
+}
@@ -0,0 +1,131 @@
+package imports_handler
+
+import (
+	"errors"
+	customErrors "gym-badges-api/internal/custom-errors"
+	"gym-badges-api/mocks/service"
+	"gym-badges-api/models"
+	op "gym-badges-api/restapi/operations/imports"
+	toolsTesting "gym-badges-api/tools/testing"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandlerImportSuite(t *testing.T) {
+	toolsTesting.ConfigureTestSuite(t, "HANDLER: Import Test Suite")
+}
+
+var _ = Describe("HANDLER: Import Test Suite", func() {
+
+	var (
+		mockCtrl          *gomock.Controller
+		mockImportService *service.MockIImportService
+		handler           IImportHandler
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockImportService = service.NewMockIImportService(mockCtrl)
+
+		handler = NewImportHandler(mockImportService)
+	})
+
+	AfterEach(func() {
+		defer mockCtrl.Finish()
+
+	})
+
+	Context("POST /imports/{user_id}", func() {
+
+		var (
+			params op.ImportHistoryParams
+		)
+
+		BeforeEach(func() {
+			dryRun := true
+			params = op.NewImportHistoryParams()
+			params.HTTPRequest = new(http.Request)
+			params.UserID = "admin"
+			params.AuthUserID = "admin"
+			params.Format = "csv"
+			params.File = io.NopCloser(strings.NewReader("date,weight\n2024-03-01,80\n"))
+			params.DryRun = &dryRun
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.ImportReport
+			ServiceError     error
+		}
+
+		DescribeTable("Checking import history handler cases", func(input Params) {
+
+			mockImportService.EXPECT().ImportHistory("admin", "csv", gomock.Any(), true, gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.ImportHistory(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewImportHistoryOK().WithPayload(&models.ImportReport{
+					Format:  "csv",
+					DryRun:  true,
+					Weights: &models.ImportSummary{Found: 1, Added: 1},
+				}),
+				ServiceResponse: &models.ImportReport{
+					Format:  "csv",
+					DryRun:  true,
+					Weights: &models.ImportSummary{Found: 1, Added: 1},
+				},
+				ServiceError: nil,
+			}),
+			Entry("CASE: Bad Request Error Response (400)", Params{
+				ExpectedResponse: op.NewImportHistoryBadRequest().WithPayload(&models.GenericResponse{
+					Code:    "400",
+					Message: "The CSV file needs a date column.",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildBadRequestError("The CSV file needs a date column."),
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewImportHistoryNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildNotFoundError("not found"),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewImportHistoryInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    errors.New("panic"),
+			}),
+		)
+
+		It("CASE: Unauthorized Error Response (401) when importing to another user", func() {
+
+			params.AuthUserID = "other"
+
+			mockImportService.EXPECT().ImportHistory(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			response := handler.ImportHistory(params)
+			Expect(response).To(BeEquivalentTo(op.NewImportHistoryUnauthorized().WithPayload(&models.GenericResponse{
+				Code:    "401",
+				Message: "Unauthorized",
+			})))
+		})
+
+	})
+
+})
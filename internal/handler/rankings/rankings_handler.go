@@ -47,7 +47,8 @@ func (h *rankingsHandler) GetGlobalRanking(params op.GetGlobalRankingParams) mid
 
 	ctxLog.Infof("RANKINGS_HANDLER: Getting global ranking with user: %s", params.UserID)
 
-	response, err := h.rankingsService.GetGlobalRanking(params.UserID, params.Page, ctxLog)
+	response, err := h.rankingsService.GetGlobalRanking(params.UserID, params.Page,
+		params.Verified != nil && *params.Verified, ctxLog)
 	if err != nil {
 		switch {
 		case errors.As(err, &customErrors.Unauthorized):
@@ -68,7 +69,8 @@ func (h *rankingsHandler) GetFriendsRanking(params op.GetFriendsRankingParams) m
 
 	ctxLog.Infof("RANKINGS_HANDLER: Getting friends ranking with user: %s", params.UserID)
 
-	response, err := h.rankingsService.GetFriendsRanking(params.UserID, params.Page, ctxLog)
+	response, err := h.rankingsService.GetFriendsRanking(params.UserID, params.Page,
+		params.Verified != nil && *params.Verified, ctxLog)
 	if err != nil {
 		switch {
 		case errors.As(err, &customErrors.Unauthorized):
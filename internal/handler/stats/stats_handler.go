@@ -20,6 +20,11 @@ var (
 		Message: http.StatusText(http.StatusUnauthorized),
 	}
 
+	forbiddenErrorResponse = models.GenericResponse{
+		Code:    fmt.Sprint(http.StatusForbidden),
+		Message: http.StatusText(http.StatusForbidden),
+	}
+
 	notFoundErrorResponse = models.GenericResponse{
 		Code:    fmt.Sprint(http.StatusNotFound),
 		Message: http.StatusText(http.StatusNotFound),
@@ -135,6 +140,63 @@ func (h statsHandler) AddBodyFat(params op.AddBodyFatParams) middleware.Responde
 	return op.NewAddBodyFatOK()
 }
 
+func (h statsHandler) GetBodyComposition(params op.GetBodyCompositionByUserIDParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("STATS_HANDLER: Getting body composition for user: %s", params.UserID)
+
+	response, err := h.statsService.GetBodyComposition(params.UserID, params.AuthUserID, params.Months, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewGetBodyCompositionByUserIDUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.Forbidden):
+			return op.NewGetBodyCompositionByUserIDForbidden().WithPayload(&forbiddenErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewGetBodyCompositionByUserIDNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewGetBodyCompositionByUserIDInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGetBodyCompositionByUserIDOK().WithPayload(response)
+}
+
+func (h statsHandler) GetMeasurementTrend(params op.GetMeasurementTrendByUserIDParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("STATS_HANDLER: Getting %s trend for user: %s", params.Measurement, params.UserID)
+
+	var window int32
+	if params.Window != nil {
+		window = *params.Window
+	}
+
+	response, err := h.statsService.GetMeasurementTrend(params.UserID, params.AuthUserID, params.Measurement, params.Months,
+		window, params.Target, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.BadRequest):
+			return op.NewGetMeasurementTrendByUserIDBadRequest().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusBadRequest),
+				Message: err.Error(),
+			})
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewGetMeasurementTrendByUserIDUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.Forbidden):
+			return op.NewGetMeasurementTrendByUserIDForbidden().WithPayload(&forbiddenErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewGetMeasurementTrendByUserIDNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewGetMeasurementTrendByUserIDInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGetMeasurementTrendByUserIDOK().WithPayload(response)
+}
+
 func (h statsHandler) GetStreakCalendar(params op.GetStreakCalendarByUserIDParams) middleware.Responder {
 
 	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
@@ -176,9 +238,32 @@ func (h statsHandler) AddGymAttendance(params op.AddGymAttendanceParams) middlew
 		})
 	}
 
-	err := h.statsService.AddGymAttendance(params.UserID, time.Time(params.Input.Date), ctxLog)
+	var err error
+	switch {
+	case params.Input.StartTime == nil && params.Input.EndTime == nil:
+		err = h.statsService.AddGymAttendance(params.UserID, time.Time(params.Input.Date), ctxLog)
+	case params.Input.StartTime == nil || params.Input.EndTime == nil:
+		return op.NewAddGymAttendanceBadRequest().WithPayload(&models.GenericResponse{
+			Code:    fmt.Sprint(http.StatusBadRequest),
+			Message: "The session needs both start and end times.",
+		})
+	default:
+		err = h.statsService.AddGymSession(params.UserID, time.Time(params.Input.Date),
+			time.Time(*params.Input.StartTime), time.Time(*params.Input.EndTime), ctxLog)
+	}
+
 	if err != nil {
 		switch {
+		case errors.As(err, &customErrors.BadRequest):
+			return op.NewAddGymAttendanceBadRequest().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusBadRequest),
+				Message: err.Error(),
+			})
+		case errors.As(err, &customErrors.Conflict):
+			return op.NewAddGymAttendanceConflict().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusConflict),
+				Message: err.Error(),
+			})
 		case errors.As(err, &customErrors.Unauthorized):
 			return op.NewAddGymAttendanceUnauthorized().WithPayload(&unauthorizedErrorResponse)
 		case errors.As(err, &customErrors.NotFound):
@@ -216,3 +301,145 @@ func (h statsHandler) DeleteGymAttendance(params op.DeleteGymAttendanceParams) m
 
 	return op.NewDeleteGymAttendanceOK()
 }
+
+func (h statsHandler) GetAttendanceRange(params op.GetAttendanceRangeParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("STATS_HANDLER: Getting attendance for user: %s from: %s to: %s", params.UserID,
+		params.From, params.To)
+
+	response, err := h.statsService.GetAttendanceRange(params.UserID, time.Time(params.From), time.Time(params.To), ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.BadRequest):
+			return op.NewGetAttendanceRangeBadRequest().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusBadRequest),
+				Message: err.Error(),
+			})
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewGetAttendanceRangeUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewGetAttendanceRangeNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewGetAttendanceRangeInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGetAttendanceRangeOK().WithPayload(response)
+}
+
+func (h statsHandler) GetTrainingTime(params op.GetTrainingTimeParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("STATS_HANDLER: Getting training time for user: %s in the last %d months", params.UserID,
+		params.Months)
+
+	response, err := h.statsService.GetTrainingTime(params.UserID, params.Months, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewGetTrainingTimeUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewGetTrainingTimeNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewGetTrainingTimeInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGetTrainingTimeOK().WithPayload(response)
+}
+
+func (h statsHandler) GetStreakProtection(params op.GetStreakProtectionParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("STATS_HANDLER: Getting streak protection of user: %s", params.UserID)
+
+	// An user can only check his own freezes and vacations
+	if params.AuthUserID != params.UserID {
+		return op.NewGetStreakProtectionUnauthorized().WithPayload(&unauthorizedErrorResponse)
+	}
+
+	response, err := h.statsService.GetStreakProtection(params.UserID, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewGetStreakProtectionUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewGetStreakProtectionNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewGetStreakProtectionInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGetStreakProtectionOK().WithPayload(response)
+}
+
+func (h statsHandler) AddVacationWeek(params op.AddVacationWeekParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("STATS_HANDLER: Adding a vacation week to user: %s", params.UserID)
+
+	// An user can only add vacation weeks to himself
+	if params.AuthUserID != params.UserID {
+		return op.NewAddVacationWeekUnauthorized().WithPayload(&unauthorizedErrorResponse)
+	}
+
+	err := h.statsService.AddVacationWeek(params.UserID, time.Time(params.Input.Week), ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.BadRequest):
+			return op.NewAddVacationWeekBadRequest().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusBadRequest),
+				Message: err.Error(),
+			})
+		case errors.As(err, &customErrors.Conflict):
+			return op.NewAddVacationWeekConflict().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusConflict),
+				Message: err.Error(),
+			})
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewAddVacationWeekUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewAddVacationWeekNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewAddVacationWeekInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewAddVacationWeekOK()
+}
+
+func (h statsHandler) DeleteVacationWeek(params op.DeleteVacationWeekParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("STATS_HANDLER: Deleting a vacation week of user: %s", params.UserID)
+
+	// An user can only delete his own vacation weeks
+	if params.AuthUserID != params.UserID {
+		return op.NewDeleteVacationWeekUnauthorized().WithPayload(&unauthorizedErrorResponse)
+	}
+
+	err := h.statsService.DeleteVacationWeek(params.UserID, time.Time(params.Input.Week), ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.BadRequest):
+			return op.NewDeleteVacationWeekBadRequest().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusBadRequest),
+				Message: err.Error(),
+			})
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewDeleteVacationWeekUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewDeleteVacationWeekNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewDeleteVacationWeekInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewDeleteVacationWeekOK()
+}
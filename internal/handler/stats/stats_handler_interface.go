@@ -13,7 +13,16 @@ type IStatsHandler interface {
 	GetFatHistory(params stats.GetFatHistoryByUserIDParams) middleware.Responder
 	AddBodyFat(params stats.AddBodyFatParams) middleware.Responder
 
+	GetBodyComposition(params stats.GetBodyCompositionByUserIDParams) middleware.Responder
+	GetMeasurementTrend(params stats.GetMeasurementTrendByUserIDParams) middleware.Responder
+
 	GetStreakCalendar(params stats.GetStreakCalendarByUserIDParams) middleware.Responder
 	AddGymAttendance(params stats.AddGymAttendanceParams) middleware.Responder
 	DeleteGymAttendance(params stats.DeleteGymAttendanceParams) middleware.Responder
+	GetAttendanceRange(params stats.GetAttendanceRangeParams) middleware.Responder
+	GetTrainingTime(params stats.GetTrainingTimeParams) middleware.Responder
+
+	GetStreakProtection(params stats.GetStreakProtectionParams) middleware.Responder
+	AddVacationWeek(params stats.AddVacationWeekParams) middleware.Responder
+	DeleteVacationWeek(params stats.DeleteVacationWeekParams) middleware.Responder
 }
@@ -9,7 +9,9 @@ import (
 	toolsTesting "gym-badges-api/tools/testing"
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/go-openapi/strfmt"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
@@ -221,6 +223,159 @@ var _ = Describe("HANDLER: Stats Test Suite", func() {
 
 	})
 
+	Context("GET /stats/body-composition/{user_id}", func() {
+
+		var (
+			params op.GetBodyCompositionByUserIDParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewGetBodyCompositionByUserIDParams()
+			params.HTTPRequest = new(http.Request)
+			params.Months = 3
+			params.UserID = "admin"
+			params.AuthUserID = "other"
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.BodyCompositionResponse
+			ServiceError     error
+		}
+
+		DescribeTable("Checking get body composition handler cases", func(input Params) {
+
+			mockStatsService.EXPECT().GetBodyComposition(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.GetBodyComposition(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewGetBodyCompositionByUserIDOK().WithPayload(&models.BodyCompositionResponse{
+					Sex: "masculine",
+					Days: []*models.BodyCompositionDay{
+						{Date: "2024-11-01", Weight: 80},
+					},
+				}),
+				ServiceResponse: &models.BodyCompositionResponse{
+					Sex: "masculine",
+					Days: []*models.BodyCompositionDay{
+						{Date: "2024-11-01", Weight: 80},
+					},
+				},
+				ServiceError: nil,
+			}),
+			Entry("CASE: Forbidden Error Response (403)", Params{
+				ExpectedResponse: op.NewGetBodyCompositionByUserIDForbidden().WithPayload(&models.GenericResponse{
+					Code:    "403",
+					Message: "Forbidden",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildForbiddenError("stats hidden"),
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewGetBodyCompositionByUserIDNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildNotFoundError("user not found"),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewGetBodyCompositionByUserIDInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    errors.New("panic"),
+			}),
+		)
+
+	})
+
+	Context("GET /stats/trend/{user_id}", func() {
+
+		var (
+			params op.GetMeasurementTrendByUserIDParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewGetMeasurementTrendByUserIDParams()
+			params.HTTPRequest = new(http.Request)
+			params.Measurement = "weight"
+			params.Months = 3
+			params.UserID = "admin"
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.MeasurementTrendResponse
+			ServiceError     error
+		}
+
+		DescribeTable("Checking get measurement trend handler cases", func(input Params) {
+
+			mockStatsService.EXPECT().GetMeasurementTrend(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.GetMeasurementTrend(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewGetMeasurementTrendByUserIDOK().WithPayload(&models.MeasurementTrendResponse{
+					Measurement: "weight",
+					Days: []*models.TrendDay{
+						{Date: "2024-11-01", Value: 84, Trend: 84},
+					},
+				}),
+				ServiceResponse: &models.MeasurementTrendResponse{
+					Measurement: "weight",
+					Days: []*models.TrendDay{
+						{Date: "2024-11-01", Value: 84, Trend: 84},
+					},
+				},
+				ServiceError: nil,
+			}),
+			Entry("CASE: Bad Request Error Response (400)", Params{
+				ExpectedResponse: op.NewGetMeasurementTrendByUserIDBadRequest().WithPayload(&models.GenericResponse{
+					Code:    "400",
+					Message: "Unknown measurement height.",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildBadRequestError("Unknown measurement %s.", "height"),
+			}),
+			Entry("CASE: Forbidden Error Response (403)", Params{
+				ExpectedResponse: op.NewGetMeasurementTrendByUserIDForbidden().WithPayload(&models.GenericResponse{
+					Code:    "403",
+					Message: "Forbidden",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildForbiddenError("hidden"),
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewGetMeasurementTrendByUserIDNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildNotFoundError("user not found"),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewGetMeasurementTrendByUserIDInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    errors.New("panic"),
+			}),
+		)
+
+	})
+
 	Context("GET /stats/streak/{user_id}", func() {
 
 		var (
@@ -301,4 +456,469 @@ var _ = Describe("HANDLER: Stats Test Suite", func() {
 
 	})
 
+	Context("POST /stats/streak/{user_id}", func() {
+
+		var (
+			params op.AddGymAttendanceParams
+			date   time.Time
+			start  time.Time
+			end    time.Time
+		)
+
+		BeforeEach(func() {
+			date = time.Date(2024, 12, 2, 0, 0, 0, 0, time.UTC)
+			start = time.Date(2024, 12, 2, 6, 30, 0, 0, time.FixedZone("CET", 3600))
+			end = start.Add(90 * time.Minute)
+
+			params = op.NewAddGymAttendanceParams()
+			params.HTTPRequest = new(http.Request)
+			params.UserID = "admin"
+			params.AuthUserID = "admin"
+			params.Input = &models.AddGymAttendanceRequest{Date: strfmt.Date(date)}
+		})
+
+		It("CASE: Success Response (200) adding a day without session times", func() {
+
+			mockStatsService.EXPECT().AddGymAttendance("admin", date, gomock.Any()).
+				Times(1).
+				Return(nil)
+
+			response := handler.AddGymAttendance(params)
+			Expect(response).To(BeEquivalentTo(op.NewAddGymAttendanceOK()))
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceError     error
+		}
+
+		DescribeTable("Checking add gym session handler cases", func(input Params) {
+
+			startTime, endTime := strfmt.DateTime(start), strfmt.DateTime(end)
+			params.Input.StartTime = &startTime
+			params.Input.EndTime = &endTime
+
+			mockStatsService.EXPECT().AddGymSession("admin", date, start, end, gomock.Any()).
+				Times(1).
+				Return(input.ServiceError)
+
+			response := handler.AddGymAttendance(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewAddGymAttendanceOK(),
+			}),
+			Entry("CASE: Bad Request Error Response (400)", Params{
+				ExpectedResponse: op.NewAddGymAttendanceBadRequest().WithPayload(&models.GenericResponse{
+					Code:    "400",
+					Message: "The session must end after it starts.",
+				}),
+				ServiceError: customErrors.BuildBadRequestError("The session must end after it starts."),
+			}),
+			Entry("CASE: Conflict Error Response (409)", Params{
+				ExpectedResponse: op.NewAddGymAttendanceConflict().WithPayload(&models.GenericResponse{
+					Code:    "409",
+					Message: "The session overlaps the session of 2024-12-01.",
+				}),
+				ServiceError: customErrors.BuildConflictError("The session overlaps the session of 2024-12-01."),
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewAddGymAttendanceNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceError: customErrors.BuildNotFoundError("user not found"),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewAddGymAttendanceInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceError: errors.New("panic"),
+			}),
+		)
+
+		It("CASE: Bad Request Error Response (400) when the session has no end time", func() {
+
+			startTime := strfmt.DateTime(start)
+			params.Input.StartTime = &startTime
+
+			mockStatsService.EXPECT().AddGymSession(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			response := handler.AddGymAttendance(params)
+			Expect(response).To(BeEquivalentTo(op.NewAddGymAttendanceBadRequest().WithPayload(&models.GenericResponse{
+				Code:    "400",
+				Message: "The session needs both start and end times.",
+			})))
+		})
+
+	})
+
+	Context("GET /stats/training-time/{user_id}", func() {
+
+		var (
+			params op.GetTrainingTimeParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewGetTrainingTimeParams()
+			params.HTTPRequest = new(http.Request)
+			params.UserID = "admin"
+			params.Months = 3
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.TrainingTimeResponse
+			ServiceError     error
+		}
+
+		DescribeTable("Checking get training time handler cases", func(input Params) {
+
+			mockStatsService.EXPECT().GetTrainingTime("admin", int32(3), gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.GetTrainingTime(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewGetTrainingTimeOK().WithPayload(&models.TrainingTimeResponse{
+					TotalMinutes:  90,
+					TimedSessions: 1,
+					Weeks:         []*models.TrainingTimePeriod{{Period: "2024-12-02", Sessions: 1, TimedSessions: 1, Minutes: 90}},
+				}),
+				ServiceResponse: &models.TrainingTimeResponse{
+					TotalMinutes:  90,
+					TimedSessions: 1,
+					Weeks:         []*models.TrainingTimePeriod{{Period: "2024-12-02", Sessions: 1, TimedSessions: 1, Minutes: 90}},
+				},
+				ServiceError: nil,
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewGetTrainingTimeNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildNotFoundError("user not found"),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewGetTrainingTimeInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    errors.New("panic"),
+			}),
+		)
+
+	})
+
+	Context("GET /stats/attendance/{user_id}", func() {
+
+		var (
+			params op.GetAttendanceRangeParams
+			from   time.Time
+			to     time.Time
+		)
+
+		BeforeEach(func() {
+			from = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			to = time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+
+			params = op.NewGetAttendanceRangeParams()
+			params.HTTPRequest = new(http.Request)
+			params.From = strfmt.Date(from)
+			params.To = strfmt.Date(to)
+			params.UserID = "admin"
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.AttendanceRangeResponse
+			ServiceError     error
+		}
+
+		DescribeTable("Checking get attendance range handler cases", func(input Params) {
+
+			mockStatsService.EXPECT().GetAttendanceRange("admin", from, to, gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.GetAttendanceRange(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewGetAttendanceRangeOK().WithPayload(&models.AttendanceRangeResponse{
+					From:          "2024-01-01",
+					To:            "2024-12-31",
+					TotalSessions: 120,
+					LongestStreak: 20,
+				}),
+				ServiceResponse: &models.AttendanceRangeResponse{
+					From:          "2024-01-01",
+					To:            "2024-12-31",
+					TotalSessions: 120,
+					LongestStreak: 20,
+				},
+				ServiceError: nil,
+			}),
+			Entry("CASE: Bad Request Error Response (400)", Params{
+				ExpectedResponse: op.NewGetAttendanceRangeBadRequest().WithPayload(&models.GenericResponse{
+					Code:    "400",
+					Message: "The range cannot end before it starts.",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildBadRequestError("The range cannot end before it starts."),
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewGetAttendanceRangeNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildNotFoundError("user not found"),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewGetAttendanceRangeInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    errors.New("panic"),
+			}),
+		)
+
+	})
+
+	Context("GET /stats/streak/{user_id}/protection", func() {
+
+		var (
+			params op.GetStreakProtectionParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewGetStreakProtectionParams()
+			params.HTTPRequest = new(http.Request)
+			params.UserID = "admin"
+			params.AuthUserID = "admin"
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.StreakProtectionResponse
+			ServiceError     error
+		}
+
+		DescribeTable("Checking get streak protection handler cases", func(input Params) {
+
+			mockStatsService.EXPECT().GetStreakProtection("admin", gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.GetStreakProtection(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewGetStreakProtectionOK().WithPayload(&models.StreakProtectionResponse{
+					Streak:           12,
+					AvailableFreezes: 1,
+					Freezes: []*models.StreakFreeze{
+						{ID: 1, Reason: "experience", EarnedAt: "2024-11-01"},
+					},
+					VacationWeeks: []string{"2024-12-23"},
+				}),
+				ServiceResponse: &models.StreakProtectionResponse{
+					Streak:           12,
+					AvailableFreezes: 1,
+					Freezes: []*models.StreakFreeze{
+						{ID: 1, Reason: "experience", EarnedAt: "2024-11-01"},
+					},
+					VacationWeeks: []string{"2024-12-23"},
+				},
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewGetStreakProtectionNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceError: customErrors.BuildNotFoundError("user not found"),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewGetStreakProtectionInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceError: errors.New("panic"),
+			}),
+		)
+
+		It("CASE: Unauthorized Error Response (401) when getting another user", func() {
+
+			params.AuthUserID = "other"
+
+			mockStatsService.EXPECT().GetStreakProtection(gomock.Any(), gomock.Any()).Times(0)
+
+			response := handler.GetStreakProtection(params)
+			Expect(response).To(BeEquivalentTo(op.NewGetStreakProtectionUnauthorized().WithPayload(&models.GenericResponse{
+				Code:    "401",
+				Message: "Unauthorized",
+			})))
+		})
+
+	})
+
+	Context("POST /stats/streak/{user_id}/vacation", func() {
+
+		var (
+			params op.AddVacationWeekParams
+			week   time.Time
+		)
+
+		BeforeEach(func() {
+			week = time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC)
+
+			params = op.NewAddVacationWeekParams()
+			params.HTTPRequest = new(http.Request)
+			params.UserID = "admin"
+			params.AuthUserID = "admin"
+			params.Input = &models.VacationWeekRequest{Week: strfmt.Date(week)}
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceError     error
+		}
+
+		DescribeTable("Checking add vacation week handler cases", func(input Params) {
+
+			mockStatsService.EXPECT().AddVacationWeek("admin", week, gomock.Any()).
+				Times(1).
+				Return(input.ServiceError)
+
+			response := handler.AddVacationWeek(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewAddVacationWeekOK(),
+			}),
+			Entry("CASE: Bad Request Error Response (400)", Params{
+				ExpectedResponse: op.NewAddVacationWeekBadRequest().WithPayload(&models.GenericResponse{
+					Code:    "400",
+					Message: "Vacation weeks cannot be in the past.",
+				}),
+				ServiceError: customErrors.BuildBadRequestError("Vacation weeks cannot be in the past."),
+			}),
+			Entry("CASE: Conflict Error Response (409)", Params{
+				ExpectedResponse: op.NewAddVacationWeekConflict().WithPayload(&models.GenericResponse{
+					Code:    "409",
+					Message: "The week of 2024-12-23 is already a vacation week.",
+				}),
+				ServiceError: customErrors.BuildConflictError("The week of 2024-12-23 is already a vacation week."),
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewAddVacationWeekNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceError: customErrors.BuildNotFoundError("user not found"),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewAddVacationWeekInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceError: errors.New("panic"),
+			}),
+		)
+
+		It("CASE: Unauthorized Error Response (401) when adding to another user", func() {
+
+			params.AuthUserID = "other"
+
+			mockStatsService.EXPECT().AddVacationWeek(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+			response := handler.AddVacationWeek(params)
+			Expect(response).To(BeEquivalentTo(op.NewAddVacationWeekUnauthorized().WithPayload(&models.GenericResponse{
+				Code:    "401",
+				Message: "Unauthorized",
+			})))
+		})
+
+	})
+
+	Context("DELETE /stats/streak/{user_id}/vacation", func() {
+
+		var (
+			params op.DeleteVacationWeekParams
+			week   time.Time
+		)
+
+		BeforeEach(func() {
+			week = time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC)
+
+			params = op.NewDeleteVacationWeekParams()
+			params.HTTPRequest = new(http.Request)
+			params.UserID = "admin"
+			params.AuthUserID = "admin"
+			params.Input = &models.VacationWeekRequest{Week: strfmt.Date(week)}
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceError     error
+		}
+
+		DescribeTable("Checking delete vacation week handler cases", func(input Params) {
+
+			mockStatsService.EXPECT().DeleteVacationWeek("admin", week, gomock.Any()).
+				Times(1).
+				Return(input.ServiceError)
+
+			response := handler.DeleteVacationWeek(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewDeleteVacationWeekOK(),
+			}),
+			Entry("CASE: Bad Request Error Response (400)", Params{
+				ExpectedResponse: op.NewDeleteVacationWeekBadRequest().WithPayload(&models.GenericResponse{
+					Code:    "400",
+					Message: "Past vacation weeks cannot be cancelled.",
+				}),
+				ServiceError: customErrors.BuildBadRequestError("Past vacation weeks cannot be cancelled."),
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewDeleteVacationWeekNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceError: customErrors.BuildNotFoundError("The week of 2024-12-23 is not a vacation week."),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewDeleteVacationWeekInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceError: errors.New("panic"),
+			}),
+		)
+
+		It("CASE: Unauthorized Error Response (401) when deleting from another user", func() {
+
+			params.AuthUserID = "other"
+
+			mockStatsService.EXPECT().DeleteVacationWeek(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+			response := handler.DeleteVacationWeek(params)
+			Expect(response).To(BeEquivalentTo(op.NewDeleteVacationWeekUnauthorized().WithPayload(&models.GenericResponse{
+				Code:    "401",
+				Message: "Unauthorized",
+			})))
+		})
+
+	})
+
 })
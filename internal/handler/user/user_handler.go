@@ -15,6 +15,7 @@ import (
 
 var (
 	unauthorizedError customErrors.UnauthorizedError
+	badRequestError   customErrors.BadRequestError
 	conflictError     customErrors.ConflictError
 	NotFoundError     customErrors.NotFoundError
 
@@ -105,6 +106,16 @@ func (h userHandler) EditUserInfo(params op.EditUserInfoParams) middleware.Respo
 			return op.NewEditUserInfoUnauthorized().WithPayload(&unauthorizedErrorResponse)
 		case errors.As(err, &NotFoundError):
 			return op.NewEditUserInfoNotFound().WithPayload(&notFoundErrorResponse)
+		case errors.As(err, &badRequestError):
+			return op.NewEditUserInfoBadRequest().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusBadRequest),
+				Message: err.Error(),
+			})
+		case errors.As(err, &conflictError):
+			return op.NewEditUserInfoConflict().WithPayload(&models.GenericResponse{
+				Code:    fmt.Sprint(http.StatusConflict),
+				Message: err.Error(),
+			})
 		default:
 			return op.NewEditUserInfoInternalServerError().WithPayload(&internalServerErrorResponse)
 		}
@@ -0,0 +1,244 @@
+package workout_handler
+
+import (
+	"errors"
+	"fmt"
+	customErrors "gym-badges-api/internal/custom-errors"
+	workoutService "gym-badges-api/internal/service/workout"
+	"gym-badges-api/models"
+	op "gym-badges-api/restapi/operations/workouts"
+	toolsLogging "gym-badges-api/tools/logging"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+var (
+	unauthorizedErrorResponse = models.GenericResponse{
+		Code:    fmt.Sprint(http.StatusUnauthorized),
+		Message: http.StatusText(http.StatusUnauthorized),
+	}
+
+	notFoundErrorResponse = models.GenericResponse{
+		Code:    fmt.Sprint(http.StatusNotFound),
+		Message: http.StatusText(http.StatusNotFound),
+	}
+
+	internalServerErrorResponse = models.GenericResponse{
+		Code:    fmt.Sprint(http.StatusInternalServerError),
+		Message: http.StatusText(http.StatusInternalServerError),
+	}
+)
+
+func NewWorkoutHandler(workoutService workoutService.IWorkoutService) IWorkoutHandler {
+	return &workoutHandler{
+		workoutService: workoutService,
+	}
+}
+
+type workoutHandler struct {
+	workoutService workoutService.IWorkoutService
+}
+
+func buildBadRequestResponse(err error) *models.GenericResponse {
+	return &models.GenericResponse{
+		Code:    fmt.Sprint(http.StatusBadRequest),
+		Message: err.Error(),
+	}
+}
+
+func (h workoutHandler) GetWorkoutHistory(params op.GetWorkoutHistoryParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("WORKOUT_HANDLER: Getting workout history for user: %s page: %d", params.UserID, params.Page)
+
+	var from, to time.Time
+	if params.From != nil {
+		from = time.Time(*params.From)
+	}
+	if params.To != nil {
+		to = time.Time(*params.To)
+	}
+
+	response, err := h.workoutService.GetWorkoutHistory(params.UserID, from, to, params.Page, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewGetWorkoutHistoryUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewGetWorkoutHistoryNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewGetWorkoutHistoryInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGetWorkoutHistoryOK().WithPayload(response)
+}
+
+func (h workoutHandler) GetWorkout(params op.GetWorkoutParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("WORKOUT_HANDLER: Getting workout %d of user: %s", params.WorkoutID, params.UserID)
+
+	response, err := h.workoutService.GetWorkout(params.UserID, params.WorkoutID, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewGetWorkoutUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewGetWorkoutNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewGetWorkoutInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGetWorkoutOK().WithPayload(response)
+}
+
+func (h workoutHandler) AddWorkout(params op.AddWorkoutParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("WORKOUT_HANDLER: Adding a workout to user: %s", params.UserID)
+
+	// An user can only log workouts to himself
+	if params.AuthUserID != params.UserID {
+		return op.NewAddWorkoutUnauthorized().WithPayload(&unauthorizedErrorResponse)
+	}
+
+	response, err := h.workoutService.CreateWorkout(params.UserID, params.Input, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.BadRequest):
+			return op.NewAddWorkoutBadRequest().WithPayload(buildBadRequestResponse(err))
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewAddWorkoutUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewAddWorkoutNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewAddWorkoutInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewAddWorkoutCreated().WithPayload(response)
+}
+
+func (h workoutHandler) EditWorkout(params op.EditWorkoutParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("WORKOUT_HANDLER: Editing workout %d of user: %s", params.WorkoutID, params.UserID)
+
+	// An user can only edit his own workouts
+	if params.AuthUserID != params.UserID {
+		return op.NewEditWorkoutUnauthorized().WithPayload(&unauthorizedErrorResponse)
+	}
+
+	response, err := h.workoutService.EditWorkout(params.UserID, params.WorkoutID, params.Input, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.BadRequest):
+			return op.NewEditWorkoutBadRequest().WithPayload(buildBadRequestResponse(err))
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewEditWorkoutUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewEditWorkoutNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewEditWorkoutInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewEditWorkoutOK().WithPayload(response)
+}
+
+func (h workoutHandler) DeleteWorkout(params op.DeleteWorkoutParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("WORKOUT_HANDLER: Deleting workout %d of user: %s", params.WorkoutID, params.UserID)
+
+	// An user can only delete his own workouts
+	if params.AuthUserID != params.UserID {
+		return op.NewDeleteWorkoutUnauthorized().WithPayload(&unauthorizedErrorResponse)
+	}
+
+	err := h.workoutService.DeleteWorkout(params.UserID, params.WorkoutID, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewDeleteWorkoutUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewDeleteWorkoutNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewDeleteWorkoutInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewDeleteWorkoutOK()
+}
+
+func (h workoutHandler) GetExerciseHistory(params op.GetExerciseHistoryParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("WORKOUT_HANDLER: Getting %s history for user: %s", params.Exercise, params.UserID)
+
+	response, err := h.workoutService.GetExerciseHistory(params.UserID, params.Exercise, params.Months, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewGetExerciseHistoryUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewGetExerciseHistoryNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewGetExerciseHistoryInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGetExerciseHistoryOK().WithPayload(response)
+}
+
+func (h workoutHandler) GetPersonalRecords(params op.GetPersonalRecordsParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("WORKOUT_HANDLER: Getting personal records for user: %s", params.UserID)
+
+	response, err := h.workoutService.GetPersonalRecords(params.UserID, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewGetPersonalRecordsUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewGetPersonalRecordsNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewGetPersonalRecordsInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGetPersonalRecordsOK().WithPayload(response)
+}
+
+func (h workoutHandler) GetExerciseProgression(params op.GetExerciseProgressionParams) middleware.Responder {
+
+	ctxLog := toolsLogging.BuildLogger(params.HTTPRequest.Context())
+
+	ctxLog.Infof("WORKOUT_HANDLER: Getting %s progression for user: %s", params.Exercise, params.UserID)
+
+	response, err := h.workoutService.GetExerciseProgression(params.UserID, params.Exercise, params.Months, ctxLog)
+	if err != nil {
+		switch {
+		case errors.As(err, &customErrors.Unauthorized):
+			return op.NewGetExerciseProgressionUnauthorized().WithPayload(&unauthorizedErrorResponse)
+		case errors.As(err, &customErrors.NotFound):
+			return op.NewGetExerciseProgressionNotFound().WithPayload(&notFoundErrorResponse)
+		default:
+			return op.NewGetExerciseProgressionInternalServerError().WithPayload(&internalServerErrorResponse)
+		}
+	}
+
+	return op.NewGetExerciseProgressionOK().WithPayload(response)
+}
@@ -0,0 +1,20 @@
+package workout_handler
+
+import (
+	"gym-badges-api/restapi/operations/workouts"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+type IWorkoutHandler interface {
+	GetWorkoutHistory(params workouts.GetWorkoutHistoryParams) middleware.Responder
+	GetWorkout(params workouts.GetWorkoutParams) middleware.Responder
+	AddWorkout(params workouts.AddWorkoutParams) middleware.Responder
+	EditWorkout(params workouts.EditWorkoutParams) middleware.Responder
+	DeleteWorkout(params workouts.DeleteWorkoutParams) middleware.Responder
+
+	GetExerciseHistory(params workouts.GetExerciseHistoryParams) middleware.Responder
+
+	GetPersonalRecords(params workouts.GetPersonalRecordsParams) middleware.Responder
+	GetExerciseProgression(params workouts.GetExerciseProgressionParams) middleware.Responder
+}
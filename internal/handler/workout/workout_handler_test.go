@@ -0,0 +1,380 @@
+package workout_handler
+
+import (
+	"errors"
+	customErrors "gym-badges-api/internal/custom-errors"
+	"gym-badges-api/mocks/service"
+	"gym-badges-api/models"
+	op "gym-badges-api/restapi/operations/workouts"
+	toolsTesting "gym-badges-api/tools/testing"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandlerWorkoutSuite(t *testing.T) {
+	toolsTesting.ConfigureTestSuite(t, "HANDLER: Workout Test Suite")
+}
+
+var _ = Describe("HANDLER: Workout Test Suite", func() {
+
+	var (
+		mockCtrl           *gomock.Controller
+		mockWorkoutService *service.MockIWorkoutService
+		handler            IWorkoutHandler
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockWorkoutService = service.NewMockIWorkoutService(mockCtrl)
+
+		handler = NewWorkoutHandler(mockWorkoutService)
+	})
+
+	AfterEach(func() {
+		defer mockCtrl.Finish()
+
+	})
+
+	Context("GET /workouts/{user_id}", func() {
+
+		var (
+			params op.GetWorkoutHistoryParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewGetWorkoutHistoryParams()
+			params.HTTPRequest = new(http.Request)
+			params.Page = 1
+			params.UserID = "admin"
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.WorkoutHistoryResponse
+			ServiceError     error
+		}
+
+		DescribeTable("Checking get workout history handler cases", func(input Params) {
+
+			mockWorkoutService.EXPECT().GetWorkoutHistory(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.GetWorkoutHistory(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewGetWorkoutHistoryOK().WithPayload(&models.WorkoutHistoryResponse{
+					Workouts: []*models.WorkoutSession{
+						{
+							ID:   1,
+							Date: "2024-11-07",
+							Name: "Push",
+							Exercises: []*models.WorkoutExercise{
+								{
+									Name: "Bench press",
+									Sets: []*models.WorkoutSet{{Reps: 5, Weight: 100}},
+								},
+							},
+						},
+					},
+				}),
+				ServiceResponse: &models.WorkoutHistoryResponse{
+					Workouts: []*models.WorkoutSession{
+						{
+							ID:   1,
+							Date: "2024-11-07",
+							Name: "Push",
+							Exercises: []*models.WorkoutExercise{
+								{
+									Name: "Bench press",
+									Sets: []*models.WorkoutSet{{Reps: 5, Weight: 100}},
+								},
+							},
+						},
+					},
+				},
+				ServiceError: nil,
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewGetWorkoutHistoryNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildNotFoundError("user not found"),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewGetWorkoutHistoryInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    errors.New("panic"),
+			}),
+		)
+
+	})
+
+	Context("POST /workouts/{user_id}", func() {
+
+		var (
+			params op.AddWorkoutParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewAddWorkoutParams()
+			params.HTTPRequest = new(http.Request)
+			params.UserID = "admin"
+			params.AuthUserID = "admin"
+			params.Input = &models.WorkoutSessionRequest{Name: "Push"}
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.WorkoutSession
+			ServiceError     error
+		}
+
+		DescribeTable("Checking add workout handler cases", func(input Params) {
+
+			mockWorkoutService.EXPECT().CreateWorkout(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.AddWorkout(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Created Response (201)", Params{
+				ExpectedResponse: op.NewAddWorkoutCreated().WithPayload(&models.WorkoutSession{
+					ID:        1,
+					Date:      "2024-11-07",
+					Name:      "Push",
+					Exercises: []*models.WorkoutExercise{},
+				}),
+				ServiceResponse: &models.WorkoutSession{
+					ID:        1,
+					Date:      "2024-11-07",
+					Name:      "Push",
+					Exercises: []*models.WorkoutExercise{},
+				},
+				ServiceError: nil,
+			}),
+			Entry("CASE: Bad Request Error Response (400)", Params{
+				ExpectedResponse: op.NewAddWorkoutBadRequest().WithPayload(&models.GenericResponse{
+					Code:    "400",
+					Message: "Date cannot be in the future.",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildBadRequestError("Date cannot be in the future."),
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewAddWorkoutNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildNotFoundError("user not found"),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewAddWorkoutInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    errors.New("panic"),
+			}),
+		)
+
+		It("CASE: Unauthorized Error Response (401) when logging to another user", func() {
+
+			params.AuthUserID = "other"
+
+			mockWorkoutService.EXPECT().CreateWorkout(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			response := handler.AddWorkout(params)
+			Expect(response).To(BeEquivalentTo(op.NewAddWorkoutUnauthorized().WithPayload(&models.GenericResponse{
+				Code:    "401",
+				Message: "Unauthorized",
+			})))
+		})
+
+	})
+
+	Context("DELETE /workouts/{user_id}/session/{workout_id}", func() {
+
+		var (
+			params op.DeleteWorkoutParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewDeleteWorkoutParams()
+			params.HTTPRequest = new(http.Request)
+			params.UserID = "admin"
+			params.AuthUserID = "admin"
+			params.WorkoutID = 1
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceError     error
+		}
+
+		DescribeTable("Checking delete workout handler cases", func(input Params) {
+
+			mockWorkoutService.EXPECT().DeleteWorkout(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(input.ServiceError)
+
+			response := handler.DeleteWorkout(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewDeleteWorkoutOK(),
+				ServiceError:     nil,
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewDeleteWorkoutNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceError: customErrors.BuildNotFoundError("workout not found"),
+			}),
+		)
+
+	})
+
+	Context("GET /workouts/{user_id}/records", func() {
+
+		var (
+			params op.GetPersonalRecordsParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewGetPersonalRecordsParams()
+			params.HTTPRequest = new(http.Request)
+			params.UserID = "admin"
+			params.AuthUserID = "admin"
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.PersonalRecordsResponse
+			ServiceError     error
+		}
+
+		DescribeTable("Checking get personal records handler cases", func(input Params) {
+
+			mockWorkoutService.EXPECT().GetPersonalRecords(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.GetPersonalRecords(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewGetPersonalRecordsOK().WithPayload(&models.PersonalRecordsResponse{
+					Records: []*models.PersonalRecord{
+						{Exercise: "Squat", Type: "5RM", Weight: 125, Reps: 5, Date: "2024-11-07", WorkoutID: 2},
+					},
+				}),
+				ServiceResponse: &models.PersonalRecordsResponse{
+					Records: []*models.PersonalRecord{
+						{Exercise: "Squat", Type: "5RM", Weight: 125, Reps: 5, Date: "2024-11-07", WorkoutID: 2},
+					},
+				},
+				ServiceError: nil,
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewGetPersonalRecordsNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildNotFoundError("user not found"),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewGetPersonalRecordsInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    errors.New("panic"),
+			}),
+		)
+
+	})
+
+	Context("GET /workouts/{user_id}/records/progression", func() {
+
+		var (
+			params op.GetExerciseProgressionParams
+		)
+
+		BeforeEach(func() {
+			params = op.NewGetExerciseProgressionParams()
+			params.HTTPRequest = new(http.Request)
+			params.UserID = "admin"
+			params.AuthUserID = "admin"
+			params.Exercise = "Squat"
+			params.Months = 3
+		})
+
+		type Params struct {
+			ExpectedResponse any
+			ServiceResponse  *models.ExerciseProgressionResponse
+			ServiceError     error
+		}
+
+		DescribeTable("Checking get exercise progression handler cases", func(input Params) {
+
+			mockWorkoutService.EXPECT().GetExerciseProgression(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(input.ServiceResponse, input.ServiceError)
+
+			response := handler.GetExerciseProgression(params)
+			Expect(response).To(BeEquivalentTo(input.ExpectedResponse))
+		},
+			Entry("CASE: Success Response (200)", Params{
+				ExpectedResponse: op.NewGetExerciseProgressionOK().WithPayload(&models.ExerciseProgressionResponse{
+					Exercise: "Squat",
+					Points: []*models.ProgressionPoint{
+						{Date: "2024-11-07", TopWeight: 120, EstimatedEpley: 140, EstimatedBrzycki: 135, Volume: 600},
+					},
+					Records: []*models.PersonalRecord{},
+				}),
+				ServiceResponse: &models.ExerciseProgressionResponse{
+					Exercise: "Squat",
+					Points: []*models.ProgressionPoint{
+						{Date: "2024-11-07", TopWeight: 120, EstimatedEpley: 140, EstimatedBrzycki: 135, Volume: 600},
+					},
+					Records: []*models.PersonalRecord{},
+				},
+				ServiceError: nil,
+			}),
+			Entry("CASE: Not Found Error Response (404)", Params{
+				ExpectedResponse: op.NewGetExerciseProgressionNotFound().WithPayload(&models.GenericResponse{
+					Code:    "404",
+					Message: "Not Found",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    customErrors.BuildNotFoundError("user not found"),
+			}),
+			Entry("CASE: Internal Server Error Response (500)", Params{
+				ExpectedResponse: op.NewGetExerciseProgressionInternalServerError().WithPayload(&models.GenericResponse{
+					Code:    "500",
+					Message: "Internal Server Error",
+				}),
+				ServiceResponse: nil,
+				ServiceError:    errors.New("panic"),
+			}),
+		)
+
+	})
+
+})
@@ -1,13 +1,69 @@
 package badge_dao
 
 import (
+	"time"
+
 	log "github.com/sirupsen/logrus"
 )
 
 type IBadgeDAO interface {
+	// Returns the whole catalog, retired badges included, ordered by position
 	GetBadges(ctxLog *log.Entry) ([]*Badge, error)
-	AddBadge(userID string, badgeID int16, ctxLog *log.Entry) error
+	// Awards the badge and grants its exp to the user in one transaction. Conflict when the user already has it
+	AddBadge(award *UserBadge, ctxLog *log.Entry) error
 	GetBadge(badgeID int16, ctxLog *log.Entry) (*Badge, error)
-	DeleteBadge(userID string, badgeID int16, ctxLog *log.Entry) error
+	// Returns the deleted award, NotFound when the user does not have the badge. The exp granted is taken back
+	// in the same transaction
+	DeleteBadge(userID string, badgeID int16, ctxLog *log.Entry) (*UserBadge, error)
 	CheckBadge(userID string, badgeID int16, ctxLog *log.Entry) (bool, error)
+	// Returns the awards of the user, newest first
+	GetBadgeAwards(userID string, offset int32, size int32, ctxLog *log.Entry) ([]*UserBadge, error)
+	// Returns only the badges with criteria that are not retired, ordered by id
+	GetBadgesWithCriteria(ctxLog *log.Entry) ([]*Badge, error)
+	// Returns the auto badge rules of the badges that are not retired, with their conditions, ordered by badge
+	GetBadgeRules(ctxLog *log.Entry) ([]*BadgeRule, error)
+
+	// Recomputes the statistics of every badge, with the users attending since the date as active
+	RefreshBadgeStats(activeSince time.Time, ctxLog *log.Entry) error
+	GetBadgeStats(ctxLog *log.Entry) ([]*BadgeStat, error)
+
+	// ******** Catalog administration **********
+
+	// Creates the badge with its audit entry. The next free id is used when the badge has none
+	CreateBadge(badge *Badge, audit BadgeAudit, ctxLog *log.Entry) error
+	// Saves the badges with their audit entries. The experience of the users that achieved a badge follows
+	// the change of its exp, returns these users
+	UpdateBadges(badges []*Badge, audits []BadgeAudit, ctxLog *log.Entry) ([]string, error)
+	// Creates and updates the badges of a catalog file in one transaction. The created badges go parents first
+	SeedBadges(created []*Badge, updated []*Badge, audits []BadgeAudit, ctxLog *log.Entry) error
+	// Returns the audit entries of the badge, newest first
+	GetBadgeAudit(badgeID int16, ctxLog *log.Entry) ([]*BadgeAudit, error)
+	// Creates or replaces the rule of the badge, conditions included, with its audit entry
+	SaveBadgeRule(rule *BadgeRule, audit BadgeAudit, ctxLog *log.Entry) error
+	DeleteBadgeRule(badgeID int16, audit BadgeAudit, ctxLog *log.Entry) error
+
+	// ******** Translations **********
+
+	// Returns the translations of every badge in the locales
+	GetBadgeTranslations(locales []string, ctxLog *log.Entry) ([]*BadgeTranslation, error)
+	// Returns the translations of the badge, ordered by locale
+	GetTranslationsOfBadge(badgeID int16, ctxLog *log.Entry) ([]*BadgeTranslation, error)
+	// Creates or replaces the translation of the badge in its locale, with its audit entry
+	SaveBadgeTranslation(translation *BadgeTranslation, audit BadgeAudit, ctxLog *log.Entry) error
+	DeleteBadgeTranslation(badgeID int16, locale string, audit BadgeAudit, ctxLog *log.Entry) error
+
+	// ******** Badge claims **********
+
+	CreateBadgeClaim(claim *BadgeClaim, ctxLog *log.Entry) error
+	// Returns the claim with its vouches
+	GetBadgeClaim(claimID int64, ctxLog *log.Entry) (*BadgeClaim, error)
+	// Returns the claims with their vouches, newest first. Empty filters are not applied
+	GetBadgeClaims(userID string, status string, ctxLog *log.Entry) ([]*BadgeClaim, error)
+	// Conflict when the friend already vouched for the claim
+	AddClaimVouch(vouch *BadgeClaimVouch, ctxLog *log.Entry) error
+	// Saves the status and the review of the claim
+	UpdateBadgeClaim(claim *BadgeClaim, ctxLog *log.Entry) error
+	// Approves the pending claim and awards its badge, with the exp, in one transaction. Conflict when the claim
+	// is not pending anymore or the user already has the badge
+	ApproveBadgeClaim(claim *BadgeClaim, award *UserBadge, ctxLog *log.Entry) error
 }
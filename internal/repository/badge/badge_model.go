@@ -1,11 +1,188 @@
 package badge_dao
 
+import (
+	"time"
+
+	"gorm.io/gorm/schema"
+)
+
 type Badge struct {
-	ID            int16  `gorm:"primaryKey"`
-	Name          string `gorm:"not null"`
-	Description   string `gorm:"not null"`
-	Image         string `gorm:"not null"`
-	Exp           int64  `gorm:"not null"`
-	ParentBadgeID int16  `gorm:"null"`
-	ParentBadge   *Badge `gorm:"null"`
+	ID            int16          `gorm:"primaryKey"`
+	Name          string         `gorm:"not null"`
+	Description   string         `gorm:"not null"`
+	Image         string         `gorm:"not null"`
+	Exp           int64          `gorm:"not null"`
+	ParentBadgeID int16          `gorm:"null"`
+	ParentBadge   *Badge         `gorm:"null"`
+	Criteria      *BadgeCriteria `gorm:"foreignKey:BadgeID;constraint:OnDelete:CASCADE"`
+	Rule          *BadgeRule     `gorm:"foreignKey:BadgeID;constraint:OnDelete:CASCADE"`
+	// Order among the badges with the same parent
+	Position int16 `gorm:"not null;default:0"`
+	// Retired badges are kept by the users that achieved them, but cannot be achieved anymore
+	RetiredAt *time.Time `gorm:"null"`
+	// Claims of the badge need a proof or a witness, and are awarded once verified
+	RequiresProof bool `gorm:"not null;default:false"`
+}
+
+// Sources of a badge award
+const (
+	AwardSourceManual = "manual" // Claimed by the user
+	AwardSourceAuto   = "auto"   // Awarded by a rule or the criteria of a strength badge
+	AwardSourceAdmin  = "admin"  // Granted by a catalog administrator
+	AwardSourceClaim  = "claim"  // Claim with proof verified by friends or a moderator
+	AwardSourceSystem = "system" // Base category badge of every user, not an achievement
+)
+
+// UserBadge Award of a badge to a user, the join table of the user badges
+type UserBadge struct {
+	UserID     string    `gorm:"primaryKey"`
+	BadgeID    int16     `gorm:"primaryKey"`
+	AwardedAt  time.Time `gorm:"not null;default:now();index"`
+	Source     string    `gorm:"not null;default:manual"`
+	ExpGranted int64     `gorm:"not null;default:0"` // Follows the exp changes of the badge
+	GrantedBy  *string   `gorm:"null"`               // Admin of a grant, or moderator that approved a claim
+}
+
+// TableName keeps the table of the many2many relation, with the prefix of the connection
+func (UserBadge) TableName(namer schema.Namer) string {
+	return namer.JoinTableName("user_badges")
+}
+
+// BadgeStat Figures of a badge, refreshed periodically for the whole catalog
+type BadgeStat struct {
+	BadgeID         int16      `gorm:"primaryKey"`
+	Holders         int64      `gorm:"not null"`
+	ActiveHolders   int64      `gorm:"not null"` // Holders with an attendance in the active period
+	ActiveUsers     int64      `gorm:"not null"` // Users with an attendance in the active period
+	FirstAchieverID *string    `gorm:"null"`
+	FirstAchievedAt *time.Time `gorm:"null"`
+	// Average days from the signup to the award, without the awards backfilled at the signup
+	AvgDaysToEarn *float64  `gorm:"null"`
+	RefreshedAt   time.Time `gorm:"not null"`
+}
+
+// States of a badge claim
+const (
+	ClaimStatusPending  = "pending"
+	ClaimStatusApproved = "approved"
+	ClaimStatusRejected = "rejected"
+)
+
+// Kinds of uploaded proof
+const (
+	ProofTypePhoto = "photo"
+	ProofTypeVideo = "video"
+)
+
+// BadgeClaim Claim of a badge that requires proof. The badge is awarded when enough friends vouch for the
+// claim or a moderator approves it
+type BadgeClaim struct {
+	ID        int64   `gorm:"primary_key;autoIncrement"`
+	UserID    string  `gorm:"not null;index"`
+	BadgeID   int16   `gorm:"not null;index"`
+	Status    string  `gorm:"not null;index"`
+	ProofType *string `gorm:"null"`
+	Proof     *string `gorm:"null"` // URL of the uploaded proof
+	WitnessID *string `gorm:"null"` // Friend that saw the feat
+	// Moderator that approved or rejected the claim, missing when the friends approved it
+	ReviewerID *string           `gorm:"null"`
+	ReviewedAt *time.Time        `gorm:"null"`
+	Vouches    []BadgeClaimVouch `gorm:"foreignKey:ClaimID;constraint:OnDelete:CASCADE"`
+
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+// BadgeClaimVouch A friend of the user vouches for the claim
+type BadgeClaimVouch struct {
+	ClaimID  int64  `gorm:"primaryKey"`
+	FriendID string `gorm:"primaryKey"`
+
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+// BadgeCriteria A logged set meeting every threshold awards the badge
+type BadgeCriteria struct {
+	BadgeID   int16   `gorm:"primaryKey"`
+	Exercise  string  `gorm:"not null"` // Exercise name of the catalog, compared case insensitive
+	MinWeight float32 `gorm:"not null;type:decimal(6,2)"`
+	MinReps   int32   `gorm:"not null"`
+	// Minimum weight relative to the user's bodyweight (1.5 means 1.5 x bodyweight)
+	MinBodyweightRatio *float32 `gorm:"null;type:decimal(4,2)"`
+}
+
+// Metrics of the user that an auto badge rule can compare
+const (
+	MetricStreak          = "streak"           // Weeks of the current streak
+	MetricAttendances     = "attendances"      // Gym sessions
+	MetricAccountAgeDays  = "account_age_days" // Days since the user signed up
+	MetricTrainingHours   = "training_hours"   // Hours of the sessions with times
+	MetricSessionsBetween = "sessions_between" // Sessions started in the window of the day
+	MetricGlobalRank      = "global_rank"
+	MetricFriendsRank     = "friends_rank"
+	MetricFriendsCount    = "friends_count"
+)
+
+// Comparators of a metric with the threshold
+const (
+	ComparatorGTE = "gte"
+	ComparatorGT  = "gt"
+	ComparatorLTE = "lte"
+	ComparatorLT  = "lt"
+	ComparatorEQ  = "eq"
+)
+
+// BadgeRuleClause compares a metric of the user with a threshold
+type BadgeRuleClause struct {
+	Metric     string `gorm:"not null"`
+	Comparator string `gorm:"not null"`
+	Threshold  int64  `gorm:"not null"`
+	// Window of the sessions_between metric, in minutes of the local time of the user
+	FromMinute *int16 `gorm:"null"`
+	ToMinute   *int16 `gorm:"null"`
+}
+
+// BadgeRule The badge is awarded automatically when the rule and all its conditions are met
+type BadgeRule struct {
+	BadgeID         int16 `gorm:"primaryKey"`
+	BadgeRuleClause `gorm:"embedded"`
+	Conditions      []BadgeRuleCondition `gorm:"foreignKey:BadgeID;references:BadgeID;constraint:OnDelete:CASCADE"`
+}
+
+// BadgeRuleCondition Extra clause of a rule, like a minimum of friends for a ranking badge
+type BadgeRuleCondition struct {
+	ID              int64 `gorm:"primary_key;autoIncrement"`
+	BadgeID         int16 `gorm:"not null;index"`
+	BadgeRuleClause `gorm:"embedded"`
+}
+
+// Changes of the catalog administration
+const (
+	BadgeActionCreate    = "create"
+	BadgeActionEdit      = "edit"
+	BadgeActionReparent  = "reparent"
+	BadgeActionRetire    = "retire"
+	BadgeActionReorder   = "reorder"
+	BadgeActionImage     = "image"
+	BadgeActionRule      = "rule"
+	BadgeActionTranslate = "translate"
+)
+
+// BadgeAudit records who changed a badge of the catalog and how
+type BadgeAudit struct {
+	ID      int64  `gorm:"primary_key;autoIncrement"`
+	BadgeID int16  `gorm:"not null;index"`
+	AdminID string `gorm:"not null"`
+	Action  string `gorm:"not null"`
+	Changes string `gorm:"not null"` // Readable list of the changed fields with their old and new values
+
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+// BadgeTranslation Name and description of a badge in a locale. The name and description of the badge itself are
+// the default locale ones, used when a translation or its description is missing
+type BadgeTranslation struct {
+	BadgeID     int16  `gorm:"primaryKey"`
+	Locale      string `gorm:"primaryKey;size:8"`
+	Name        string `gorm:"not null"`
+	Description string `gorm:"not null;default:''"`
 }
@@ -6,16 +6,25 @@ import (
 	badgeModelDB "gym-badges-api/internal/repository/badge"
 	"gym-badges-api/internal/repository/config/postgresql"
 	userModelDB "gym-badges-api/internal/repository/user"
+	"slices"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const (
-	userNotFoundErrorMsg  = "User not found"
-	badgeNotFoundErrorMsg = "Badge not found"
+	userNotFoundErrorMsg    = "User not found"
+	badgeNotFoundErrorMsg   = "Badge not found"
+	awardNotFoundErrorMsg   = "Badge not achieved"
+	awardConflictErrorMsg   = "Badge %d already achieved."
+	claimNotPendingErrorMsg = "The claim is not pending anymore."
 )
 
+// badgeIDLockKey Advisory lock taken while a new badge id is chosen
+const badgeIDLockKey = 7261
+
 type badgeDAO struct {
 	connection *gorm.DB
 }
@@ -36,6 +45,8 @@ func (dao badgeDAO) GetBadges(ctxLog *log.Entry) ([]*badgeModelDB.Badge, error)
 	var badges = make([]*badgeModelDB.Badge, 0)
 
 	queryResult := dao.connection.
+		Preload("Criteria").
+		Order("position ASC, id ASC").
 		Find(&badges)
 
 	if queryResult.Error != nil && !errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
@@ -69,18 +80,26 @@ func (dao badgeDAO) GetBadge(badgeID int16, ctxLog *log.Entry) (*badgeModelDB.Ba
 	return &badge, nil
 }
 
-func (dao badgeDAO) AddBadge(userID string, badgeID int16, ctxLog *log.Entry) error {
+func (dao badgeDAO) AddBadge(award *badgeModelDB.UserBadge, ctxLog *log.Entry) error {
 
-	ctxLog.Debugf("BADGE_DAO: Adding badge %d to user %s", badgeID, userID)
+	ctxLog.Debugf("BADGE_DAO: Adding badge %d to user %s", award.BadgeID, award.UserID)
 
 	if err := dao.connection.Error; err != nil {
 		return err
 	}
 
+	return dao.connection.Transaction(func(tx *gorm.DB) error {
+		return awardBadge(tx, award)
+	})
+}
+
+// awardBadge inserts the award and grants its exp to the user
+func awardBadge(tx *gorm.DB, award *badgeModelDB.UserBadge) error {
+
 	var user userModelDB.User
 
-	queryResult := dao.connection.
-		Where("id = ?", userID).
+	queryResult := tx.
+		Where("id = ?", award.UserID).
 		First(&user)
 
 	if queryResult.Error != nil {
@@ -90,33 +109,70 @@ func (dao badgeDAO) AddBadge(userID string, badgeID int16, ctxLog *log.Entry) er
 		return queryResult.Error
 	}
 
-	user.Badges = append(user.Badges, &badgeModelDB.Badge{ID: badgeID})
+	// The award key makes concurrent awards of the same badge insert only once
+	queryResult = tx.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(award)
 
-	return dao.connection.Save(user).Error
+	if queryResult.Error != nil {
+		return queryResult.Error
+	}
+	if queryResult.RowsAffected == 0 {
+		return customErrors.BuildConflictError(awardConflictErrorMsg, award.BadgeID)
+	}
+
+	return addExperience(tx, award.UserID, award.ExpGranted)
 }
 
-func (dao badgeDAO) DeleteBadge(userID string, badgeID int16, ctxLog *log.Entry) error {
+func (dao badgeDAO) DeleteBadge(userID string, badgeID int16, ctxLog *log.Entry) (*badgeModelDB.UserBadge, error) {
 
-	ctxLog.Debugf("BADGE_DAO: Adding badge %d to user %s", badgeID, userID)
+	ctxLog.Debugf("BADGE_DAO: Deleting badge %d of user %s", badgeID, userID)
 
 	if err := dao.connection.Error; err != nil {
-		return err
+		return nil, err
 	}
 
-	var user userModelDB.User
+	var award badgeModelDB.UserBadge
 
-	queryResult := dao.connection.
+	err := dao.connection.Transaction(func(tx *gorm.DB) error {
+
+		queryResult := tx.
+			Clauses(clause.Returning{}).
+			Where("user_id = ? AND badge_id = ?", userID, badgeID).
+			Delete(&award)
+
+		if queryResult.Error != nil {
+			return queryResult.Error
+		}
+		if queryResult.RowsAffected == 0 {
+			return customErrors.BuildNotFoundError(awardNotFoundErrorMsg)
+		}
+
+		// Take back the exp the badge granted
+		return addExperience(tx, userID, -award.ExpGranted)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &award, nil
+}
+
+// addExperience changes the experience of the user with an atomic increment, without getting negative
+func addExperience(tx *gorm.DB, userID string, exp int64) error {
+
+	queryResult := tx.Model(&userModelDB.User{}).
 		Where("id = ?", userID).
-		First(&user)
+		UpdateColumn("experience", gorm.Expr("GREATEST(experience + ?, 0)", exp))
 
 	if queryResult.Error != nil {
-		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
-			return customErrors.BuildNotFoundError(userNotFoundErrorMsg)
-		}
 		return queryResult.Error
 	}
+	if queryResult.RowsAffected == 0 {
+		return customErrors.BuildNotFoundError(userNotFoundErrorMsg)
+	}
 
-	return dao.connection.Unscoped().Model(&user).Association("Badges").Unscoped().Delete(badgeModelDB.Badge{ID: badgeID})
+	return nil
 }
 
 func (dao badgeDAO) CheckBadge(userID string, badgeID int16, ctxLog *log.Entry) (bool, error) {
@@ -149,3 +205,635 @@ func (dao badgeDAO) CheckBadge(userID string, badgeID int16, ctxLog *log.Entry)
 
 	return (len(badges) > 0), nil
 }
+
+func (dao badgeDAO) GetBadgeAwards(userID string, offset int32, size int32, ctxLog *log.Entry) ([]*badgeModelDB.UserBadge, error) {
+
+	ctxLog.Debugf("BADGE_DAO: Getting badge awards for user: %s offset: %d size: %d", userID, offset, size)
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	var user userModelDB.User
+
+	queryResult := dao.connection.
+		Where("id = ?", userID).
+		First(&user)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return nil, customErrors.BuildNotFoundError(userNotFoundErrorMsg)
+		}
+		return nil, queryResult.Error
+	}
+
+	awards := make([]*badgeModelDB.UserBadge, 0)
+
+	// The base category badges of every user are not awards
+	queryResult = dao.connection.
+		Where("user_id = ?", userID).
+		Where("source <> ?", badgeModelDB.AwardSourceSystem).
+		Order("awarded_at DESC, badge_id DESC").
+		Limit(int(size)).
+		Offset(int(offset)).
+		Find(&awards)
+
+	if queryResult.Error != nil {
+		return nil, queryResult.Error
+	}
+
+	return awards, nil
+}
+
+func (dao badgeDAO) GetBadgesWithCriteria(ctxLog *log.Entry) ([]*badgeModelDB.Badge, error) {
+
+	ctxLog.Debugf("BADGE_DAO: Getting badges with criteria")
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	var badges = make([]*badgeModelDB.Badge, 0)
+
+	queryResult := dao.connection.
+		Joins("Criteria").
+		Where(`"Criteria".badge_id IS NOT NULL`).
+		Where("badge.retired_at IS NULL").
+		Order("badge.id").
+		Find(&badges)
+
+	if queryResult.Error != nil && !errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+		return nil, queryResult.Error
+	}
+
+	return badges, nil
+}
+
+func (dao badgeDAO) GetBadgeRules(ctxLog *log.Entry) ([]*badgeModelDB.BadgeRule, error) {
+
+	ctxLog.Debugf("BADGE_DAO: Getting badge rules")
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	var rules = make([]*badgeModelDB.BadgeRule, 0)
+
+	active := dao.connection.Model(&badgeModelDB.Badge{}).
+		Select("id").
+		Where("retired_at IS NULL")
+
+	queryResult := dao.connection.
+		Preload("Conditions").
+		Where("badge_id IN (?)", active).
+		Order("badge_id").
+		Find(&rules)
+
+	if queryResult.Error != nil {
+		return nil, queryResult.Error
+	}
+
+	return rules, nil
+}
+
+// *******************************************************************
+// STATISTICS
+// *******************************************************************
+
+func (dao badgeDAO) RefreshBadgeStats(activeSince time.Time, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("BADGE_DAO: Refreshing badge statistics with users active since %s", activeSince.Format(time.DateOnly))
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	return dao.connection.Transaction(func(tx *gorm.DB) error {
+
+		active := tx.Model(&userModelDB.GymAttendance{}).
+			Select("user_id").
+			Where("date >= ?", activeSince)
+
+		var activeUsers int64
+		if err := tx.Model(&userModelDB.GymAttendance{}).
+			Where("date >= ?", activeSince).
+			Distinct("user_id").
+			Count(&activeUsers).Error; err != nil {
+			return err
+		}
+
+		// The awards backfilled at the signup would make the badges look earned on the first day, and the
+		// base category badges given at the signup are not earned at all
+		stats := tx.Model(&badgeModelDB.Badge{}).
+			Select(`badge.id, COUNT(user_badges.user_id), COUNT(user_badges.user_id) FILTER (WHERE user_badges.user_id IN (?)), ?,
+				(ARRAY_AGG(user_badges.user_id ORDER BY user_badges.awarded_at, user_badges.user_id))[1], MIN(user_badges.awarded_at),
+				AVG(EXTRACT(EPOCH FROM user_badges.awarded_at - "user".created_at) / 86400) FILTER (WHERE user_badges.awarded_at > "user".created_at), ?`,
+				active, activeUsers, time.Now()).
+			Joins("LEFT JOIN user_badges ON user_badges.badge_id = badge.id AND user_badges.source <> ?", badgeModelDB.AwardSourceSystem).
+			Joins(`LEFT JOIN "user" ON "user".id = user_badges.user_id`).
+			Group("badge.id")
+
+		if err := tx.Where("1 = 1").Delete(&badgeModelDB.BadgeStat{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(`INSERT INTO badge_stat (badge_id, holders, active_holders, active_users, first_achiever_id,
+			first_achieved_at, avg_days_to_earn, refreshed_at) ?`, stats).Error
+	})
+}
+
+func (dao badgeDAO) GetBadgeStats(ctxLog *log.Entry) ([]*badgeModelDB.BadgeStat, error) {
+
+	ctxLog.Debugf("BADGE_DAO: Getting badge statistics")
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	stats := make([]*badgeModelDB.BadgeStat, 0)
+
+	queryResult := dao.connection.
+		Order("badge_id").
+		Find(&stats)
+
+	if queryResult.Error != nil {
+		return nil, queryResult.Error
+	}
+
+	return stats, nil
+}
+
+// *******************************************************************
+// CATALOG ADMINISTRATION
+// *******************************************************************
+
+func (dao badgeDAO) CreateBadge(badge *badgeModelDB.Badge, audit badgeModelDB.BadgeAudit, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("BADGE_DAO: Creating badge %s", badge.Name)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	return dao.connection.Transaction(func(tx *gorm.DB) error {
+
+		// The seeded ids are not taken from the sequence. The lock, released with the transaction, keeps two
+		// creations from reading the same next id
+		if badge.ID == 0 {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", badgeIDLockKey).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&badgeModelDB.Badge{}).Select("COALESCE(MAX(id), 0) + 1").Scan(&badge.ID).Error; err != nil {
+				return err
+			}
+		}
+
+		columns := badgeColumns(badge)
+		columns["id"] = badge.ID
+
+		if err := tx.Model(&badgeModelDB.Badge{}).Create(columns).Error; err != nil {
+			return err
+		}
+
+		audit.BadgeID = badge.ID
+		return tx.Create(&audit).Error
+	})
+}
+
+func (dao badgeDAO) UpdateBadges(badges []*badgeModelDB.Badge, audits []badgeModelDB.BadgeAudit, ctxLog *log.Entry) ([]string, error) {
+
+	ctxLog.Debugf("BADGE_DAO: Updating %d badges", len(badges))
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0)
+
+	err := dao.connection.Transaction(func(tx *gorm.DB) error {
+
+		for _, badge := range badges {
+			holderIDs, err := updateBadge(tx, badge)
+			if err != nil {
+				return err
+			}
+			for _, userID := range holderIDs {
+				if !slices.Contains(userIDs, userID) {
+					userIDs = append(userIDs, userID)
+				}
+			}
+		}
+
+		for i := range audits {
+			if err := tx.Create(&audits[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return userIDs, nil
+}
+
+func (dao badgeDAO) SeedBadges(created []*badgeModelDB.Badge, updated []*badgeModelDB.Badge, audits []badgeModelDB.BadgeAudit,
+	ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("BADGE_DAO: Seeding %d new and %d changed badges", len(created), len(updated))
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	return dao.connection.Transaction(func(tx *gorm.DB) error {
+
+		for _, badge := range created {
+			columns := badgeColumns(badge)
+			columns["id"] = badge.ID
+
+			if err := tx.Model(&badgeModelDB.Badge{}).Create(columns).Error; err != nil {
+				return err
+			}
+		}
+
+		// The catalog tool runs apart from the server and its events, the holders are not notified
+		for _, badge := range updated {
+			if _, err := updateBadge(tx, badge); err != nil {
+				return err
+			}
+		}
+
+		for i := range audits {
+			if err := tx.Create(&audits[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// updateBadge saves the badge. The experience of the users that achieved it follows the change of its exp,
+// returns these users
+func updateBadge(tx *gorm.DB, badge *badgeModelDB.Badge) ([]string, error) {
+
+	var stored badgeModelDB.Badge
+	if err := tx.Where("id = ?", badge.ID).First(&stored).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.BuildNotFoundError(badgeNotFoundErrorMsg)
+		}
+		return nil, err
+	}
+
+	holderIDs := make([]string, 0)
+
+	// Users keep the experience of their badges up to date, without getting negative
+	if change := badge.Exp - stored.Exp; change != 0 {
+		if err := tx.Model(&badgeModelDB.UserBadge{}).
+			Where("badge_id = ?", badge.ID).
+			Order("user_id").
+			Pluck("user_id", &holderIDs).Error; err != nil {
+			return nil, err
+		}
+
+		if err := tx.Model(&userModelDB.User{}).
+			Where("id IN ?", holderIDs).
+			UpdateColumn("experience", gorm.Expr("GREATEST(experience + ?, 0)", change)).Error; err != nil {
+			return nil, err
+		}
+
+		// So that removing the badge takes back what the user has
+		if err := tx.Model(&badgeModelDB.UserBadge{}).
+			Where("badge_id = ?", badge.ID).
+			UpdateColumn("exp_granted", gorm.Expr("exp_granted + ?", change)).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	err := tx.Model(&badgeModelDB.Badge{}).
+		Where("id = ?", badge.ID).
+		Updates(badgeColumns(badge)).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return holderIDs, nil
+}
+
+// badgeColumns maps the badge to its columns, so that badges without parent are saved with a null parent
+func badgeColumns(badge *badgeModelDB.Badge) map[string]any {
+
+	var parent any
+	if badge.ParentBadgeID != 0 {
+		parent = badge.ParentBadgeID
+	}
+
+	return map[string]any{
+		"name":            badge.Name,
+		"description":     badge.Description,
+		"image":           badge.Image,
+		"exp":             badge.Exp,
+		"parent_badge_id": parent,
+		"position":        badge.Position,
+		"retired_at":      badge.RetiredAt,
+		"requires_proof":  badge.RequiresProof,
+	}
+}
+
+func (dao badgeDAO) GetBadgeAudit(badgeID int16, ctxLog *log.Entry) ([]*badgeModelDB.BadgeAudit, error) {
+
+	ctxLog.Debugf("BADGE_DAO: Getting audit of badge %d", badgeID)
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	audit := make([]*badgeModelDB.BadgeAudit, 0)
+
+	queryResult := dao.connection.
+		Where("badge_id = ?", badgeID).
+		Order("created_at DESC, id DESC").
+		Find(&audit)
+
+	if queryResult.Error != nil {
+		return nil, queryResult.Error
+	}
+
+	return audit, nil
+}
+
+func (dao badgeDAO) SaveBadgeRule(rule *badgeModelDB.BadgeRule, audit badgeModelDB.BadgeAudit, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("BADGE_DAO: Saving rule of badge %d", rule.BadgeID)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	return dao.connection.Transaction(func(tx *gorm.DB) error {
+
+		// The conditions of the old rule are replaced
+		if err := tx.Where("badge_id = ?", rule.BadgeID).Delete(&badgeModelDB.BadgeRuleCondition{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Save(rule).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&audit).Error
+	})
+}
+
+func (dao badgeDAO) DeleteBadgeRule(badgeID int16, audit badgeModelDB.BadgeAudit, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("BADGE_DAO: Deleting rule of badge %d", badgeID)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	return dao.connection.Transaction(func(tx *gorm.DB) error {
+
+		if err := tx.Where("badge_id = ?", badgeID).Delete(&badgeModelDB.BadgeRuleCondition{}).Error; err != nil {
+			return err
+		}
+
+		queryResult := tx.Where("badge_id = ?", badgeID).Delete(&badgeModelDB.BadgeRule{})
+		if queryResult.Error != nil {
+			return queryResult.Error
+		}
+		if queryResult.RowsAffected == 0 {
+			return customErrors.BuildNotFoundError("Badge rule not found")
+		}
+
+		return tx.Create(&audit).Error
+	})
+}
+
+// *******************************************************************
+// TRANSLATIONS
+// *******************************************************************
+
+func (dao badgeDAO) GetBadgeTranslations(locales []string, ctxLog *log.Entry) ([]*badgeModelDB.BadgeTranslation, error) {
+
+	ctxLog.Debugf("BADGE_DAO: Getting badge translations in %v", locales)
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	var translations = make([]*badgeModelDB.BadgeTranslation, 0)
+
+	queryResult := dao.connection.
+		Where("locale IN ?", locales).
+		Find(&translations)
+
+	if queryResult.Error != nil {
+		return nil, queryResult.Error
+	}
+
+	return translations, nil
+}
+
+func (dao badgeDAO) GetTranslationsOfBadge(badgeID int16, ctxLog *log.Entry) ([]*badgeModelDB.BadgeTranslation, error) {
+
+	ctxLog.Debugf("BADGE_DAO: Getting translations of badge %d", badgeID)
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	var translations = make([]*badgeModelDB.BadgeTranslation, 0)
+
+	queryResult := dao.connection.
+		Where("badge_id = ?", badgeID).
+		Order("locale").
+		Find(&translations)
+
+	if queryResult.Error != nil {
+		return nil, queryResult.Error
+	}
+
+	return translations, nil
+}
+
+func (dao badgeDAO) SaveBadgeTranslation(translation *badgeModelDB.BadgeTranslation, audit badgeModelDB.BadgeAudit, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("BADGE_DAO: Saving %s translation of badge %d", translation.Locale, translation.BadgeID)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	return dao.connection.Transaction(func(tx *gorm.DB) error {
+
+		if err := tx.Save(translation).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&audit).Error
+	})
+}
+
+func (dao badgeDAO) DeleteBadgeTranslation(badgeID int16, locale string, audit badgeModelDB.BadgeAudit, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("BADGE_DAO: Deleting %s translation of badge %d", locale, badgeID)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	return dao.connection.Transaction(func(tx *gorm.DB) error {
+
+		queryResult := tx.Where("badge_id = ? AND locale = ?", badgeID, locale).Delete(&badgeModelDB.BadgeTranslation{})
+		if queryResult.Error != nil {
+			return queryResult.Error
+		}
+		if queryResult.RowsAffected == 0 {
+			return customErrors.BuildNotFoundError("Badge translation not found")
+		}
+
+		return tx.Create(&audit).Error
+	})
+}
+
+// *******************************************************************
+// BADGE CLAIMS
+// *******************************************************************
+
+func (dao badgeDAO) CreateBadgeClaim(claim *badgeModelDB.BadgeClaim, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("BADGE_DAO: Creating claim of badge %d for user %s", claim.BadgeID, claim.UserID)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	return dao.connection.Create(claim).Error
+}
+
+func (dao badgeDAO) GetBadgeClaim(claimID int64, ctxLog *log.Entry) (*badgeModelDB.BadgeClaim, error) {
+
+	ctxLog.Debugf("BADGE_DAO: Getting badge claim %d", claimID)
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	var claim badgeModelDB.BadgeClaim
+
+	queryResult := dao.connection.
+		Preload("Vouches").
+		Where("id = ?", claimID).
+		First(&claim)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return nil, customErrors.BuildNotFoundError("Badge claim not found")
+		}
+		return nil, queryResult.Error
+	}
+
+	return &claim, nil
+}
+
+func (dao badgeDAO) GetBadgeClaims(userID string, status string, ctxLog *log.Entry) ([]*badgeModelDB.BadgeClaim, error) {
+
+	ctxLog.Debugf("BADGE_DAO: Getting badge claims of user %q with status %q", userID, status)
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	claims := make([]*badgeModelDB.BadgeClaim, 0)
+
+	query := dao.connection.Preload("Vouches")
+
+	if userID != "" {
+		query = query.Where("user_id = ?", userID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	queryResult := query.
+		Order("created_at DESC, id DESC").
+		Find(&claims)
+
+	if queryResult.Error != nil {
+		return nil, queryResult.Error
+	}
+
+	return claims, nil
+}
+
+func (dao badgeDAO) AddClaimVouch(vouch *badgeModelDB.BadgeClaimVouch, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("BADGE_DAO: Adding vouch of %s to badge claim %d", vouch.FriendID, vouch.ClaimID)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	queryResult := dao.connection.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(vouch)
+
+	if queryResult.Error != nil {
+		return queryResult.Error
+	}
+	if queryResult.RowsAffected == 0 {
+		return customErrors.BuildConflictError("You already vouched for the claim.")
+	}
+
+	return nil
+}
+
+func (dao badgeDAO) UpdateBadgeClaim(claim *badgeModelDB.BadgeClaim, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("BADGE_DAO: Updating badge claim %d to %s", claim.ID, claim.Status)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	return dao.connection.
+		Model(claim).
+		Select("status", "proof", "reviewer_id", "reviewed_at").
+		Updates(claim).Error
+}
+
+func (dao badgeDAO) ApproveBadgeClaim(claim *badgeModelDB.BadgeClaim, award *badgeModelDB.UserBadge, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("BADGE_DAO: Approving badge claim %d", claim.ID)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	return dao.connection.Transaction(func(tx *gorm.DB) error {
+
+		// Only a pending claim is approved, so concurrent approvals award the badge once
+		queryResult := tx.
+			Model(&badgeModelDB.BadgeClaim{}).
+			Where("id = ? AND status = ?", claim.ID, badgeModelDB.ClaimStatusPending).
+			Updates(map[string]any{
+				"status":      badgeModelDB.ClaimStatusApproved,
+				"reviewer_id": claim.ReviewerID,
+				"reviewed_at": claim.ReviewedAt,
+			})
+
+		if queryResult.Error != nil {
+			return queryResult.Error
+		}
+		if queryResult.RowsAffected == 0 {
+			return customErrors.BuildConflictError(claimNotPendingErrorMsg)
+		}
+
+		return awardBadge(tx, award)
+	})
+}
@@ -2,9 +2,15 @@ package postgresql
 
 import (
 	"fmt"
+	badgeModelDB "gym-badges-api/internal/repository/badge"
+	exerciseModelDB "gym-badges-api/internal/repository/exercise"
+	goalModelDB "gym-badges-api/internal/repository/goal"
+	gymModelDB "gym-badges-api/internal/repository/gym"
 	"gym-badges-api/internal/repository/user"
+	workoutModelDB "gym-badges-api/internal/repository/workout"
 	toolsConfig "gym-badges-api/tools/config"
 	toolsLogging "gym-badges-api/tools/logging"
+	"sync"
 	"time"
 
 	"gorm.io/driver/postgres"
@@ -19,6 +25,10 @@ const (
 
 var (
 	Config GormConfiguration
+
+	// Every DAO shares one pool, and the migrations run once
+	connection     *gorm.DB
+	connectionOnce sync.Once
 )
 
 type GormConfiguration struct {
@@ -39,7 +49,15 @@ func LoadConfig() {
 	toolsConfig.LoadGenericConfig(&Config)
 }
 
+// OpenConnection returns the connection of the service, opened and migrated on the first call
 func OpenConnection() *gorm.DB {
+	connectionOnce.Do(func() {
+		connection = openConnection()
+	})
+	return connection
+}
+
+func openConnection() *gorm.DB {
 
 	ctxLogger := toolsLogging.BuildLogger()
 
@@ -85,10 +103,58 @@ func OpenConnection() *gorm.DB {
 		ctxLogger.Info("postgres-gorm connection successfully established")
 	}
 
-	if err = DbConnection.AutoMigrate(&user.User{}, &user.GymAttendance{}, &user.FatHistory{}, &user.WeightHistory{}, &user.Preference{}); err != nil {
+	// The user badges keep the award of each badge
+	if err = DbConnection.SetupJoinTable(&user.User{}, "Badges", &badgeModelDB.UserBadge{}); err != nil {
+		ctxLogger.Errorf("postgres-gorm migration failed: %s", err)
+		return nil
+	}
+
+	// The awards before the award details were recorded are backfilled once
+	backfillAwards := !DbConnection.Migrator().HasColumn(&badgeModelDB.UserBadge{}, "AwardedAt")
+
+	if err = DbConnection.AutoMigrate(&user.User{}, &user.GymAttendance{}, &user.FatHistory{}, &user.WeightHistory{}, &user.Preference{},
+		&user.StreakFreeze{}, &user.VacationWeek{}, &user.VerifiedAttendance{}, &user.WeeklyGoalChange{},
+		&workoutModelDB.WorkoutSession{}, &workoutModelDB.WorkoutExercise{}, &workoutModelDB.WorkoutSet{}, &workoutModelDB.PersonalRecord{},
+		&exerciseModelDB.Exercise{}, &badgeModelDB.Badge{}, &badgeModelDB.BadgeCriteria{}, &badgeModelDB.BadgeRule{},
+		&badgeModelDB.BadgeRuleCondition{}, &badgeModelDB.BadgeAudit{}, &badgeModelDB.BadgeClaim{}, &badgeModelDB.BadgeClaimVouch{},
+		&badgeModelDB.BadgeStat{}, &badgeModelDB.BadgeTranslation{}, &goalModelDB.Goal{},
+		&gymModelDB.Gym{}, &gymModelDB.UsedQRCode{}); err != nil {
 		ctxLogger.Errorf("postgres-gorm migration failed: %s", err)
 		return nil
 	}
 
+	if backfillAwards {
+		if err = backfillBadgeAwards(DbConnection); err != nil {
+			ctxLogger.Errorf("postgres-gorm badge awards backfill failed: %s", err)
+			return nil
+		}
+	}
+
+	// The base category badges were given at the signup as manual awards
+	if err = DbConnection.Model(&badgeModelDB.UserBadge{}).
+		Where("badge_id < 0 AND source <> ?", badgeModelDB.AwardSourceSystem).
+		Update("source", badgeModelDB.AwardSourceSystem).Error; err != nil {
+		ctxLogger.Errorf("postgres-gorm category badges migration failed: %s", err)
+		return nil
+	}
+
 	return DbConnection
 }
+
+// backfillBadgeAwards gives the old awards a best-effort record: the signup of the user as the award time,
+// the current exp of the badge, and the auto source for the badges with a rule
+func backfillBadgeAwards(db *gorm.DB) error {
+
+	signups := db.Model(&user.User{}).Select("created_at").Where("id = user_id")
+	exps := db.Model(&badgeModelDB.Badge{}).Select("exp").Where("id = badge_id")
+	rules := db.Model(&badgeModelDB.BadgeRule{}).Select("badge_id")
+
+	return db.Model(&badgeModelDB.UserBadge{}).
+		Where("1 = 1").
+		Updates(map[string]any{
+			"awarded_at":  gorm.Expr("COALESCE((?), awarded_at)", signups),
+			"exp_granted": gorm.Expr("COALESCE((?), 0)", exps),
+			"source": gorm.Expr("CASE WHEN badge_id IN (?) THEN ? ELSE ? END", rules,
+				badgeModelDB.AwardSourceAuto, badgeModelDB.AwardSourceManual),
+		}).Error
+}
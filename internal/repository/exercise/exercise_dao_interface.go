@@ -0,0 +1,16 @@
+package exercise_dao
+
+import (
+	log "github.com/sirupsen/logrus"
+)
+
+// Exercises visible to an user are the ones of the catalog and his custom ones
+type IExerciseDAO interface {
+	SearchExercises(userID string, filter ExerciseFilter, offset int32, size int32, ctxLog *log.Entry) ([]*Exercise, error)
+	GetExercise(userID string, exerciseID int64, ctxLog *log.Entry) (*Exercise, error)
+	// Name comparison is case insensitive
+	GetExerciseByName(userID string, name string, ctxLog *log.Entry) (*Exercise, error)
+	CreateExercise(exercise *Exercise, ctxLog *log.Entry) error
+	// Only custom exercises can be deleted
+	DeleteExercise(userID string, exerciseID int64, ctxLog *log.Entry) error
+}
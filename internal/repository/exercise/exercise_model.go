@@ -0,0 +1,30 @@
+package exercise_dao
+
+import (
+	badgeModelDB "gym-badges-api/internal/repository/badge"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type Exercise struct {
+	ID              int64               `gorm:"primaryKey;autoIncrement"`
+	Name            string              `gorm:"not null;index"`
+	MuscleGroups    pq.StringArray      `gorm:"not null;type:text[]"`
+	Equipment       string              `gorm:"not null;index"`
+	BadgeCategoryID int16               `gorm:"not null;index"`
+	BadgeCategory   *badgeModelDB.Badge `gorm:"null"`
+	CreatorID       *string             `gorm:"null;index"` // Null for the exercises of the catalog
+
+	CreatedAt time.Time `gorm:"null" json:"created_at"`
+	UpdatedAt time.Time `gorm:"null" json:"updated_at"`
+	DeletedAt time.Time `gorm:"null" json:"deleted_at"`
+}
+
+// ExerciseFilter Empty fields are not applied
+type ExerciseFilter struct {
+	Name            string
+	MuscleGroup     string
+	Equipment       string
+	BadgeCategoryID int16
+}
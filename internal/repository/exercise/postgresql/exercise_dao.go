@@ -0,0 +1,178 @@
+package postgresql
+
+import (
+	"errors"
+	customErrors "gym-badges-api/internal/custom-errors"
+	"gym-badges-api/internal/repository/config/postgresql"
+	exerciseModelDB "gym-badges-api/internal/repository/exercise"
+	userModelDB "gym-badges-api/internal/repository/user"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	userNotFoundErrorMsg     = "User not found"
+	exerciseNotFoundErrorMsg = "Exercise not found"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type exerciseDAO struct {
+	connection *gorm.DB
+}
+
+func NewExerciseDAO() exerciseModelDB.IExerciseDAO {
+	connection := postgresql.OpenConnection()
+	return &exerciseDAO{connection: connection}
+}
+
+func (dao exerciseDAO) visibleTo(userID string) *gorm.DB {
+	return dao.connection.
+		Preload("BadgeCategory").
+		Where("creator_id IS NULL OR creator_id = ?", userID)
+}
+
+func (dao exerciseDAO) SearchExercises(userID string, filter exerciseModelDB.ExerciseFilter, offset int32, size int32,
+	ctxLog *log.Entry) ([]*exerciseModelDB.Exercise, error) {
+
+	ctxLog.Debugf("EXERCISE_DAO: Searching exercises for user: %s filter: %+v", userID, filter)
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	var exercises = make([]*exerciseModelDB.Exercise, 0)
+
+	query := dao.visibleTo(userID)
+
+	if filter.Name != "" {
+		query = query.Where("name ILIKE ?", "%"+likeEscaper.Replace(filter.Name)+"%")
+	}
+	if filter.MuscleGroup != "" {
+		query = query.Where("? = ANY(muscle_groups)", filter.MuscleGroup)
+	}
+	if filter.Equipment != "" {
+		query = query.Where("equipment = ?", filter.Equipment)
+	}
+	if filter.BadgeCategoryID != 0 {
+		query = query.Where("badge_category_id = ?", filter.BadgeCategoryID)
+	}
+
+	queryResult := query.
+		Order("name, id").
+		Limit(int(size)).
+		Offset(int(offset)).
+		Find(&exercises)
+
+	if queryResult.Error != nil {
+		return nil, queryResult.Error
+	}
+
+	return exercises, nil
+}
+
+func (dao exerciseDAO) GetExercise(userID string, exerciseID int64, ctxLog *log.Entry) (*exerciseModelDB.Exercise, error) {
+
+	ctxLog.Debugf("EXERCISE_DAO: Getting exercise %d for user: %s", exerciseID, userID)
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	var exercise exerciseModelDB.Exercise
+
+	queryResult := dao.visibleTo(userID).
+		Where("id = ?", exerciseID).
+		First(&exercise)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return nil, customErrors.BuildNotFoundError(exerciseNotFoundErrorMsg)
+		}
+		return nil, queryResult.Error
+	}
+
+	return &exercise, nil
+}
+
+func (dao exerciseDAO) GetExerciseByName(userID string, name string, ctxLog *log.Entry) (*exerciseModelDB.Exercise, error) {
+
+	ctxLog.Debugf("EXERCISE_DAO: Getting exercise %s for user: %s", name, userID)
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	var exercise exerciseModelDB.Exercise
+
+	queryResult := dao.visibleTo(userID).
+		Where("LOWER(name) = LOWER(?)", name).
+		First(&exercise)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return nil, customErrors.BuildNotFoundError(exerciseNotFoundErrorMsg)
+		}
+		return nil, queryResult.Error
+	}
+
+	return &exercise, nil
+}
+
+func (dao exerciseDAO) CreateExercise(exercise *exerciseModelDB.Exercise, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("EXERCISE_DAO: Creating exercise %s", exercise.Name)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	if exercise.CreatorID != nil {
+
+		var user userModelDB.User
+
+		queryResult := dao.connection.
+			Where("id = ?", *exercise.CreatorID).
+			First(&user)
+
+		if queryResult.Error != nil {
+			if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+				return customErrors.BuildNotFoundError(userNotFoundErrorMsg)
+			}
+			return queryResult.Error
+		}
+	}
+
+	if err := dao.connection.Omit("BadgeCategory").Create(exercise).Error; err != nil {
+		return err
+	}
+
+	return dao.connection.
+		Preload("BadgeCategory").
+		First(exercise, exercise.ID).Error
+}
+
+func (dao exerciseDAO) DeleteExercise(userID string, exerciseID int64, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("EXERCISE_DAO: Deleting exercise %d of user: %s", exerciseID, userID)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	queryResult := dao.connection.
+		Where("id = ? AND creator_id = ?", exerciseID, userID).
+		Delete(&exerciseModelDB.Exercise{})
+
+	if queryResult.Error != nil {
+		return queryResult.Error
+	}
+
+	if queryResult.RowsAffected == 0 {
+		return customErrors.BuildNotFoundError(exerciseNotFoundErrorMsg)
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package goal_dao
+
+import (
+	log "github.com/sirupsen/logrus"
+)
+
+type IGoalDAO interface {
+	// An empty status returns the goals in any status
+	GetGoals(userID string, status string, ctxLog *log.Entry) ([]*Goal, error)
+	GetGoal(userID string, goalID int64, ctxLog *log.Entry) (*Goal, error)
+	CreateGoal(goal *Goal, ctxLog *log.Entry) error
+	EditGoal(goal *Goal, ctxLog *log.Entry) error
+	// Marks the goal as achieved and grants its exp to the user in one transaction, only while the goal is
+	// active. Returns whether this call achieved it
+	AchieveGoal(goal *Goal, ctxLog *log.Entry) (bool, error)
+	DeleteGoal(userID string, goalID int64, ctxLog *log.Entry) error
+}
@@ -0,0 +1,34 @@
+package goal_dao
+
+import "time"
+
+// Types of goal
+const (
+	TypeWeight  = "weight"
+	TypeBodyFat = "body_fat"
+	TypeLift    = "lift" // Heaviest load lifted in an exercise
+)
+
+// Status of a goal
+const (
+	StatusActive    = "active"
+	StatusAchieved  = "achieved"
+	StatusAbandoned = "abandoned"
+)
+
+type Goal struct {
+	ID         int64      `gorm:"primaryKey;autoIncrement"`
+	UserID     string     `gorm:"not null;index"`
+	Type       string     `gorm:"not null"`
+	Exercise   string     `gorm:"not null"` // Only for lift goals
+	StartValue float32    `gorm:"not null;type:decimal(6,2)"`
+	Target     float32    `gorm:"not null;type:decimal(6,2)"`
+	Deadline   *time.Time `gorm:"null"`
+	Status     string     `gorm:"not null;index"`
+	AchievedAt *time.Time `gorm:"null"`
+	Exp        int64      `gorm:"not null"` // Experience granted when achieved
+
+	CreatedAt time.Time `gorm:"null" json:"created_at"`
+	UpdatedAt time.Time `gorm:"null" json:"updated_at"`
+	DeletedAt time.Time `gorm:"null" json:"deleted_at"`
+}
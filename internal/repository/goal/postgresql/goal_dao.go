@@ -0,0 +1,215 @@
+package postgresql
+
+import (
+	"errors"
+	customErrors "gym-badges-api/internal/custom-errors"
+	"gym-badges-api/internal/repository/config/postgresql"
+	goalModelDB "gym-badges-api/internal/repository/goal"
+	userModelDB "gym-badges-api/internal/repository/user"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	userNotFoundErrorMsg = "User not found"
+	goalNotFoundErrorMsg = "Goal not found"
+)
+
+type goalDAO struct {
+	connection *gorm.DB
+}
+
+func NewGoalDAO() goalModelDB.IGoalDAO {
+	connection := postgresql.OpenConnection()
+	return &goalDAO{connection: connection}
+}
+
+func (dao goalDAO) GetGoals(userID string, status string, ctxLog *log.Entry) ([]*goalModelDB.Goal, error) {
+
+	ctxLog.Debugf("GOAL_DAO: Getting goals for user: %s status: %s", userID, status)
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	var user userModelDB.User
+
+	queryResult := dao.connection.
+		Where("id = ?", userID).
+		First(&user)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return nil, customErrors.BuildNotFoundError(userNotFoundErrorMsg)
+		}
+		return nil, queryResult.Error
+	}
+
+	var goals = make([]*goalModelDB.Goal, 0)
+
+	query := dao.connection.
+		Where("user_id = ?", userID)
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	queryResult = query.
+		Order("id DESC").
+		Find(&goals)
+
+	if queryResult.Error != nil {
+		return nil, queryResult.Error
+	}
+
+	return goals, nil
+}
+
+func (dao goalDAO) GetGoal(userID string, goalID int64, ctxLog *log.Entry) (*goalModelDB.Goal, error) {
+
+	ctxLog.Debugf("GOAL_DAO: Getting goal %d of user: %s", goalID, userID)
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	var goal goalModelDB.Goal
+
+	queryResult := dao.connection.
+		Where("id = ? AND user_id = ?", goalID, userID).
+		First(&goal)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return nil, customErrors.BuildNotFoundError(goalNotFoundErrorMsg)
+		}
+		return nil, queryResult.Error
+	}
+
+	return &goal, nil
+}
+
+func (dao goalDAO) CreateGoal(goal *goalModelDB.Goal, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("GOAL_DAO: Creating %s goal for user: %s", goal.Type, goal.UserID)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	var user userModelDB.User
+
+	queryResult := dao.connection.
+		Where("id = ?", goal.UserID).
+		First(&user)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return customErrors.BuildNotFoundError(userNotFoundErrorMsg)
+		}
+		return queryResult.Error
+	}
+
+	return dao.connection.Create(goal).Error
+}
+
+func (dao goalDAO) EditGoal(goal *goalModelDB.Goal, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("GOAL_DAO: Editing goal %d of user: %s", goal.ID, goal.UserID)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	var current goalModelDB.Goal
+
+	queryResult := dao.connection.
+		Where("id = ? AND user_id = ?", goal.ID, goal.UserID).
+		First(&current)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return customErrors.BuildNotFoundError(goalNotFoundErrorMsg)
+		}
+		return queryResult.Error
+	}
+
+	goal.CreatedAt = current.CreatedAt
+
+	return dao.connection.Save(goal).Error
+}
+
+func (dao goalDAO) AchieveGoal(goal *goalModelDB.Goal, ctxLog *log.Entry) (bool, error) {
+
+	ctxLog.Debugf("GOAL_DAO: Achieving goal %d of user: %s", goal.ID, goal.UserID)
+
+	if err := dao.connection.Error; err != nil {
+		return false, err
+	}
+
+	achieved := false
+
+	err := dao.connection.Transaction(func(tx *gorm.DB) error {
+
+		// Only the check that finds the goal still active grants its experience
+		queryResult := tx.Model(&goalModelDB.Goal{}).
+			Where("id = ? AND status = ?", goal.ID, goalModelDB.StatusActive).
+			Updates(map[string]any{
+				"status":      goalModelDB.StatusAchieved,
+				"achieved_at": goal.AchievedAt,
+				"exp":         goal.Exp,
+			})
+
+		if queryResult.Error != nil {
+			return queryResult.Error
+		}
+
+		if queryResult.RowsAffected == 0 {
+			return nil
+		}
+
+		queryResult = tx.Model(&userModelDB.User{}).
+			Where("id = ?", goal.UserID).
+			UpdateColumn("experience", gorm.Expr("GREATEST(experience + ?, 0)", goal.Exp))
+
+		if queryResult.Error != nil {
+			return queryResult.Error
+		}
+
+		if queryResult.RowsAffected == 0 {
+			return customErrors.BuildNotFoundError(userNotFoundErrorMsg)
+		}
+
+		achieved = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return achieved, nil
+}
+
+func (dao goalDAO) DeleteGoal(userID string, goalID int64, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("GOAL_DAO: Deleting goal %d of user: %s", goalID, userID)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	queryResult := dao.connection.
+		Where("id = ? AND user_id = ?", goalID, userID).
+		Delete(&goalModelDB.Goal{})
+
+	if queryResult.Error != nil {
+		return queryResult.Error
+	}
+
+	if queryResult.RowsAffected == 0 {
+		return customErrors.BuildNotFoundError(goalNotFoundErrorMsg)
+	}
+
+	return nil
+}
@@ -0,0 +1,12 @@
+package gym_dao
+
+import (
+	log "github.com/sirupsen/logrus"
+)
+
+type IGymDAO interface {
+	GetGyms(ctxLog *log.Entry) ([]*Gym, error)
+	GetGym(gymID int64, ctxLog *log.Entry) (*Gym, error)
+	// Records the nonce of a QR code, a nonce that was already used is a conflict
+	UseQRCode(use *UsedQRCode, ctxLog *log.Entry) error
+}
@@ -0,0 +1,28 @@
+package gym_dao
+
+import "time"
+
+type Gym struct {
+	ID        int64   `gorm:"primaryKey;autoIncrement"`
+	Name      string  `gorm:"not null"`
+	Latitude  float64 `gorm:"not null"`
+	Longitude float64 `gorm:"not null"`
+	Radius    float64 `gorm:"not null"` // Geofence radius in meters
+	// Signs the check-in QR codes, it never leaves the server
+	QRSecret string `gorm:"not null" json:"-"`
+	// SHA-256 of the key that authenticates the screen of the gym, in hexadecimal. Without it no screen is accepted
+	DeviceKeyHash string `gorm:"not null;default:''" json:"-"`
+
+	CreatedAt time.Time `gorm:"null" json:"created_at"`
+	UpdatedAt time.Time `gorm:"null" json:"updated_at"`
+	DeletedAt time.Time `gorm:"null" json:"deleted_at"`
+}
+
+// UsedQRCode records the nonce of each QR code used to check in, so every code is only accepted once.
+// The records are kept as the audit trail of the check-ins.
+type UsedQRCode struct {
+	Nonce  string    `gorm:"primaryKey"`
+	GymID  int64     `gorm:"not null;index"`
+	UserID string    `gorm:"not null"`
+	UsedAt time.Time `gorm:"not null"`
+}
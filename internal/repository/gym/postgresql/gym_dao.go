@@ -0,0 +1,95 @@
+package postgresql
+
+import (
+	"errors"
+	customErrors "gym-badges-api/internal/custom-errors"
+	"gym-badges-api/internal/repository/config/postgresql"
+	gymModelDB "gym-badges-api/internal/repository/gym"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	gymNotFoundErrorMsg = "Gym not found"
+	qrCodeUsedErrorMsg  = "The QR code was already used, scan the new one."
+)
+
+type gymDAO struct {
+	connection *gorm.DB
+}
+
+func NewGymDAO() gymModelDB.IGymDAO {
+	connection := postgresql.OpenConnection()
+	return &gymDAO{connection: connection}
+}
+
+func (dao gymDAO) GetGyms(ctxLog *log.Entry) ([]*gymModelDB.Gym, error) {
+
+	ctxLog.Debugf("GYM_DAO: Getting gyms")
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	var gyms = make([]*gymModelDB.Gym, 0)
+
+	queryResult := dao.connection.
+		Order("name, id").
+		Find(&gyms)
+
+	if queryResult.Error != nil {
+		return nil, queryResult.Error
+	}
+
+	return gyms, nil
+}
+
+func (dao gymDAO) GetGym(gymID int64, ctxLog *log.Entry) (*gymModelDB.Gym, error) {
+
+	ctxLog.Debugf("GYM_DAO: Getting gym: %d", gymID)
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	var gym gymModelDB.Gym
+
+	queryResult := dao.connection.
+		Where("id = ?", gymID).
+		First(&gym)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return nil, customErrors.BuildNotFoundError(gymNotFoundErrorMsg)
+		}
+		return nil, queryResult.Error
+	}
+
+	return &gym, nil
+}
+
+func (dao gymDAO) UseQRCode(use *gymModelDB.UsedQRCode, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("GYM_DAO: Using a QR code of gym: %d by user: %s", use.GymID, use.UserID)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	// The primary key keeps two concurrent check-ins from using the same nonce
+	queryResult := dao.connection.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(use)
+
+	if queryResult.Error != nil {
+		return queryResult.Error
+	}
+
+	if queryResult.RowsAffected == 0 {
+		return customErrors.BuildConflictError(qrCodeUsedErrorMsg)
+	}
+
+	return nil
+}
@@ -4,16 +4,19 @@ import (
 	"database/sql"
 	"errors"
 	customErrors "gym-badges-api/internal/custom-errors"
+	badgeModelDB "gym-badges-api/internal/repository/badge"
 	"gym-badges-api/internal/repository/config/postgresql"
 	userModelDB "gym-badges-api/internal/repository/user"
 	"time"
 
+	"github.com/lib/pq"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
 const (
-	userNotFoundErrorMsg = "User not found"
+	userNotFoundErrorMsg       = "User not found"
+	attendanceNotFoundErrorMsg = "Gym attendance not found"
 )
 
 type userDAO struct {
@@ -46,6 +49,10 @@ func (dao userDAO) GetUser(userID string, ctxLog *log.Entry) (*userModelDB.User,
 		Preload("TopFeats", func(db *gorm.DB) *gorm.DB {
 			return db.Limit(3)
 		}).
+		// Only the changes not in force yet
+		Preload("GoalHistory", func(db *gorm.DB) *gorm.DB {
+			return db.Where("effective_from > ?", time.Now()).Order("effective_from ASC")
+		}).
 		Where("id = ?", userID).
 		First(&user)
 
@@ -83,15 +90,26 @@ func (dao userDAO) GetUserByEmail(email string, ctxLog *log.Entry) (*userModelDB
 	return &user, nil
 }
 
-func (dao userDAO) CreateUser(user *userModelDB.User, ctxLog *log.Entry) error {
+func (dao userDAO) CreateUser(user *userModelDB.User, awards []*badgeModelDB.UserBadge, ctxLog *log.Entry) error {
 
-	ctxLog.Debugf("USER_DAO: Creating user: %s", user.ID)
+	ctxLog.Debugf("USER_DAO: Creating user: %s with %d awards", user.ID, len(awards))
 
 	if err := dao.connection.Error; err != nil {
 		return err
 	}
 
-	return dao.connection.Create(user).Error
+	return dao.connection.Transaction(func(tx *gorm.DB) error {
+
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+
+		if len(awards) == 0 {
+			return nil
+		}
+
+		return tx.Create(&awards).Error
+	})
 }
 
 func (dao userDAO) EditUserInfo(userID string, newUserInfo *userModelDB.User, ctxLog *log.Entry) (*userModelDB.User, error) {
@@ -124,8 +142,15 @@ func (dao userDAO) EditUserInfo(userID string, newUserInfo *userModelDB.User, ct
 	if newUserInfo.Image != nil {
 		user.Image = newUserInfo.Image
 	}
-	if newUserInfo.WeeklyGoal >= 1 && newUserInfo.WeeklyGoal <= 7 {
-		user.WeeklyGoal = newUserInfo.WeeklyGoal
+	if newUserInfo.WeekStart >= 1 && newUserInfo.WeekStart <= 7 {
+		user.WeekStart = newUserInfo.WeekStart
+	}
+	if newUserInfo.GoalPeriodDays >= 1 && newUserInfo.GoalPeriodDays <= userModelDB.MaxGoalPeriodDays &&
+		newUserInfo.GoalPeriodDays != user.GoalPeriodDays {
+		// The new periods start today
+		anchor := time.Now().Truncate(24 * time.Hour)
+		user.GoalPeriodDays = newUserInfo.GoalPeriodDays
+		user.GoalPeriodAnchor = &anchor
 	}
 	if *newUserInfo.Height != 0 {
 		user.Height = newUserInfo.Height
@@ -152,7 +177,17 @@ func (dao userDAO) EditUserInfo(userID string, newUserInfo *userModelDB.User, ct
 		return nil, err
 	}
 
-	if err := dao.connection.Save(&user).Error; err != nil {
+	err := updateUser(dao.connection, userID, map[string]any{
+		"email":              user.Email,
+		"name":               user.Name,
+		"image":              user.Image,
+		"week_start":         user.WeekStart,
+		"goal_period_days":   user.GoalPeriodDays,
+		"goal_period_anchor": user.GoalPeriodAnchor,
+		"height":             user.Height,
+		"sex":                user.Sex,
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -161,9 +196,81 @@ func (dao userDAO) EditUserInfo(userID string, newUserInfo *userModelDB.User, ct
 	return &user, nil
 }
 
-func (dao userDAO) setDayToCurrentWeek(userID string, dayIndex int, marked bool, ctxLog *log.Entry) (*userModelDB.User, error) {
+func (dao userDAO) UpdateStreak(userID string, streak int32, currentWeek []bool, weeklyGoal int32, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("USER_DAO: Updating streak of user: %s to %d weeks", userID, streak)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	return updateUser(dao.connection, userID, map[string]any{
+		"streak":       streak,
+		"current_week": pq.BoolArray(currentWeek),
+		"weekly_goal":  weeklyGoal,
+	})
+}
+
+func (dao userDAO) SaveWeeklyGoalChanges(userID string, changes []userModelDB.WeeklyGoalChange, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("USER_DAO: Saving %d weekly goal changes of user: %s", len(changes), userID)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	return dao.connection.Transaction(func(tx *gorm.DB) error {
+
+		// Changes not in force yet are replaced
+		if err := tx.Unscoped().
+			Where("user_id = ? AND effective_from >= ?", userID, changes[0].EffectiveFrom).
+			Delete(&userModelDB.WeeklyGoalChange{}).Error; err != nil {
+			return err
+		}
+
+		for i := range changes {
+			changes[i].UserID = userID
+			if err := tx.Save(&changes[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// *******************************************************************
+// STREAK PROTECTION
+// *******************************************************************
+
+func (dao userDAO) GetUserIDs(ctxLog *log.Entry) ([]string, error) {
+
+	ctxLog.Debugf("USER_DAO: Getting the ids of every user")
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0)
+
+	queryResult := dao.connection.
+		Model(&userModelDB.User{}).
+		Order("id ASC").
+		Pluck("id", &userIDs)
+
+	if queryResult.Error != nil {
+		return nil, queryResult.Error
+	}
+
+	return userIDs, nil
+}
+
+func (dao userDAO) GetUserWithStreakProtection(userID string, ctxLog *log.Entry) (*userModelDB.User, error) {
 
-	ctxLog.Debugf("USER_DAO: Adding a day to current week of user: %s", userID)
+	ctxLog.Debugf("USER_DAO: Getting attendance, streak freezes, vacation weeks and goal history for user: %s", userID)
 
 	if err := dao.connection.Error; err != nil {
 		return nil, err
@@ -172,6 +279,20 @@ func (dao userDAO) setDayToCurrentWeek(userID string, dayIndex int, marked bool,
 	var user userModelDB.User
 
 	queryResult := dao.connection.
+		Preload("GymAttendance", func(db *gorm.DB) *gorm.DB {
+			return db.Order("date ASC")
+		}).
+		Preload("StreakFreezes", func(db *gorm.DB) *gorm.DB {
+			return db.Order("earned_at ASC, id ASC")
+		}).
+		Preload("VacationWeeks", func(db *gorm.DB) *gorm.DB {
+			return db.Order("week ASC")
+		}).
+		Preload("GoalHistory", func(db *gorm.DB) *gorm.DB {
+			return db.Order("effective_from ASC")
+		}).
+		// The rules tell the consistency badges that grant freezes
+		Preload("Badges.Rule").
 		Where("id = ?", userID).
 		First(&user)
 
@@ -182,57 +303,50 @@ func (dao userDAO) setDayToCurrentWeek(userID string, dayIndex int, marked bool,
 		return nil, queryResult.Error
 	}
 
-	user.CurrentWeek[dayIndex] = marked
-
-	if err := dao.connection.Save(&user).Error; err != nil {
-		return nil, err
-	}
-
 	return &user, nil
 }
 
-func countDays(list []bool) int32 {
-	var count int32 = 0
-	for _, b := range list {
-		if b {
-			count++
-		}
-	}
-	return count
-}
+func (dao userDAO) SaveStreakFreezes(userID string, freezes []userModelDB.StreakFreeze, ctxLog *log.Entry) error {
 
-func (dao userDAO) AddDayToCurrentWeek(userID string, dayIndex int, ctxLog *log.Entry) error {
-	user, err := dao.setDayToCurrentWeek(userID, dayIndex, true, ctxLog)
-	if err != nil {
+	ctxLog.Debugf("USER_DAO: Saving %d streak freezes of user: %s", len(freezes), userID)
+
+	if err := dao.connection.Error; err != nil {
 		return err
 	}
 
-	// Update streak
-	if countDays(user.CurrentWeek) == user.WeeklyGoal {
-		user.Streak += 1
-		if err := dao.connection.Save(&user).Error; err != nil {
-			return err
+	return dao.connection.Transaction(func(tx *gorm.DB) error {
+		for i := range freezes {
+			freezes[i].UserID = userID
+			if err := tx.Save(&freezes[i]).Error; err != nil {
+				return err
+			}
 		}
-	}
-
-	return nil
+		return nil
+	})
 }
 
-func (dao userDAO) DeleteDayFromCurrentWeek(userID string, dayIndex int, ctxLog *log.Entry) error {
-	user, err := dao.setDayToCurrentWeek(userID, dayIndex, false, ctxLog)
-	if err != nil {
+func (dao userDAO) AddVacationWeek(userID string, week time.Time, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("USER_DAO: Adding vacation week %s to user: %s", week, userID)
+
+	if err := dao.connection.Error; err != nil {
 		return err
 	}
 
-	// Update streak
-	if countDays(user.CurrentWeek) == user.WeeklyGoal-1 {
-		user.Streak -= 1
-		if err := dao.connection.Save(&user).Error; err != nil {
-			return err
-		}
+	return dao.connection.Create(&userModelDB.VacationWeek{UserID: userID, Week: week}).Error
+}
+
+func (dao userDAO) DeleteVacationWeek(userID string, week time.Time, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("USER_DAO: Deleting vacation week %s of user: %s", week, userID)
+
+	if err := dao.connection.Error; err != nil {
+		return err
 	}
 
-	return nil
+	return dao.connection.Unscoped().
+		Where("user_id = ? AND week = ?", userID, week).
+		Delete(&userModelDB.VacationWeek{}).Error
 }
 
 // *******************************************************************
@@ -291,7 +405,6 @@ func (dao userDAO) AddWeight(userID string, weight float32, date time.Time, ctxL
 		return queryResult.Error
 	}
 
-	user.Weight = &weight
 	err := dao.connection.Unscoped().Model(&user).Association("WeightHistory").Unscoped().Delete(&userModelDB.WeightHistory{UserID: user.ID, Date: date})
 	if err != nil {
 		return err
@@ -301,7 +414,7 @@ func (dao userDAO) AddWeight(userID string, weight float32, date time.Time, ctxL
 		return err
 	}
 
-	return dao.connection.Save(&user).Error
+	return updateUser(dao.connection, userID, map[string]any{"weight": weight})
 }
 
 // *******************************************************************
@@ -347,39 +460,388 @@ func (dao userDAO) AddBodyFat(userID string, bodyFat float32, date time.Time, ct
 		return err
 	}
 
-	var user userModelDB.User
+	var user userModelDB.User
+
+	queryResult := dao.connection.
+		Where("id = ?", userID).
+		First(&user)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return customErrors.BuildNotFoundError(userNotFoundErrorMsg)
+		}
+		return queryResult.Error
+	}
+
+	err := dao.connection.Unscoped().Model(&user).Association("FatHistory").Unscoped().Delete(&userModelDB.FatHistory{UserID: user.ID, Date: date})
+	if err != nil {
+		return err
+	}
+	err = dao.connection.Model(&user).Association("FatHistory").Append(&userModelDB.FatHistory{Date: date, Fat: bodyFat})
+	if err != nil {
+		return err
+	}
+
+	return updateUser(dao.connection, userID, map[string]any{"body_fat": bodyFat})
+}
+
+// *******************************************************************
+// BODY COMPOSITION
+// *******************************************************************
+
+func (dao userDAO) GetUserWithBodyHistory(userID string, months int32, ctxLog *log.Entry) (*userModelDB.User, error) {
+
+	ctxLog.Debugf("USER_DAO: Getting weight and fat history for user: %s for last %d months", userID, months)
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	var user userModelDB.User
+
+	history := func(db *gorm.DB) *gorm.DB {
+		if months > 0 {
+			startDate := time.Now().AddDate(0, -int(months), 0)
+			return db.Where("date >= ?", startDate).Order("date ASC")
+		}
+		return db.Order("date ASC")
+	}
+
+	queryResult := dao.connection.
+		Preload("WeightHistory", history).
+		Preload("FatHistory", history).
+		Preload("Preferences", func(db *gorm.DB) *gorm.DB {
+			return db.Order("preference.id")
+		}).
+		Where("id = ?", userID).
+		First(&user)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return nil, customErrors.BuildNotFoundError(userNotFoundErrorMsg)
+		}
+		return nil, queryResult.Error
+	}
+
+	return &user, nil
+}
+
+// *******************************************************************
+// GYM ATTENDANCES (STREAK)
+// *******************************************************************
+
+func (dao userDAO) GetUserWithAttendance(userID string, year int32, month int32, ctxLog *log.Entry) (*userModelDB.User, error) {
+
+	ctxLog.Debugf("USER_DAO: Getting attendance info for user: %s in year %d and month %d", userID, year, month)
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	var user userModelDB.User
+
+	queryResult := dao.connection.
+		Preload("GymAttendance", func(db *gorm.DB) *gorm.DB {
+			return db.Where("EXTRACT(YEAR FROM date) = ? AND EXTRACT(MONTH FROM date) = ?", year, month).
+				Order("date ASC")
+		}).
+		Where("id = ?", userID).
+		First(&user)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return nil, customErrors.BuildNotFoundError(userNotFoundErrorMsg)
+		}
+		return nil, queryResult.Error
+	}
+
+	return &user, nil
+}
+
+func (dao userDAO) AddGymAttendance(userID string, date time.Time, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("USER_DAO: Adding a gym attendance to user %s", userID)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	var user userModelDB.User
+
+	queryResult := dao.connection.
+		Where("id = ?", userID).
+		First(&user)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return customErrors.BuildNotFoundError(userNotFoundErrorMsg)
+		}
+		return queryResult.Error
+	}
+
+	return dao.connection.Model(&user).Association("GymAttendance").Append(&userModelDB.GymAttendance{Date: date})
+}
+
+func (dao userDAO) DeleteGymAttendance(userID string, date time.Time, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("USER_DAO: Deleting a gym attendance to user %s", userID)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	var user userModelDB.User
+
+	queryResult := dao.connection.
+		Where("id = ?", userID).
+		First(&user)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return customErrors.BuildNotFoundError(userNotFoundErrorMsg)
+		}
+		return queryResult.Error
+	}
+
+	return dao.connection.Transaction(func(tx *gorm.DB) error {
+
+		err := tx.Unscoped().Model(&user).Association("GymAttendance").Unscoped().Delete(&userModelDB.GymAttendance{
+			UserID: user.ID,
+			Date:   date,
+		})
+		if err != nil {
+			return err
+		}
+
+		// A day that is not attended is not verified either
+		verified := tx.
+			Where("user_id = ? AND date = ?", user.ID, date).
+			Delete(&userModelDB.VerifiedAttendance{})
+
+		if verified.Error != nil {
+			return verified.Error
+		}
+
+		if verified.RowsAffected == 0 {
+			return nil
+		}
+
+		return tx.Model(&userModelDB.User{}).
+			Where("id = ?", user.ID).
+			UpdateColumn("verified_attendances", gorm.Expr("GREATEST(verified_attendances - ?, 0)", verified.RowsAffected)).Error
+	})
+}
+
+func (dao userDAO) GetAttendanceCount(userID string, ctxLog *log.Entry) (int32, error) {
+
+	ctxLog.Debugf("USER_DAO: Getting attendance count for user: %s", userID)
+
+	if err := dao.connection.Error; err != nil {
+		return -1, err
+	}
+
+	var user userModelDB.User
+
+	queryResult := dao.connection.
+		Where("id = ?", userID).
+		First(&user)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return -1, customErrors.BuildNotFoundError(userNotFoundErrorMsg)
+		}
+		return -1, queryResult.Error
+	}
+
+	count := dao.connection.Model(&user).Association("GymAttendance").Count()
+
+	return int32(count), nil
+}
+
+func (dao userDAO) CheckGymAttendance(userID string, date time.Time, ctxLog *log.Entry) (bool, error) {
+
+	ctxLog.Debugf("USER_DAO: Checking gym attendance of user %s on %s", userID, date)
+
+	if err := dao.connection.Error; err != nil {
+		return false, err
+	}
+
+	var user userModelDB.User
+
+	queryResult := dao.connection.
+		Where("id = ?", userID).
+		First(&user)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return false, customErrors.BuildNotFoundError(userNotFoundErrorMsg)
+		}
+		return false, queryResult.Error
+	}
+
+	var count int64
+
+	queryResult = dao.connection.
+		Model(&userModelDB.GymAttendance{}).
+		Where("user_id = ? AND date = ?", userID, date).
+		Count(&count)
+
+	if queryResult.Error != nil {
+		return false, queryResult.Error
+	}
+
+	return count > 0, nil
+}
+
+// *******************************************************************
+// GYM SESSIONS
+// *******************************************************************
+
+func (dao userDAO) SetGymSession(userID string, session userModelDB.GymAttendance, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("USER_DAO: Setting the session times of user %s on %s", userID, session.Date)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	queryResult := dao.connection.
+		Model(&userModelDB.GymAttendance{}).
+		Where("user_id = ? AND date = ?", userID, session.Date).
+		Updates(map[string]any{
+			"start_time":   session.StartTime,
+			"end_time":     session.EndTime,
+			"start_minute": session.StartMinute,
+		})
+
+	if queryResult.Error != nil {
+		return queryResult.Error
+	}
+
+	if queryResult.RowsAffected == 0 {
+		return customErrors.BuildNotFoundError(attendanceNotFoundErrorMsg)
+	}
+
+	return nil
+}
+
+func (dao userDAO) GetTrainingTime(userID string, ctxLog *log.Entry) (time.Duration, error) {
+
+	ctxLog.Debugf("USER_DAO: Getting training time of user %s", userID)
+
+	if err := dao.connection.Error; err != nil {
+		return 0, err
+	}
+
+	var seconds float64
+
+	queryResult := dao.connection.
+		Model(&userModelDB.GymAttendance{}).
+		Select("COALESCE(SUM(EXTRACT(EPOCH FROM end_time - start_time)), 0)").
+		Where("user_id = ? AND start_time IS NOT NULL AND end_time IS NOT NULL", userID).
+		Scan(&seconds)
+
+	if queryResult.Error != nil {
+		return 0, queryResult.Error
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+func (dao userDAO) CountSessionsStartedBetween(userID string, fromMinute int16, toMinute int16,
+	ctxLog *log.Entry) (int32, error) {
+
+	ctxLog.Debugf("USER_DAO: Counting sessions of user %s started between minutes %d and %d", userID,
+		fromMinute, toMinute)
+
+	if err := dao.connection.Error; err != nil {
+		return -1, err
+	}
+
+	var count int64
+
+	queryResult := dao.connection.
+		Model(&userModelDB.GymAttendance{}).
+		Where("user_id = ? AND start_minute >= ? AND start_minute < ?", userID, fromMinute, toMinute).
+		Count(&count)
+
+	if queryResult.Error != nil {
+		return -1, queryResult.Error
+	}
+
+	return int32(count), nil
+}
+
+// *******************************************************************
+// VERIFIED ATTENDANCES
+// *******************************************************************
+
+func (dao userDAO) AddVerifiedAttendance(attendance *userModelDB.VerifiedAttendance, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("USER_DAO: Adding a verified attendance to user %s", attendance.UserID)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	var user userModelDB.User
+
+	queryResult := dao.connection.
+		Where("id = ?", attendance.UserID).
+		First(&user)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return customErrors.BuildNotFoundError(userNotFoundErrorMsg)
+		}
+		return queryResult.Error
+	}
+
+	return dao.connection.Transaction(func(tx *gorm.DB) error {
+
+		if err := tx.Create(attendance).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&user).
+			UpdateColumn("verified_attendances", gorm.Expr("verified_attendances + 1")).Error
+	})
+}
+
+func (dao userDAO) CheckVerifiedAttendance(userID string, date time.Time, ctxLog *log.Entry) (bool, error) {
+
+	ctxLog.Debugf("USER_DAO: Checking verified attendance of user %s on %s", userID, date)
+
+	if err := dao.connection.Error; err != nil {
+		return false, err
+	}
+
+	var count int64
 
 	queryResult := dao.connection.
-		Where("id = ?", userID).
-		First(&user)
+		Model(&userModelDB.VerifiedAttendance{}).
+		Where("user_id = ? AND date = ?", userID, date).
+		Count(&count)
 
 	if queryResult.Error != nil {
-		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
-			return customErrors.BuildNotFoundError(userNotFoundErrorMsg)
-		}
-		return queryResult.Error
-	}
-
-	user.BodyFat = &bodyFat
-	err := dao.connection.Unscoped().Model(&user).Association("FatHistory").Unscoped().Delete(&userModelDB.FatHistory{UserID: user.ID, Date: date})
-	if err != nil {
-		return err
-	}
-	err = dao.connection.Model(&user).Association("FatHistory").Append(&userModelDB.FatHistory{Date: date, Fat: bodyFat})
-	if err != nil {
-		return err
+		return false, queryResult.Error
 	}
 
-	return dao.connection.Save(&user).Error
+	return count > 0, nil
 }
 
 // *******************************************************************
-// GYM ATTENDANCES (STREAK)
+// HISTORY IMPORT
 // *******************************************************************
 
-func (dao userDAO) GetUserWithAttendance(userID string, year int32, month int32, ctxLog *log.Entry) (*userModelDB.User, error) {
+func (dao userDAO) GetUserWithHistory(userID string, ctxLog *log.Entry) (*userModelDB.User, error) {
+	return dao.GetUserWithHistoryBetween(userID, time.Time{}, time.Time{}, ctxLog)
+}
 
-	ctxLog.Debugf("USER_DAO: Getting attendance info for user: %s in year %d and month %d", userID, year, month)
+func (dao userDAO) GetUserWithHistoryBetween(userID string, from time.Time, to time.Time,
+	ctxLog *log.Entry) (*userModelDB.User, error) {
+
+	ctxLog.Debugf("USER_DAO: Getting history for user: %s between %s and %s", userID, from, to)
 
 	if err := dao.connection.Error; err != nil {
 		return nil, err
@@ -387,11 +849,20 @@ func (dao userDAO) GetUserWithAttendance(userID string, year int32, month int32,
 
 	var user userModelDB.User
 
+	history := func(db *gorm.DB) *gorm.DB {
+		if !from.IsZero() {
+			db = db.Where("date >= ?", from)
+		}
+		if !to.IsZero() {
+			db = db.Where("date <= ?", to)
+		}
+		return db.Order("date ASC")
+	}
+
 	queryResult := dao.connection.
-		Preload("GymAttendance", func(db *gorm.DB) *gorm.DB {
-			return db.Where("EXTRACT(YEAR FROM date) = ? AND EXTRACT(MONTH FROM date) = ?", year, month).
-				Order("date ASC")
-		}).
+		Preload("GymAttendance", history).
+		Preload("WeightHistory", history).
+		Preload("FatHistory", history).
 		Where("id = ?", userID).
 		First(&user)
 
@@ -405,9 +876,11 @@ func (dao userDAO) GetUserWithAttendance(userID string, year int32, month int32,
 	return &user, nil
 }
 
-func (dao userDAO) AddGymAttendance(userID string, date time.Time, ctxLog *log.Entry) error {
+func (dao userDAO) ImportHistory(userID string, attendances []userModelDB.GymAttendance, weights []userModelDB.WeightHistory,
+	fats []userModelDB.FatHistory, ctxLog *log.Entry) error {
 
-	ctxLog.Debugf("USER_DAO: Adding a gym attendance to user %s", userID)
+	ctxLog.Debugf("USER_DAO: Importing %d attendances, %d weights and %d body fats to user: %s", len(attendances),
+		len(weights), len(fats), userID)
 
 	if err := dao.connection.Error; err != nil {
 		return err
@@ -416,7 +889,6 @@ func (dao userDAO) AddGymAttendance(userID string, date time.Time, ctxLog *log.E
 	var user userModelDB.User
 
 	queryResult := dao.connection.
-		Preload("GymAttendance").
 		Where("id = ?", userID).
 		First(&user)
 
@@ -427,67 +899,95 @@ func (dao userDAO) AddGymAttendance(userID string, date time.Time, ctxLog *log.E
 		return queryResult.Error
 	}
 
-	user.GymAttendance = append(user.GymAttendance, userModelDB.GymAttendance{Date: date})
+	return dao.connection.Transaction(func(tx *gorm.DB) error {
 
-	return dao.connection.Save(&user).Error
-}
+		if len(attendances) > 0 {
+			if err := tx.Create(&attendances).Error; err != nil {
+				return err
+			}
+		}
 
-func (dao userDAO) DeleteGymAttendance(userID string, date time.Time, ctxLog *log.Entry) error {
+		if len(weights) > 0 {
+			if err := tx.Create(&weights).Error; err != nil {
+				return err
+			}
+		}
 
-	ctxLog.Debugf("USER_DAO: Deleting a gym attendance to user %s", userID)
+		if len(fats) > 0 {
+			if err := tx.Create(&fats).Error; err != nil {
+				return err
+			}
+		}
 
-	if err := dao.connection.Error; err != nil {
-		return err
-	}
+		// Imported measurements can be older than the current ones
+		columns := make(map[string]any)
 
-	var user userModelDB.User
+		var lastWeight userModelDB.WeightHistory
+		queryResult := tx.Where("user_id = ?", userID).Order("date DESC").Limit(1).Find(&lastWeight)
+		if queryResult.Error != nil {
+			return queryResult.Error
+		}
+		if queryResult.RowsAffected > 0 {
+			columns["weight"] = lastWeight.Weight
+		}
 
-	queryResult := dao.connection.
-		Where("id = ?", userID).
-		First(&user)
+		var lastFat userModelDB.FatHistory
+		queryResult = tx.Where("user_id = ?", userID).Order("date DESC").Limit(1).Find(&lastFat)
+		if queryResult.Error != nil {
+			return queryResult.Error
+		}
+		if queryResult.RowsAffected > 0 {
+			columns["body_fat"] = lastFat.Fat
+		}
 
-	if queryResult.Error != nil {
-		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
-			return customErrors.BuildNotFoundError(userNotFoundErrorMsg)
+		if len(columns) == 0 {
+			return nil
 		}
-		return queryResult.Error
-	}
 
-	err := dao.connection.Unscoped().Model(&user).Association("GymAttendance").Unscoped().Delete(&userModelDB.GymAttendance{
-		UserID: user.ID,
-		Date:   date,
+		return updateUser(tx, userID, columns)
 	})
-	if err != nil {
-		return err
-	}
-
-	return dao.connection.Save(&user).Error
 }
 
-func (dao userDAO) GetAttendanceCount(userID string, ctxLog *log.Entry) (int32, error) {
+// *******************************************************************
+// CALENDAR SUBSCRIPTION
+// *******************************************************************
 
-	ctxLog.Debugf("USER_DAO: Getting attendance count for user: %s", userID)
+func (dao userDAO) GetUserByCalendarToken(token string, ctxLog *log.Entry) (*userModelDB.User, error) {
+
+	ctxLog.Debugf("USER_DAO: Getting user by calendar token")
 
 	if err := dao.connection.Error; err != nil {
-		return -1, err
+		return nil, err
 	}
 
 	var user userModelDB.User
 
 	queryResult := dao.connection.
-		Where("id = ?", userID).
+		Preload("GymAttendance", func(db *gorm.DB) *gorm.DB {
+			return db.Order("date ASC")
+		}).
+		Where("calendar_token = ?", token).
 		First(&user)
 
 	if queryResult.Error != nil {
 		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
-			return -1, customErrors.BuildNotFoundError(userNotFoundErrorMsg)
+			return nil, customErrors.BuildNotFoundError(userNotFoundErrorMsg)
 		}
-		return -1, queryResult.Error
+		return nil, queryResult.Error
 	}
 
-	count := dao.connection.Model(&user).Association("GymAttendance").Count()
+	return &user, nil
+}
 
-	return int32(count), nil
+func (dao userDAO) SetCalendarToken(userID string, token string, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("USER_DAO: Setting calendar token of user: %s", userID)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	return updateUser(dao.connection, userID, map[string]any{"calendar_token": token})
 }
 
 // *******************************************************************
@@ -578,8 +1078,7 @@ func (dao userDAO) AddFriend(userID string, friendID string, ctxLog *log.Entry)
 		return nil, queryResult.Error
 	}
 
-	user.Friends = append(user.Friends, &friend)
-	if err := dao.connection.Save(user).Error; err != nil {
+	if err := dao.connection.Model(&user).Association("Friends").Append(&friend); err != nil {
 		return nil, err
 	}
 
@@ -668,6 +1167,31 @@ func (dao userDAO) GetFriendsCount(userID string, ctxLog *log.Entry) (int32, err
 	return int32(count), nil
 }
 
+func (dao userDAO) GetFriendIDs(userID string, ctxLog *log.Entry) ([]string, error) {
+
+	ctxLog.Debugf("USER_DAO: Getting friend ids for user: %s", userID)
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	friendIDs := make([]string, 0)
+
+	queryResult := dao.connection.
+		Model(&userModelDB.User{}).
+		Joins(`JOIN user_friends ON "user".id = user_friends.friend_id OR "user".id = user_friends.user_id`).
+		Where("user_friends.user_id = ? OR user_friends.friend_id = ?", userID, userID).
+		Where(`"user".id != ?`, userID).
+		Distinct().
+		Pluck(`"user".id`, &friendIDs)
+
+	if queryResult.Error != nil {
+		return nil, queryResult.Error
+	}
+
+	return friendIDs, nil
+}
+
 func (dao userDAO) CheckFriendship(userID string, friendID string, ctxLog *log.Entry) (bool, error) { // Returns: areFriends, confirmed, error
 
 	ctxLog.Debugf("USER_DAO: Checking %s and %s friendship.", userID, friendID)
@@ -751,9 +1275,7 @@ func (dao userDAO) AddFriendRequest(userID string, friendID string, ctxLog *log.
 		return nil, queryResult.Error
 	}
 
-	friend.FriendRequests = append(friend.FriendRequests, &user)
-
-	return &friend, dao.connection.Save(&friend).Error
+	return &friend, dao.connection.Model(&friend).Association("FriendRequests").Append(&user)
 }
 
 func (dao userDAO) GetUserWithFriendRequests(userID string, ctxLog *log.Entry) (*userModelDB.User, error) {
@@ -907,25 +1429,29 @@ func (dao userDAO) AddExperience(userID string, exp int64, ctxLog *log.Entry) er
 		return err
 	}
 
-	var user userModelDB.User
+	// Atomic increment, so concurrent changes are not lost. Exp cannot get negative
+	return updateUser(dao.connection, userID, map[string]any{
+		"experience": gorm.Expr("GREATEST(experience + ?, 0)", exp),
+	})
+}
 
-	queryResult := dao.connection.
+// updateUser writes only the columns given, so the concurrent changes of the others are not lost, as the
+// experience is incremented atomically
+func updateUser(tx *gorm.DB, userID string, columns map[string]any) error {
+
+	queryResult := tx.
+		Model(&userModelDB.User{}).
 		Where("id = ?", userID).
-		First(&user)
+		Updates(columns)
 
 	if queryResult.Error != nil {
-		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
-			return customErrors.BuildNotFoundError(userNotFoundErrorMsg)
-		}
 		return queryResult.Error
 	}
+	if queryResult.RowsAffected == 0 {
+		return customErrors.BuildNotFoundError(userNotFoundErrorMsg)
+	}
 
-	user.Experience += exp
-
-	// Exp cannot get negative
-	user.Experience = max(user.Experience, 0)
-
-	return dao.connection.Save(&user).Error
+	return nil
 }
 
 // *******************************************************************
@@ -1080,3 +1606,153 @@ func (dao *userDAO) GetUserWithFriendsRank(userID string, ctxLog *log.Entry) (*u
 
 	return &user, rank, nil
 }
+
+func (dao *userDAO) GetUsersOrderedByVerifiedAttendances(offset int64, size int32, ctxLog *log.Entry) ([]*userModelDB.User, error) {
+
+	ctxLog.Debugf("USER_DAO: Getting verified attendances global ranking offset: %d size: %d", offset, size)
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	var users = make([]*userModelDB.User, 0)
+
+	queryResult := dao.connection.
+		Order("verified_attendances DESC, experience DESC, streak DESC, weekly_goal DESC, id").
+		Limit(int(size)).
+		Offset(int(offset)).
+		Find(&users)
+
+	if queryResult.Error != nil {
+		return nil, queryResult.Error
+	}
+
+	return users, nil
+}
+
+func (dao *userDAO) GetUserWithVerifiedGlobalRank(userID string, ctxLog *log.Entry) (*userModelDB.User, int64, error) {
+
+	ctxLog.Debugf("USER_DAO: Getting user: %s with his verified attendances global rank", userID)
+
+	if err := dao.connection.Error; err != nil {
+		return nil, -1, err
+	}
+
+	var user userModelDB.User
+
+	queryResult := dao.connection.
+		Where("id = ?", userID).
+		First(&user)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return nil, -1, customErrors.BuildNotFoundError(userNotFoundErrorMsg)
+		}
+		return nil, -1, queryResult.Error
+	}
+
+	var rank int64 // 1-based
+	queryResult = dao.connection.
+		Raw(`
+			SELECT rank 
+			FROM (
+				SELECT id, ROW_NUMBER() OVER (ORDER BY verified_attendances DESC, experience DESC, streak DESC, weekly_goal DESC, id) AS rank
+				FROM "user"
+			)
+			WHERE "id" = ?
+		`, userID).
+		Scan(&rank)
+
+	if queryResult.Error != nil {
+		return nil, -1, queryResult.Error
+	}
+
+	return &user, rank, nil
+}
+
+func (dao *userDAO) GetFriendsOrderedByVerifiedAttendances(userID string, offset int64, size int32,
+	ctxLog *log.Entry) ([]*userModelDB.User, error) {
+
+	ctxLog.Debugf("USER_DAO: Getting verified attendances friends ranking for user: %s offset: %d size: %d", userID, offset, size)
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	var user userModelDB.User
+
+	queryResult := dao.connection.
+		Where("id = ?", userID).
+		First(&user)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return nil, customErrors.BuildNotFoundError(userNotFoundErrorMsg)
+		}
+		return nil, queryResult.Error
+	}
+
+	var friends = make([]*userModelDB.User, 0)
+
+	queryResult = dao.connection.
+		Order("verified_attendances DESC, experience DESC, streak DESC, weekly_goal DESC, id, name, image").
+		Distinct("verified_attendances, experience, streak, weekly_goal, id, name, image").
+		Joins(`JOIN user_friends ON "user".id = user_friends.friend_id OR "user".id = user_friends.user_id`).
+		Where("user_friends.user_id = ? OR user_friends.friend_id = ?", userID, userID).
+		Limit(int(size)).
+		Offset(int(offset)).
+		Find(&friends)
+
+	if queryResult.Error != nil {
+		return nil, queryResult.Error
+	}
+
+	return friends, nil
+}
+
+func (dao *userDAO) GetUserWithVerifiedFriendsRank(userID string, ctxLog *log.Entry) (*userModelDB.User, int64, error) {
+
+	ctxLog.Debugf("USER_DAO: Getting user: %s with his verified attendances friends rank", userID)
+
+	if err := dao.connection.Error; err != nil {
+		return nil, -1, err
+	}
+
+	var user userModelDB.User
+
+	queryResult := dao.connection.
+		Where("id = ?", userID).
+		First(&user)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return nil, -1, customErrors.BuildNotFoundError(userNotFoundErrorMsg)
+		}
+		return nil, -1, queryResult.Error
+	}
+
+	var rank int64 // 1-based
+	queryResult = dao.connection.
+		Raw(`
+			SELECT rank 
+			FROM (
+				SELECT id, ROW_NUMBER() OVER (ORDER BY verified_attendances DESC, experience DESC, streak DESC, weekly_goal DESC, id) AS rank
+				FROM (
+					SELECT DISTINCT ON ("user".id) "user".id, "user".verified_attendances, "user".experience, "user".streak, "user".weekly_goal
+					FROM "user"
+					JOIN user_friends 
+						ON "user".id = user_friends.user_id
+						OR "user".id = user_friends.friend_id 
+					WHERE (user_friends.user_id = @user_id OR user_friends.friend_id = @user_id)
+				)
+			)
+			WHERE "id" = @user_id
+		`, sql.Named("user_id", userID)).
+		Scan(&rank)
+
+	if queryResult.Error != nil {
+		return nil, -1, queryResult.Error
+	}
+
+	return &user, rank, nil
+}
@@ -1,6 +1,7 @@
 package user
 
 import (
+	badgeModelDB "gym-badges-api/internal/repository/badge"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -12,13 +13,27 @@ type IUserDAO interface {
 
 	GetUser(userID string, ctxLog *log.Entry) (*User, error)
 	GetUserByEmail(email string, ctxLog *log.Entry) (*User, error)
-	CreateUser(user *User, ctxLog *log.Entry) error
+	// CreateUser creates the user with the given awards, as the base badges every user starts with
+	CreateUser(user *User, awards []*badgeModelDB.UserBadge, ctxLog *log.Entry) error
 	EditUserInfo(userID string, newUserInfo *User, ctxLog *log.Entry) (*User, error)
 
-	// ******** Current week **********
+	// ******** Current goal period **********
 
-	AddDayToCurrentWeek(userID string, dayIndex int, ctxLog *log.Entry) error
-	DeleteDayFromCurrentWeek(userID string, dayIndex int, ctxLog *log.Entry) error
+	// Replaces the streak, the current goal period and its weekly goal with recomputed ones
+	UpdateStreak(userID string, streak int32, currentWeek []bool, weeklyGoal int32, ctxLog *log.Entry) error
+	// Replaces the goal changes from the first of the new ones on
+	SaveWeeklyGoalChanges(userID string, changes []WeeklyGoalChange, ctxLog *log.Entry) error
+	// Returns the ids of every user, for the periodic evaluation of the streaks
+	GetUserIDs(ctxLog *log.Entry) ([]string, error)
+
+	// ******** Streak protection **********
+
+	// Preloads the whole gym attendance, the streak freezes, the vacation weeks, the goal history and the badges
+	GetUserWithStreakProtection(userID string, ctxLog *log.Entry) (*User, error)
+	// Creates the new freezes and updates the used ones
+	SaveStreakFreezes(userID string, freezes []StreakFreeze, ctxLog *log.Entry) error
+	AddVacationWeek(userID string, week time.Time, ctxLog *log.Entry) error
+	DeleteVacationWeek(userID string, week time.Time, ctxLog *log.Entry) error
 
 	// ******** Weight **********
 
@@ -30,12 +45,47 @@ type IUserDAO interface {
 	GetUserWithFatHistory(userID string, months int32, ctxLog *log.Entry) (*User, error)
 	AddBodyFat(userID string, bodyFat float32, date time.Time, ctxLog *log.Entry) error
 
+	// ******** Body composition **********
+
+	GetUserWithBodyHistory(userID string, months int32, ctxLog *log.Entry) (*User, error)
+
 	// ******** Gym attendances **********
 
 	GetUserWithAttendance(userID string, year int32, month int32, ctxLog *log.Entry) (*User, error)
 	AddGymAttendance(userID string, date time.Time, ctxLog *log.Entry) error
+	// Also deletes the verification of the day and decreases the count of verified attendances
 	DeleteGymAttendance(userID string, date time.Time, ctxLog *log.Entry) error
 	GetAttendanceCount(userID string, ctxLog *log.Entry) (int32, error)
+	CheckGymAttendance(userID string, date time.Time, ctxLog *log.Entry) (bool, error)
+
+	// ******** Gym sessions **********
+
+	// Sets the times of the attendance of the session date
+	SetGymSession(userID string, session GymAttendance, ctxLog *log.Entry) error
+	GetTrainingTime(userID string, ctxLog *log.Entry) (time.Duration, error)
+	// Counts the sessions started between both minutes of the day, the last one excluded
+	CountSessionsStartedBetween(userID string, fromMinute int16, toMinute int16, ctxLog *log.Entry) (int32, error)
+
+	// ******** Verified attendances **********
+
+	// Also increases the count of verified attendances of the user
+	AddVerifiedAttendance(attendance *VerifiedAttendance, ctxLog *log.Entry) error
+	CheckVerifiedAttendance(userID string, date time.Time, ctxLog *log.Entry) (bool, error)
+
+	// ******** History import **********
+
+	// Preloads the whole gym attendance, weight and fat history
+	GetUserWithHistory(userID string, ctxLog *log.Entry) (*User, error)
+	// Preloads the gym attendance, weight and fat history between both dates, zero dates are not bounded
+	GetUserWithHistoryBetween(userID string, from time.Time, to time.Time, ctxLog *log.Entry) (*User, error)
+	// Stores all the rows at once and sets the current weight and body fat to the latest measured ones
+	ImportHistory(userID string, attendances []GymAttendance, weights []WeightHistory, fats []FatHistory, ctxLog *log.Entry) error
+
+	// ******** Calendar subscription **********
+
+	// Preloads the whole gym attendance
+	GetUserByCalendarToken(token string, ctxLog *log.Entry) (*User, error)
+	SetCalendarToken(userID string, token string, ctxLog *log.Entry) error
 
 	// ******** Friends **********
 
@@ -44,6 +94,7 @@ type IUserDAO interface {
 	AddFriend(userID string, friendID string, ctxLog *log.Entry) (*User, error)
 	DeleteFriend(userID string, friendID string, ctxLog *log.Entry) error
 	GetFriendsCount(userID string, ctxLog *log.Entry) (int32, error)
+	GetFriendIDs(userID string, ctxLog *log.Entry) ([]string, error)
 	CheckFriendship(userID string, friendID string, ctxLog *log.Entry) (bool, error)
 	GetUserWithFriendRequests(userID string, ctxLog *log.Entry) (*User, error)
 	AddFriendRequest(userID string, friendID string, ctxLog *log.Entry) (*User, error)
@@ -52,6 +103,7 @@ type IUserDAO interface {
 	CheckFriendRequest(userID string, friendID string, ctxLog *log.Entry) (bool, error)
 	// ******** Experience **********
 
+	// Atomically adds exp to the user, which never gets negative
 	AddExperience(userID string, exp int64, ctxLog *log.Entry) error
 
 	// ******** Rankings **********
@@ -61,4 +113,9 @@ type IUserDAO interface {
 	GetUserWithGlobalRank(userID string, ctxLog *log.Entry) (*User, int64, error)
 	GetFriendsOrderedByExp(userID string, offset int64, size int32, ctxLog *log.Entry) ([]*User, error)
 	GetUserWithFriendsRank(userID string, ctxLog *log.Entry) (*User, int64, error)
+	// Same rankings ordered by verified attendances first
+	GetUsersOrderedByVerifiedAttendances(offset int64, size int32, ctxLog *log.Entry) ([]*User, error)
+	GetUserWithVerifiedGlobalRank(userID string, ctxLog *log.Entry) (*User, int64, error)
+	GetFriendsOrderedByVerifiedAttendances(userID string, offset int64, size int32, ctxLog *log.Entry) ([]*User, error)
+	GetUserWithVerifiedFriendsRank(userID string, ctxLog *log.Entry) (*User, int64, error)
 }
@@ -2,6 +2,9 @@ package user
 
 import (
 	badgeModelDB "gym-badges-api/internal/repository/badge"
+	exerciseModelDB "gym-badges-api/internal/repository/exercise"
+	goalModelDB "gym-badges-api/internal/repository/goal"
+	workoutModelDB "gym-badges-api/internal/repository/workout"
 	"time"
 
 	"github.com/go-openapi/strfmt"
@@ -11,36 +14,128 @@ import (
 type User struct {
 	ID          string        `gorm:"primary_key;not null" json:"user_id"`
 	BodyFat     *float32      `gorm:"null;type:decimal(5,2)" json:"body_fat"`
-	CurrentWeek pq.BoolArray  `gorm:"not null;type:bool[]" json:"current_week"`
+	CurrentWeek pq.BoolArray  `gorm:"not null;type:bool[]" json:"current_week"` // Attended days of the current goal period
 	Email       string        `gorm:"not null;unique" json:"email"`
 	Experience  int64         `gorm:"not null" json:"experience"`
 	Image       strfmt.Base64 `gorm:"null" json:"image"`
 	Name        string        `gorm:"not null" json:"name"`
 	Password    string        `gorm:"not null" json:"password"`
 	Streak      int32         `gorm:"not null" json:"streak"`
-	WeeklyGoal  int32         `gorm:"not null" json:"weekly_goal"`
+	WeeklyGoal  int32         `gorm:"not null" json:"weekly_goal"` // Sessions of the current goal period
 	Weight      *float32      `gorm:"null;type:decimal(5,2)" json:"weight"`
-	Height      *float32      `gorm:"null;type:decimal(5,2)" json:"height"`
+	Height      *float32      `gorm:"null;type:decimal(5,2)" json:"height"` // In cm
 	Sex         string        `gorm:"not null" json:"sex"`
+	// Secret of the attendance calendar subscription URL
+	CalendarToken *string `gorm:"null;unique" json:"calendar_token"`
+	// Count of the verified attendances, for the rankings
+	VerifiedAttendances int32 `gorm:"not null;default:0" json:"verified_attendances"`
+	// ISO weekday the weeks start on. The defaults keep the existing users on weekly goals from Monday
+	WeekStart int16 `gorm:"not null;default:1" json:"week_start"`
+	// Days of the periods the weekly goal is counted in, 7 are weeks
+	GoalPeriodDays int16 `gorm:"not null;default:7" json:"goal_period_days"`
+	// First day of one of the goal periods that are not weeks
+	GoalPeriodAnchor *time.Time `gorm:"null" json:"goal_period_anchor"`
+	// Administrators of the badge catalog, only granted in the database
+	Admin bool `gorm:"not null;default:false" json:"admin"`
 
-	GymAttendance  []GymAttendance       `gorm:"constraint:OnDelete:CASCADE"`
-	FatHistory     []FatHistory          `gorm:"constraint:OnDelete:CASCADE"`
-	WeightHistory  []WeightHistory       `gorm:"constraint:OnDelete:CASCADE"`
-	Friends        []*User               `gorm:"many2many:user_friends;constraint:OnDelete:CASCADE"`
-	FriendRequests []*User               `gorm:"many2many:friend_requests;constraint:OnDelete:CASCADE"`
-	Badges         []*badgeModelDB.Badge `gorm:"many2many:user_badges;constraint:OnDelete:CASCADE"`
-	TopFeats       []*badgeModelDB.Badge `gorm:"many2many:user_top_feats;constraint:OnDelete:CASCADE"`
-	Preferences    []Preference          `gorm:"constraint:OnDelete:CASCADE"`
+	GymAttendance  []GymAttendance                 `gorm:"constraint:OnDelete:CASCADE"`
+	FatHistory     []FatHistory                    `gorm:"constraint:OnDelete:CASCADE"`
+	WeightHistory  []WeightHistory                 `gorm:"constraint:OnDelete:CASCADE"`
+	Friends        []*User                         `gorm:"many2many:user_friends;constraint:OnDelete:CASCADE"`
+	FriendRequests []*User                         `gorm:"many2many:friend_requests;constraint:OnDelete:CASCADE"`
+	Badges         []*badgeModelDB.Badge           `gorm:"many2many:user_badges;constraint:OnDelete:CASCADE"`
+	TopFeats       []*badgeModelDB.Badge           `gorm:"many2many:user_top_feats;constraint:OnDelete:CASCADE"`
+	Preferences    []Preference                    `gorm:"constraint:OnDelete:CASCADE"`
+	Workouts       []workoutModelDB.WorkoutSession `gorm:"constraint:OnDelete:CASCADE"`
+	Exercises      []exerciseModelDB.Exercise      `gorm:"foreignKey:CreatorID;constraint:OnDelete:CASCADE"`
+	Goals          []goalModelDB.Goal              `gorm:"constraint:OnDelete:CASCADE"`
+	StreakFreezes  []StreakFreeze                  `gorm:"constraint:OnDelete:CASCADE"`
+	VacationWeeks  []VacationWeek                  `gorm:"constraint:OnDelete:CASCADE"`
+	GoalHistory    []WeeklyGoalChange              `gorm:"constraint:OnDelete:CASCADE"`
+	VerifiedDays   []VerifiedAttendance            `gorm:"constraint:OnDelete:CASCADE"`
 
 	CreatedAt time.Time `gorm:"null" json:"created_at"`
 	UpdatedAt time.Time `gorm:"null" json:"updated_at"`
 	DeletedAt time.Time `gorm:"null" json:"deleted_at"`
 }
 
+const (
+	DefaultWeekStart      = 1 // Monday
+	DefaultGoalPeriodDays = 7
+	MaxGoalPeriodDays     = 28
+)
+
 type GymAttendance struct {
 	UserID string    `gorm:"primary_key;not null"`
 	Date   time.Time `gorm:"primary_key;not null"`
 
+	// Optional session times, a session can end on the next day
+	StartTime *time.Time `gorm:"null"`
+	EndTime   *time.Time `gorm:"null"`
+	// Minute of the day the session started in the local time of the user, the timestamps lose it
+	StartMinute *int16 `gorm:"null"`
+
+	CreatedAt time.Time `gorm:"null" json:"created_at"`
+	UpdatedAt time.Time `gorm:"null" json:"updated_at"`
+	DeletedAt time.Time `gorm:"null" json:"deleted_at"`
+}
+
+// Proofs of a verified attendance
+const (
+	CheckInMethodGPS = "gps"
+	CheckInMethodQR  = "qr"
+)
+
+// VerifiedAttendance is a check-in proved at a registered gym. The day is also stored as a gym attendance.
+type VerifiedAttendance struct {
+	UserID    string    `gorm:"primary_key;not null"`
+	Date      time.Time `gorm:"primary_key;not null"`
+	GymID     int64     `gorm:"not null;index"`
+	Method    string    `gorm:"not null"`
+	CheckedAt time.Time `gorm:"not null"`
+
+	CreatedAt time.Time `gorm:"null" json:"created_at"`
+	UpdatedAt time.Time `gorm:"null" json:"updated_at"`
+	DeletedAt time.Time `gorm:"null" json:"deleted_at"`
+}
+
+const (
+	FreezeReasonExperience = "experience"
+	FreezeReasonBadge      = "badge"
+)
+
+// StreakFreeze protects one missed week of the streak. Used freezes are kept as the audit trail.
+type StreakFreeze struct {
+	ID       int64      `gorm:"primary_key;autoIncrement"`
+	UserID   string     `gorm:"not null;index"`
+	Reason   string     `gorm:"not null"`
+	BadgeID  *int16     `gorm:"null"` // Badge that granted the freeze
+	EarnedAt time.Time  `gorm:"not null"`
+	UsedWeek *time.Time `gorm:"null"` // Monday of the protected week
+	UsedAt   *time.Time `gorm:"null"`
+
+	CreatedAt time.Time `gorm:"null" json:"created_at"`
+	UpdatedAt time.Time `gorm:"null" json:"updated_at"`
+	DeletedAt time.Time `gorm:"null" json:"deleted_at"`
+}
+
+// VacationWeek is declared in advance and pauses the streak
+type VacationWeek struct {
+	UserID string    `gorm:"primary_key;not null"`
+	Week   time.Time `gorm:"primary_key;not null"` // Monday of the week
+
+	CreatedAt time.Time `gorm:"null" json:"created_at"`
+	UpdatedAt time.Time `gorm:"null" json:"updated_at"`
+	DeletedAt time.Time `gorm:"null" json:"deleted_at"`
+}
+
+// WeeklyGoalChange sets the weekly goal from the goal period starting on a day on. Each period is evaluated
+// with the last goal in force when it started, so the changes are never applied to past periods.
+type WeeklyGoalChange struct {
+	UserID        string    `gorm:"primary_key;not null"`
+	EffectiveFrom time.Time `gorm:"primary_key;not null"` // First day of the first period with the goal
+	WeeklyGoal    int32     `gorm:"not null"`
+
 	CreatedAt time.Time `gorm:"null" json:"created_at"`
 	UpdatedAt time.Time `gorm:"null" json:"updated_at"`
 	DeletedAt time.Time `gorm:"null" json:"deleted_at"`
@@ -0,0 +1,308 @@
+package postgresql
+
+import (
+	"errors"
+	customErrors "gym-badges-api/internal/custom-errors"
+	"gym-badges-api/internal/repository/config/postgresql"
+	userModelDB "gym-badges-api/internal/repository/user"
+	workoutModelDB "gym-badges-api/internal/repository/workout"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	userNotFoundErrorMsg    = "User not found"
+	workoutNotFoundErrorMsg = "Workout not found"
+)
+
+type workoutDAO struct {
+	connection *gorm.DB
+}
+
+func NewWorkoutDAO() workoutModelDB.IWorkoutDAO {
+	connection := postgresql.OpenConnection()
+	return &workoutDAO{connection: connection}
+}
+
+func preloadExercises(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Exercises", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position")
+		}).
+		Preload("Exercises.Sets", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position")
+		})
+}
+
+func (dao workoutDAO) GetWorkouts(userID string, from time.Time, to time.Time, offset int32, size int32, ctxLog *log.Entry) ([]*workoutModelDB.WorkoutSession, error) {
+
+	ctxLog.Debugf("WORKOUT_DAO: Getting workouts for user: %s offset: %d size: %d", userID, offset, size)
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	var user userModelDB.User
+
+	queryResult := dao.connection.
+		Where("id = ?", userID).
+		First(&user)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return nil, customErrors.BuildNotFoundError(userNotFoundErrorMsg)
+		}
+		return nil, queryResult.Error
+	}
+
+	var workouts = make([]*workoutModelDB.WorkoutSession, 0)
+
+	query := preloadExercises(dao.connection).
+		Where("user_id = ?", userID)
+
+	if !from.IsZero() {
+		query = query.Where("date >= ?", from)
+	}
+	if !to.IsZero() {
+		query = query.Where("date <= ?", to)
+	}
+
+	queryResult = query.
+		Order("date DESC, id DESC").
+		Limit(int(size)).
+		Offset(int(offset)).
+		Find(&workouts)
+
+	if queryResult.Error != nil {
+		return nil, queryResult.Error
+	}
+
+	return workouts, nil
+}
+
+func (dao workoutDAO) GetWorkout(userID string, workoutID int64, ctxLog *log.Entry) (*workoutModelDB.WorkoutSession, error) {
+
+	ctxLog.Debugf("WORKOUT_DAO: Getting workout %d of user: %s", workoutID, userID)
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	var workout workoutModelDB.WorkoutSession
+
+	queryResult := preloadExercises(dao.connection).
+		Where("id = ? AND user_id = ?", workoutID, userID).
+		First(&workout)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return nil, customErrors.BuildNotFoundError(workoutNotFoundErrorMsg)
+		}
+		return nil, queryResult.Error
+	}
+
+	return &workout, nil
+}
+
+func (dao workoutDAO) CreateWorkout(workout *workoutModelDB.WorkoutSession, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("WORKOUT_DAO: Creating workout for user: %s", workout.UserID)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	var user userModelDB.User
+
+	queryResult := dao.connection.
+		Where("id = ?", workout.UserID).
+		First(&user)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return customErrors.BuildNotFoundError(userNotFoundErrorMsg)
+		}
+		return queryResult.Error
+	}
+
+	return dao.connection.Create(workout).Error
+}
+
+func (dao workoutDAO) EditWorkout(workout *workoutModelDB.WorkoutSession, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("WORKOUT_DAO: Editing workout %d of user: %s", workout.ID, workout.UserID)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	var current workoutModelDB.WorkoutSession
+
+	queryResult := dao.connection.
+		Where("id = ? AND user_id = ?", workout.ID, workout.UserID).
+		First(&current)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return customErrors.BuildNotFoundError(workoutNotFoundErrorMsg)
+		}
+		return queryResult.Error
+	}
+
+	workout.CreatedAt = current.CreatedAt
+
+	// Exercises and sets are replaced as a whole
+	return dao.connection.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("workout_session_id = ?", workout.ID).Delete(&workoutModelDB.WorkoutExercise{}).Error; err != nil {
+			return err
+		}
+		return tx.Save(workout).Error
+	})
+}
+
+func (dao workoutDAO) DeleteWorkout(userID string, workoutID int64, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("WORKOUT_DAO: Deleting workout %d of user: %s", workoutID, userID)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	queryResult := dao.connection.
+		Where("id = ? AND user_id = ?", workoutID, userID).
+		Delete(&workoutModelDB.WorkoutSession{})
+
+	if queryResult.Error != nil {
+		return queryResult.Error
+	}
+
+	if queryResult.RowsAffected == 0 {
+		return customErrors.BuildNotFoundError(workoutNotFoundErrorMsg)
+	}
+
+	return nil
+}
+
+func (dao workoutDAO) GetExerciseHistory(userID string, exercise string, months int32, ctxLog *log.Entry) ([]*workoutModelDB.WorkoutSession, error) {
+
+	ctxLog.Debugf("WORKOUT_DAO: Getting %s history for user: %s for last %d months", exercise, userID, months)
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	var user userModelDB.User
+
+	queryResult := dao.connection.
+		Where("id = ?", userID).
+		First(&user)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return nil, customErrors.BuildNotFoundError(userNotFoundErrorMsg)
+		}
+		return nil, queryResult.Error
+	}
+
+	var workouts = make([]*workoutModelDB.WorkoutSession, 0)
+
+	sessionsWithExercise := dao.connection.
+		Model(&workoutModelDB.WorkoutExercise{}).
+		Select("workout_session_id").
+		Where("LOWER(name) = LOWER(?)", exercise)
+
+	query := dao.connection.
+		Preload("Exercises", func(db *gorm.DB) *gorm.DB {
+			return db.Where("LOWER(name) = LOWER(?)", exercise).Order("position")
+		}).
+		Preload("Exercises.Sets", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position")
+		}).
+		Where("user_id = ? AND id IN (?)", userID, sessionsWithExercise)
+
+	if months > 0 {
+		startDate := time.Now().AddDate(0, -int(months), 0)
+		query = query.Where("date >= ?", startDate)
+	}
+
+	queryResult = query.
+		Order("date ASC, id ASC").
+		Find(&workouts)
+
+	if queryResult.Error != nil {
+		return nil, queryResult.Error
+	}
+
+	return workouts, nil
+}
+
+func (dao workoutDAO) GetRecords(userID string, exercise string, ctxLog *log.Entry) ([]*workoutModelDB.PersonalRecord, error) {
+
+	ctxLog.Debugf("WORKOUT_DAO: Getting personal records for user: %s exercise: %s", userID, exercise)
+
+	if err := dao.connection.Error; err != nil {
+		return nil, err
+	}
+
+	var user userModelDB.User
+
+	queryResult := dao.connection.
+		Where("id = ?", userID).
+		First(&user)
+
+	if queryResult.Error != nil {
+		if errors.Is(queryResult.Error, gorm.ErrRecordNotFound) {
+			return nil, customErrors.BuildNotFoundError(userNotFoundErrorMsg)
+		}
+		return nil, queryResult.Error
+	}
+
+	var records = make([]*workoutModelDB.PersonalRecord, 0)
+
+	query := dao.connection.
+		Where("user_id = ?", userID)
+
+	if exercise != "" {
+		query = query.Where("LOWER(exercise) = LOWER(?)", exercise)
+	}
+
+	queryResult = query.
+		Order("date ASC, id ASC").
+		Find(&records)
+
+	if queryResult.Error != nil {
+		return nil, queryResult.Error
+	}
+
+	return records, nil
+}
+
+func (dao workoutDAO) AddRecords(records []*workoutModelDB.PersonalRecord, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("WORKOUT_DAO: Adding %d personal records", len(records))
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	return dao.connection.Create(&records).Error
+}
+
+func (dao workoutDAO) DeleteWorkoutRecords(userID string, workoutID int64, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("WORKOUT_DAO: Deleting personal records of workout %d of user: %s", workoutID, userID)
+
+	if err := dao.connection.Error; err != nil {
+		return err
+	}
+
+	return dao.connection.
+		Where("workout_session_id = ? AND user_id = ?", workoutID, userID).
+		Delete(&workoutModelDB.PersonalRecord{}).Error
+}
@@ -0,0 +1,23 @@
+package workout_dao
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type IWorkoutDAO interface {
+	// Zero from/to dates leave the range open on that side
+	GetWorkouts(userID string, from time.Time, to time.Time, offset int32, size int32, ctxLog *log.Entry) ([]*WorkoutSession, error)
+	GetWorkout(userID string, workoutID int64, ctxLog *log.Entry) (*WorkoutSession, error)
+	CreateWorkout(workout *WorkoutSession, ctxLog *log.Entry) error
+	EditWorkout(workout *WorkoutSession, ctxLog *log.Entry) error
+	DeleteWorkout(userID string, workoutID int64, ctxLog *log.Entry) error
+	// Returned sessions only contain the exercises matching the given name
+	GetExerciseHistory(userID string, exercise string, months int32, ctxLog *log.Entry) ([]*WorkoutSession, error)
+
+	// An empty exercise returns the records of every exercise
+	GetRecords(userID string, exercise string, ctxLog *log.Entry) ([]*PersonalRecord, error)
+	AddRecords(records []*PersonalRecord, ctxLog *log.Entry) error
+	DeleteWorkoutRecords(userID string, workoutID int64, ctxLog *log.Entry) error
+}
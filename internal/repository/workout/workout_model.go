@@ -0,0 +1,72 @@
+package workout_dao
+
+import "time"
+
+// Types of personal records
+const (
+	RecordOneRM            = "1RM"
+	RecordThreeRM          = "3RM"
+	RecordFiveRM           = "5RM"
+	RecordTenRM            = "10RM"
+	RecordEstimatedEpley   = "e1RM-epley"
+	RecordEstimatedBrzycki = "e1RM-brzycki"
+)
+
+type WorkoutSession struct {
+	ID     int64     `gorm:"primaryKey;autoIncrement"`
+	UserID string    `gorm:"not null;index"`
+	Date   time.Time `gorm:"not null;index"`
+	Name   string    `gorm:"not null"`
+	Notes  string    `gorm:"not null"`
+
+	Exercises []WorkoutExercise `gorm:"constraint:OnDelete:CASCADE"`
+	Records   []PersonalRecord  `gorm:"constraint:OnDelete:CASCADE"`
+
+	CreatedAt time.Time `gorm:"null" json:"created_at"`
+	UpdatedAt time.Time `gorm:"null" json:"updated_at"`
+	DeletedAt time.Time `gorm:"null" json:"deleted_at"`
+}
+
+type WorkoutExercise struct {
+	ID               int64  `gorm:"primaryKey;autoIncrement"`
+	WorkoutSessionID int64  `gorm:"not null;index"`
+	Position         int32  `gorm:"not null"`
+	Name             string `gorm:"not null"`
+
+	Sets []WorkoutSet `gorm:"constraint:OnDelete:CASCADE"`
+
+	CreatedAt time.Time `gorm:"null" json:"created_at"`
+	UpdatedAt time.Time `gorm:"null" json:"updated_at"`
+	DeletedAt time.Time `gorm:"null" json:"deleted_at"`
+}
+
+type WorkoutSet struct {
+	ID                int64    `gorm:"primaryKey;autoIncrement"`
+	WorkoutExerciseID int64    `gorm:"not null;index"`
+	Position          int32    `gorm:"not null"`
+	Reps              int32    `gorm:"not null"`
+	Weight            float32  `gorm:"not null;type:decimal(6,2)"` // Load in kg
+	RPE               *float32 `gorm:"null;type:decimal(3,1)"`
+	RestSeconds       *int32   `gorm:"null"`
+
+	CreatedAt time.Time `gorm:"null" json:"created_at"`
+	UpdatedAt time.Time `gorm:"null" json:"updated_at"`
+	DeletedAt time.Time `gorm:"null" json:"deleted_at"`
+}
+
+// PersonalRecord is stored each time a record is broken, so the rows of an exercise and type are the
+// progression of that record.
+type PersonalRecord struct {
+	ID               int64     `gorm:"primaryKey;autoIncrement"`
+	UserID           string    `gorm:"not null;index"`
+	WorkoutSessionID int64     `gorm:"not null;index"`
+	Exercise         string    `gorm:"not null"`
+	Type             string    `gorm:"not null"`
+	Weight           float32   `gorm:"not null;type:decimal(6,2)"` // Lifted or estimated load in kg
+	Reps             int32     `gorm:"not null"`
+	Date             time.Time `gorm:"not null"`
+
+	CreatedAt time.Time `gorm:"null" json:"created_at"`
+	UpdatedAt time.Time `gorm:"null" json:"updated_at"`
+	DeletedAt time.Time `gorm:"null" json:"deleted_at"`
+}
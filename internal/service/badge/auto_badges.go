@@ -1,301 +1,441 @@
 package badge_service
 
 import (
+	"errors"
+	"fmt"
+	customErrors "gym-badges-api/internal/custom-errors"
+	badgeDAO "gym-badges-api/internal/repository/badge"
+	workoutDAO "gym-badges-api/internal/repository/workout"
+	"math"
+	"slices"
+	"strings"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 )
 
-func (s badgesService) checkAutoBadges(userID string, ctxLog *log.Entry) error {
+// *******************************************************************
+// AUTO BADGES
+// *******************************************************************
 
-	eg := new(errgroup.Group)
+func (s badgesService) CheckAutoBadges(userID string, ctxLog *log.Entry) error {
 
-	eg.Go(func() error {
-		// These depend on each other
+	ctxLog.Debugf("BADGES_SERVICE: Checking auto badges.")
 
-		if err := s.checkStreakBadges(userID, ctxLog); err != nil {
-			return err
-		}
-		if err := s.checkAttendancesBadges(userID, ctxLog); err != nil {
-			return err
-		}
-		if err := s.checkTimeBadges(userID, ctxLog); err != nil {
-			return err
+	rules, err := s.badgeDAO.GetBadgeRules(ctxLog)
+	if err != nil {
+		return err
+	}
+
+	metrics, err := s.getMetrics(userID, rules, ctxLog)
+	if err != nil {
+		return err
+	}
+
+	pending := make([]int16, 0)
+	for _, rule := range rules {
+		if ruleMet(rule, metrics) {
+			pending = append(pending, rule.BadgeID)
 		}
+	}
 
-		return nil
-	})
+	_, err = s.awardBadges(userID, pending, ctxLog)
+	return err
+}
 
-	eg.Go(func() error {
-		return s.checkGlobalRankingBadges(userID, ctxLog)
-	})
+func (s badgesService) CheckGlobalRankBadges(ctxLog *log.Entry) error {
 
-	eg.Go(func() error {
-		// These depend on each other
+	ctxLog.Debugf("BADGES_SERVICE: Checking global rank badges.")
 
-		if err := s.checkFriendCountBadges(userID, ctxLog); err != nil {
-			return err
+	rules, err := s.badgeDAO.GetBadgeRules(ctxLog)
+	if err != nil {
+		return err
+	}
+
+	// Only the users up to the lowest position a rule accepts can meet a global rank rule
+	lastRank := int64(0)
+	for _, rule := range rules {
+		clauses := []badgeDAO.BadgeRuleClause{rule.BadgeRuleClause}
+		for _, condition := range rule.Conditions {
+			clauses = append(clauses, condition.BadgeRuleClause)
 		}
-		if err := s.checkFriendsRankingBadges(userID, ctxLog); err != nil {
-			return err
+
+		for _, clause := range clauses {
+			if clause.Metric != badgeDAO.MetricGlobalRank {
+				continue
+			}
+			switch clause.Comparator {
+			case badgeDAO.ComparatorLTE, badgeDAO.ComparatorEQ:
+				lastRank = max(lastRank, clause.Threshold)
+			case badgeDAO.ComparatorLT:
+				lastRank = max(lastRank, clause.Threshold-1)
+			}
 		}
+	}
 
+	if lastRank <= 0 {
 		return nil
-	})
+	}
 
-	return eg.Wait()
-}
+	users, err := s.userDAO.GetUsersOrderedByExp(0, int32(min(lastRank, math.MaxInt32)), ctxLog)
+	if err != nil {
+		return err
+	}
 
-// *******************************************************************
-// CONSISTENCY BADGES
-// *******************************************************************
+	// A user failing does not stop the others
+	failed := 0
+	for _, user := range users {
+		if err := s.CheckAutoBadges(user.ID, ctxLog); err != nil {
+			ctxLog.Warnf("BADGES_SERVICE: Checking auto badges for user %s failed: %s", user.ID, err)
+			failed++
+		}
+	}
 
-var (
-	streakBadges = []struct {
-		badgeID int16
-		weeks   int32
-	}{
-		{66, 1},  // BadgeID 66: The first week
-		{67, 4},  // BadgeID 67: 4 weeks streak
-		{68, 10}, // BadgeID 68: 10 weeks streak
-		{69, 20}, // BadgeID 69: 20 weeks streak
-		{70, 50}, // BadgeID 70: 50 weeks streak
-	}
-
-	attendancesBadges = []struct {
-		badgeID     int16
-		attendances int32
-	}{
-		{72, 100}, // BadgeID 72: Reach 100 gym sessions
-		{73, 200}, // BadgeID 73: Reach 200 gym sessions
-		{74, 500}, // BadgeID 74: Reach 500 gym sessions
-	}
-
-	timeBadges = []struct {
-		badgeID int16
-		time    time.Duration
-	}{
-		{71, time.Hour * 24 * 30},      // BadgeID 71: First month
-		{75, time.Hour * 24 * 182},     // BadgeID 75: Six months
-		{76, time.Hour * 24 * 365},     // BadgeID 76: First year!
-		{77, time.Hour * 24 * 365 * 2}, // BadgeID 77: Two years
-		{78, time.Hour * 24 * 365 * 3}, // BadgeID 78: Three years
-		{79, time.Hour * 24 * 365 * 5}, // BadgeID 79: Five years
+	if failed > 0 {
+		return fmt.Errorf("the auto badges of %d of %d users could not be checked", failed, len(users))
 	}
-)
 
-func (s badgesService) checkStreakBadges(userID string, ctxLog *log.Entry) error {
+	return nil
+}
+
+// metricKey identifies a metric of the user, the window is only set for the sessions between metric
+type metricKey struct {
+	metric     string
+	fromMinute int16
+	toMinute   int16
+}
 
-	ctxLog.Debugf("BADGES_SERVICE: Checking streak badges.")
+func clauseKey(clause badgeDAO.BadgeRuleClause) metricKey {
 
-	user, err := s.userDAO.GetUser(userID, ctxLog)
-	if err != nil {
-		return err
+	key := metricKey{metric: clause.Metric}
+	if clause.Metric == badgeDAO.MetricSessionsBetween && clause.FromMinute != nil && clause.ToMinute != nil {
+		key.fromMinute = *clause.FromMinute
+		key.toMinute = *clause.ToMinute
 	}
 
-	for _, b := range streakBadges {
-		if user.Streak >= b.weeks {
-			hasBadge, err := s.badgeDAO.CheckBadge(userID, b.badgeID, ctxLog)
-			if err != nil {
-				return err
-			}
+	return key
+}
+
+// getMetrics gets in parallel every metric used by the rules
+func (s badgesService) getMetrics(userID string, rules []*badgeDAO.BadgeRule, ctxLog *log.Entry) (map[metricKey]int64, error) {
+
+	keys := make([]metricKey, 0)
+	seen := make(map[metricKey]bool)
 
-			if !hasBadge {
-				if err := s.AddBadge(userID, b.badgeID, ctxLog); err != nil {
-					return err
-				}
+	for _, rule := range rules {
+		clauses := []badgeDAO.BadgeRuleClause{rule.BadgeRuleClause}
+		for _, condition := range rule.Conditions {
+			clauses = append(clauses, condition.BadgeRuleClause)
+		}
+
+		for _, clause := range clauses {
+			if key := clauseKey(clause); !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
 			}
 		}
 	}
 
-	return nil
+	values := make([]int64, len(keys))
+
+	eg := new(errgroup.Group)
+	for i, key := range keys {
+		eg.Go(func() error {
+			var err error
+			values[i], err = s.getMetric(userID, key, ctxLog)
+			return err
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	metrics := make(map[metricKey]int64, len(keys))
+	for i, key := range keys {
+		metrics[key] = values[i]
+	}
+
+	return metrics, nil
 }
 
-func (s badgesService) checkAttendancesBadges(userID string, ctxLog *log.Entry) error {
+func (s badgesService) getMetric(userID string, key metricKey, ctxLog *log.Entry) (int64, error) {
+
+	switch key.metric {
+	case badgeDAO.MetricStreak:
+		user, err := s.userDAO.GetUser(userID, ctxLog)
+		if err != nil {
+			return 0, err
+		}
+		return int64(user.Streak), nil
 
-	ctxLog.Debugf("BADGES_SERVICE: Checking attendances badges.")
+	case badgeDAO.MetricAttendances:
+		attendanceCount, err := s.userDAO.GetAttendanceCount(userID, ctxLog)
+		return int64(attendanceCount), err
 
-	attendanceCount, err := s.userDAO.GetAttendanceCount(userID, ctxLog)
-	if err != nil {
-		return err
+	case badgeDAO.MetricAccountAgeDays:
+		user, err := s.userDAO.GetUser(userID, ctxLog)
+		if err != nil {
+			return 0, err
+		}
+		return int64(time.Since(user.CreatedAt) / (time.Hour * 24)), nil
+
+	case badgeDAO.MetricTrainingHours:
+		trainingTime, err := s.userDAO.GetTrainingTime(userID, ctxLog)
+		return int64(trainingTime / time.Hour), err
+
+	case badgeDAO.MetricSessionsBetween:
+		sessions, err := s.userDAO.CountSessionsStartedBetween(userID, key.fromMinute, key.toMinute, ctxLog)
+		return int64(sessions), err
+
+	case badgeDAO.MetricGlobalRank:
+		_, rank, err := s.userDAO.GetUserWithGlobalRank(userID, ctxLog)
+		return rank, err
+
+	case badgeDAO.MetricFriendsRank:
+		_, rank, err := s.userDAO.GetUserWithFriendsRank(userID, ctxLog)
+		return rank, err
+
+	case badgeDAO.MetricFriendsCount:
+		friendsCount, err := s.userDAO.GetFriendsCount(userID, ctxLog)
+		return int64(friendsCount), err
 	}
 
-	for _, b := range attendancesBadges {
-		if attendanceCount >= b.attendances {
-			hasBadge, err := s.badgeDAO.CheckBadge(userID, b.badgeID, ctxLog)
-			if err != nil {
-				return err
-			}
+	// Unknown metrics never meet a rule
+	ctxLog.Warnf("BADGES_SERVICE: Unknown badge rule metric %s", key.metric)
+	return 0, nil
+}
 
-			if !hasBadge {
-				if err := s.AddBadge(userID, b.badgeID, ctxLog); err != nil {
-					return err
-				}
-			}
+func ruleMet(rule *badgeDAO.BadgeRule, metrics map[metricKey]int64) bool {
+
+	if !clauseMet(rule.BadgeRuleClause, metrics) {
+		return false
+	}
+
+	for _, condition := range rule.Conditions {
+		if !clauseMet(condition.BadgeRuleClause, metrics) {
+			return false
 		}
 	}
 
-	return nil
+	return true
 }
 
-func (s badgesService) checkTimeBadges(userID string, ctxLog *log.Entry) error {
+func clauseMet(clause badgeDAO.BadgeRuleClause, metrics map[metricKey]int64) bool {
 
-	ctxLog.Debugf("BADGES_SERVICE: Checking time badges.")
+	value, ok := metrics[clauseKey(clause)]
+	if !ok || !knownMetrics[clause.Metric] {
+		return false
+	}
 
-	user, err := s.userDAO.GetUser(userID, ctxLog)
-	if err != nil {
-		return err
+	switch clause.Comparator {
+	case badgeDAO.ComparatorGTE:
+		return value >= clause.Threshold
+	case badgeDAO.ComparatorGT:
+		return value > clause.Threshold
+	case badgeDAO.ComparatorLTE:
+		return value <= clause.Threshold
+	case badgeDAO.ComparatorLT:
+		return value < clause.Threshold
+	case badgeDAO.ComparatorEQ:
+		return value == clause.Threshold
 	}
 
-	userLifespan := time.Since(user.CreatedAt)
+	return false
+}
+
+var (
+	knownMetrics = map[string]bool{
+		badgeDAO.MetricStreak:          true,
+		badgeDAO.MetricAttendances:     true,
+		badgeDAO.MetricAccountAgeDays:  true,
+		badgeDAO.MetricTrainingHours:   true,
+		badgeDAO.MetricSessionsBetween: true,
+		badgeDAO.MetricGlobalRank:      true,
+		badgeDAO.MetricFriendsRank:     true,
+		badgeDAO.MetricFriendsCount:    true,
+	}
+
+	knownComparators = map[string]bool{
+		badgeDAO.ComparatorGTE: true,
+		badgeDAO.ComparatorGT:  true,
+		badgeDAO.ComparatorLTE: true,
+		badgeDAO.ComparatorLT:  true,
+		badgeDAO.ComparatorEQ:  true,
+	}
+)
 
-	for _, b := range timeBadges {
-		if userLifespan >= b.time {
-			hasBadge, err := s.badgeDAO.CheckBadge(userID, b.badgeID, ctxLog)
+// awardBadges adds the badges the user does not have yet. A badge whose parent is also pending can be
+// awarded once the parent is, so it keeps going while there is progress. Returns the awarded badges
+func (s badgesService) awardBadges(userID string, pending []int16, ctxLog *log.Entry) ([]int16, error) {
+
+	awarded := make([]int16, 0)
+
+	// Own error targets, as the checks of a user can run concurrently
+	var (
+		forbidden customErrors.ForbiddenError
+		conflict  customErrors.ConflictError
+	)
+
+	for progress := true; progress; {
+
+		progress = false
+		remaining := make([]int16, 0, len(pending))
+
+		for _, badgeID := range pending {
+
+			hasBadge, err := s.badgeDAO.CheckBadge(userID, badgeID, ctxLog)
 			if err != nil {
-				return err
+				return awarded, err
+			}
+
+			if hasBadge {
+				continue
 			}
 
-			if !hasBadge {
-				if err := s.AddBadge(userID, b.badgeID, ctxLog); err != nil {
-					return err
-				}
+			err = s.addBadge(userID, badgeID, badgeDAO.AwardSourceAuto, nil, ctxLog)
+			switch {
+			case err == nil:
+				awarded = append(awarded, badgeID)
+				progress = true
+			case errors.As(err, &forbidden):
+				// Parent badge not achieved yet
+				remaining = append(remaining, badgeID)
+			case errors.As(err, &conflict):
+				// Awarded meanwhile by a concurrent check
+				continue
+			default:
+				return awarded, err
 			}
 		}
+
+		pending = remaining
 	}
 
-	return nil
+	return awarded, nil
 }
 
 // *******************************************************************
-// RANKING BADGES
+// STRENGTH BADGES
 // *******************************************************************
 
-var (
-	globalRankingBadges = []struct {
-		badgeID int16
-		rank    int64
-	}{
-		{80, 500}, // BadgeID 80: Get to the top 500 at the Global Ranking
-		{81, 100}, // BadgeID 81: Get to the top 100 at the Global Ranking
-		{82, 50},  // BadgeID 82: Get to the top 50 at the Global Ranking
-		{83, 10},  // BadgeID 83: Get to the top 10 at the Global Ranking
-		{84, 3},   // BadgeID 84: Get to the top 3 at the Global Ranking
-		{85, 1},   // BadgeID 85: Get to the top 1 at the Global Ranking
-	}
-
-	friendsRankingBadges = []struct {
-		badgeID    int16
-		minFriends int32
-		rank       int64
-	}{
-		{90, 20, 3}, // BadgeID 90: Reach the podium at the Friends Ranking with at least 20 friends
-		{91, 20, 1}, // BadgeID 91: Get to the top at the Friends Ranking with at least 20 friends
-		{92, 10, 3}, // BadgeID 92: Reach the podium at the Friends Ranking with at least 10 friends
-		{93, 10, 1}, // BadgeID 93: Get to the top at the Friends Ranking with at least 10 friends
-		{94, 5, 1},  // BadgeID 94: Get to the top at the Friends Ranking with at least 5 friends
-	}
-)
+func (s badgesService) CheckStrengthBadges(userID string, exercises []workoutDAO.WorkoutExercise, ctxLog *log.Entry) ([]int16, error) {
 
-func (s badgesService) checkGlobalRankingBadges(userID string, ctxLog *log.Entry) error {
+	ctxLog.Debugf("BADGES_SERVICE: Checking strength badges.")
 
-	ctxLog.Debugf("BADGES_SERVICE: Checking global ranking badges.")
+	badges, err := s.badgeDAO.GetBadgesWithCriteria(ctxLog)
+	if err != nil {
+		return nil, err
+	}
 
-	_, rank, err := s.userDAO.GetUserWithGlobalRank(userID, ctxLog)
+	user, err := s.userDAO.GetUser(userID, ctxLog)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	for _, b := range globalRankingBadges {
-		if rank <= b.rank {
-			hasBadge, err := s.badgeDAO.CheckBadge(userID, b.badgeID, ctxLog)
-			if err != nil {
-				return err
-			}
+	pending := make([]int16, 0)
+	proofPending := make([]*badgeDAO.Badge, 0)
+	for _, badge := range badges {
+		if !criteriaMet(badge.Criteria, exercises, user.Weight) {
+			continue
+		}
+		// The sets are logged by the user, so these badges need a claim verified by friends or a moderator
+		if badge.RequiresProof {
+			proofPending = append(proofPending, badge)
+			continue
+		}
+		pending = append(pending, badge.ID)
+	}
 
-			if !hasBadge {
-				if err := s.AddBadge(userID, b.badgeID, ctxLog); err != nil {
-					return err
-				}
-			}
+	awarded, err := s.awardBadges(userID, pending, ctxLog)
+	if err != nil {
+		return awarded, err
+	}
+
+	if len(proofPending) > 0 {
+		if err := s.openClaims(userID, proofPending, ctxLog); err != nil {
+			return awarded, err
 		}
 	}
 
-	return nil
+	return awarded, nil
 }
 
-func (s badgesService) checkFriendsRankingBadges(userID string, ctxLog *log.Entry) error {
-
-	ctxLog.Debugf("BADGES_SERVICE: Checking friends ranking badges.")
+// openClaims opens a pending claim, without proof yet, for each badge the user can achieve and has no claim for
+func (s badgesService) openClaims(userID string, badges []*badgeDAO.Badge, ctxLog *log.Entry) error {
 
-	_, rank, err := s.userDAO.GetUserWithFriendsRank(userID, ctxLog)
+	user, err := s.userDAO.GetUserWithBadges(userID, ctxLog)
 	if err != nil {
 		return err
 	}
 
-	friendsCount, err := s.userDAO.GetFriendsCount(userID, ctxLog)
+	claims, err := s.badgeDAO.GetBadgeClaims(userID, badgeDAO.ClaimStatusPending, ctxLog)
 	if err != nil {
 		return err
 	}
 
-	for _, b := range friendsRankingBadges {
-		if friendsCount >= b.minFriends && rank <= b.rank {
-			hasBadge, err := s.badgeDAO.CheckBadge(userID, b.badgeID, ctxLog)
-			if err != nil {
-				return err
-			}
+	owned := make(map[int16]bool, len(user.Badges))
+	for _, badge := range user.Badges {
+		owned[badge.ID] = true
+	}
 
-			if !hasBadge {
-				if err := s.AddBadge(userID, b.badgeID, ctxLog); err != nil {
-					return err
-				}
-			}
+	for _, badge := range badges {
+
+		// Achieved or already claimed
+		if owned[badge.ID] || slices.ContainsFunc(claims, func(claim *badgeDAO.BadgeClaim) bool {
+			return claim.BadgeID == badge.ID
+		}) {
+			continue
+		}
+
+		if badge.ParentBadgeID != 0 && !owned[badge.ParentBadgeID] {
+			continue
+		}
+
+		claim := badgeDAO.BadgeClaim{
+			UserID:    userID,
+			BadgeID:   badge.ID,
+			Status:    badgeDAO.ClaimStatusPending,
+			CreatedAt: time.Now(),
 		}
+
+		if err := s.badgeDAO.CreateBadgeClaim(&claim, ctxLog); err != nil {
+			return err
+		}
+
+		ctxLog.Infof("BADGES_SERVICE: Claim %d of badge %d opened from a logged workout for user %s", claim.ID, badge.ID, userID)
 	}
 
 	return nil
 }
 
-// *******************************************************************
-// FRIEND COUNT BADGES
-// *******************************************************************
+func criteriaMet(criteria *badgeDAO.BadgeCriteria, exercises []workoutDAO.WorkoutExercise, bodyweight *float32) bool {
 
-var (
-	friendCountBadges = []struct {
-		badgeID    int16
-		minFriends int32
-	}{
-		{86, 1},  // BadgeID 86: Add your first friend
-		{87, 5},  // BadgeID 87: Add five friends
-		{88, 10}, // BadgeID 88: Add ten friends
-		{89, 20}, // BadgeID 89: Add twenty friends
+	if criteria == nil {
+		return false
 	}
-)
-
-func (s badgesService) checkFriendCountBadges(userID string, ctxLog *log.Entry) error {
 
-	ctxLog.Debugf("BADGES_SERVICE: Checking friend count badges.")
-
-	friendsCount, err := s.userDAO.GetFriendsCount(userID, ctxLog)
-	if err != nil {
-		return err
+	minWeight := criteria.MinWeight
+	if criteria.MinBodyweightRatio != nil {
+		if bodyweight == nil {
+			return false
+		}
+		minWeight = max(minWeight, *criteria.MinBodyweightRatio*(*bodyweight))
 	}
 
-	for _, b := range friendCountBadges {
-		if friendsCount >= b.minFriends {
-			hasBadge, err := s.badgeDAO.CheckBadge(userID, b.badgeID, ctxLog)
-			if err != nil {
-				return err
-			}
+	for _, exercise := range exercises {
+
+		if !strings.EqualFold(exercise.Name, criteria.Exercise) {
+			continue
+		}
 
-			if !hasBadge {
-				if err := s.AddBadge(userID, b.badgeID, ctxLog); err != nil {
-					return err
-				}
+		for _, set := range exercise.Sets {
+			if set.Reps >= criteria.MinReps && set.Weight >= minWeight {
+				return true
 			}
 		}
 	}
 
-	return nil
+	return false
 }
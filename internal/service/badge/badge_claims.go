@@ -0,0 +1,421 @@
+package badge_service
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	configs "gym-badges-api/config/gym-badges-server"
+	customErrors "gym-badges-api/internal/custom-errors"
+	badgeDAO "gym-badges-api/internal/repository/badge"
+	eventsService "gym-badges-api/internal/service/events"
+	"gym-badges-api/models"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	log "github.com/sirupsen/logrus"
+)
+
+// Bytes read to detect the format of a proof
+const proofSniffSize = 512
+
+// *******************************************************************
+// BADGE CLAIMS
+// *******************************************************************
+
+func (s badgesService) ClaimBadge(userID string, badgeID int16, witnessID *string, proof io.Reader,
+	ctxLog *log.Entry) (*models.BadgeClaim, error) {
+
+	ctxLog.Debugf("BADGES_SERVICE: Processing ClaimBadge %d for user: %s", badgeID, userID)
+
+	badge, err := s.badgeDAO.GetBadge(badgeID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	if !badge.RequiresProof {
+		return nil, customErrors.BuildBadRequestError("Badge %d does not need proof, it can be marked as achieved.", badge.ID)
+	}
+	if badge.RetiredAt != nil {
+		return nil, customErrors.BuildForbiddenError("Badge %d is retired and cannot be achieved anymore.", badge.ID)
+	}
+
+	if witnessID != nil && *witnessID == "" {
+		witnessID = nil
+	}
+	if witnessID == nil && proof == nil {
+		return nil, customErrors.BuildBadRequestError("The claim needs a proof or a witness.")
+	}
+
+	user, err := s.userDAO.GetUserWithBadges(userID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	hasParent := false
+	for _, b := range user.Badges {
+		if b.ID == badge.ID {
+			return nil, customErrors.BuildConflictError("Badge %d is already achieved.", badge.ID)
+		}
+		if b.ID == badge.ParentBadgeID {
+			hasParent = true
+		}
+	}
+
+	if !hasParent {
+		return nil, customErrors.BuildForbiddenError("Parent Badge %d is needed first to mark badge %d as completed.", badge.ParentBadgeID, badge.ID)
+	}
+
+	pending, err := s.badgeDAO.GetBadgeClaims(userID, badgeDAO.ClaimStatusPending, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, claim := range pending {
+		if claim.BadgeID == badge.ID {
+			return nil, customErrors.BuildConflictError("Badge %d already has a pending claim.", badge.ID)
+		}
+	}
+
+	if witnessID != nil {
+		friends, err := s.userDAO.GetFriendIDs(userID, ctxLog)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(friends, *witnessID) {
+			return nil, customErrors.BuildBadRequestError("The witness has to be one of your friends.")
+		}
+	}
+
+	claim := badgeDAO.BadgeClaim{
+		UserID:    userID,
+		BadgeID:   badge.ID,
+		Status:    badgeDAO.ClaimStatusPending,
+		WitnessID: witnessID,
+		CreatedAt: time.Now(),
+	}
+
+	if proof != nil {
+		proofType, proofURL, err := saveProof(proof)
+		if err != nil {
+			return nil, err
+		}
+		claim.ProofType = &proofType
+		claim.Proof = &proofURL
+	}
+
+	if err := s.badgeDAO.CreateBadgeClaim(&claim, ctxLog); err != nil {
+		removeProof(claim.Proof, ctxLog)
+		return nil, err
+	}
+
+	ctxLog.Infof("BADGES_SERVICE: Claim %d of badge %d created for user %s", claim.ID, badge.ID, userID)
+
+	return mapBadgeClaim(&claim), nil
+}
+
+func (s badgesService) GetBadgeClaims(authUserID string, userID string, ctxLog *log.Entry) (models.BadgeClaimsResponse, error) {
+
+	ctxLog.Debugf("BADGES_SERVICE: Processing GetBadgeClaims of user %s for user: %s", userID, authUserID)
+
+	// The proofs are only shared with the friends, who vouch for the claims
+	if authUserID != userID {
+		friends, err := s.userDAO.GetFriendIDs(userID, ctxLog)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(friends, authUserID) {
+			return nil, customErrors.BuildForbiddenError("Only the friends of the user can see the claims.")
+		}
+	}
+
+	claims, err := s.badgeDAO.GetBadgeClaims(userID, "", ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapBadgeClaims(claims), nil
+}
+
+func (s badgesService) VouchBadgeClaim(friendID string, claimID int64, ctxLog *log.Entry) (*models.BadgeClaim, error) {
+
+	ctxLog.Debugf("BADGES_SERVICE: Processing VouchBadgeClaim %d for user: %s", claimID, friendID)
+
+	claim, err := s.badgeDAO.GetBadgeClaim(claimID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	if claim.Status != badgeDAO.ClaimStatusPending {
+		return nil, customErrors.BuildConflictError("The claim is already %s.", claim.Status)
+	}
+
+	if claim.UserID == friendID {
+		return nil, customErrors.BuildForbiddenError("You cannot vouch for your own claim.")
+	}
+
+	friends, err := s.userDAO.GetFriendIDs(claim.UserID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(friends, friendID) {
+		return nil, customErrors.BuildForbiddenError("Only the friends of the user can vouch for the claim.")
+	}
+
+	vouch := badgeDAO.BadgeClaimVouch{
+		ClaimID:   claim.ID,
+		FriendID:  friendID,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.badgeDAO.AddClaimVouch(&vouch, ctxLog); err != nil {
+		return nil, err
+	}
+
+	claim.Vouches = append(claim.Vouches, vouch)
+
+	if len(claim.Vouches) >= configs.Basic.ClaimVouches {
+
+		err := s.approveClaim(claim, nil, ctxLog)
+
+		// A concurrent vouch reaching the threshold already approved it
+		var conflict customErrors.ConflictError
+		if errors.As(err, &conflict) {
+			current, getErr := s.badgeDAO.GetBadgeClaim(claimID, ctxLog)
+			if getErr == nil && current.Status == badgeDAO.ClaimStatusApproved {
+				return mapBadgeClaim(current), nil
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return mapBadgeClaim(claim), nil
+}
+
+func (s badgesService) GetPendingBadgeClaims(moderatorID string, ctxLog *log.Entry) (models.BadgeClaimsResponse, error) {
+
+	ctxLog.Debugf("BADGES_SERVICE: Processing GetPendingBadgeClaims for moderator: %s", moderatorID)
+
+	if err := s.checkAdmin(moderatorID, ctxLog); err != nil {
+		return nil, err
+	}
+
+	claims, err := s.badgeDAO.GetBadgeClaims("", badgeDAO.ClaimStatusPending, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapBadgeClaims(claims), nil
+}
+
+func (s badgesService) ReviewBadgeClaim(moderatorID string, claimID int64, approve bool, ctxLog *log.Entry) (*models.BadgeClaim, error) {
+
+	ctxLog.Debugf("BADGES_SERVICE: Processing ReviewBadgeClaim %d for moderator: %s", claimID, moderatorID)
+
+	if err := s.checkAdmin(moderatorID, ctxLog); err != nil {
+		return nil, err
+	}
+
+	claim, err := s.badgeDAO.GetBadgeClaim(claimID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case approve && claim.Status == badgeDAO.ClaimStatusPending:
+		err = s.approveClaim(claim, &moderatorID, ctxLog)
+	case !approve && claim.Status != badgeDAO.ClaimStatusRejected:
+		err = s.rejectClaim(claim, moderatorID, ctxLog)
+	default:
+		return nil, customErrors.BuildConflictError("The claim is already %s.", claim.Status)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return mapBadgeClaim(claim), nil
+}
+
+// approveClaim awards the badge, with its experience, and closes the claim in one transaction. The claim stays
+// pending when the badge cannot be awarded, and Conflict is returned when it is not pending anymore
+func (s badgesService) approveClaim(claim *badgeDAO.BadgeClaim, moderatorID *string, ctxLog *log.Entry) error {
+
+	award, err := s.buildAward(claim.UserID, claim.BadgeID, badgeDAO.AwardSourceClaim, moderatorID, ctxLog)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	claim.Status = badgeDAO.ClaimStatusApproved
+	claim.ReviewerID = moderatorID
+	claim.ReviewedAt = &now
+
+	if err := s.badgeDAO.ApproveBadgeClaim(claim, award, ctxLog); err != nil {
+		return err
+	}
+
+	s.eventsService.Publish(eventsService.Event{Type: eventsService.ExperienceChanged, UserID: claim.UserID}, ctxLog)
+
+	ctxLog.Infof("BADGES_SERVICE: Claim %d of badge %d approved for user %s", claim.ID, claim.BadgeID, claim.UserID)
+
+	return nil
+}
+
+// rejectClaim closes the claim and deletes its proof. A claim approved before loses the badge and its experience
+func (s badgesService) rejectClaim(claim *badgeDAO.BadgeClaim, moderatorID string, ctxLog *log.Entry) error {
+
+	if claim.Status == badgeDAO.ClaimStatusApproved {
+		err := s.DeleteBadge(claim.UserID, claim.BadgeID, ctxLog)
+		switch {
+		case errors.As(err, &customErrors.Forbidden):
+			return customErrors.BuildConflictError("The claim cannot be rejected while the user has children of badge %d.", claim.BadgeID)
+		case errors.As(err, &customErrors.NotFound):
+			// The user already removed the badge
+		case err != nil:
+			return err
+		}
+	}
+
+	proof := claim.Proof
+
+	now := time.Now()
+	claim.Status = badgeDAO.ClaimStatusRejected
+	claim.Proof = nil
+	claim.ReviewerID = &moderatorID
+	claim.ReviewedAt = &now
+
+	if err := s.badgeDAO.UpdateBadgeClaim(claim, ctxLog); err != nil {
+		return err
+	}
+
+	removeProof(proof, ctxLog)
+
+	ctxLog.Infof("BADGES_SERVICE: Claim %d of badge %d rejected for user %s", claim.ID, claim.BadgeID, claim.UserID)
+
+	return nil
+}
+
+// saveProof stores the uploaded photo or video. Returns its type and URL
+func saveProof(proof io.Reader) (string, string, error) {
+
+	head := make([]byte, proofSniffSize)
+	n, err := io.ReadFull(proof, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", "", err
+	}
+	head = head[:n]
+
+	proofType, extension := proofFormat(head)
+	if extension == "" {
+		return "", "", customErrors.BuildBadRequestError("The proof has to be a PNG or JPEG photo, or an MP4 or WebM video.")
+	}
+
+	secret := make([]byte, 16)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+
+	if err := os.MkdirAll(configs.Basic.ClaimProofsDir, 0755); err != nil {
+		return "", "", err
+	}
+
+	// The name cannot be guessed, the proofs are only shared with the friends and the moderators
+	fileName := fmt.Sprintf("%s.%s", hex.EncodeToString(secret), extension)
+	filePath := filepath.Join(configs.Basic.ClaimProofsDir, fileName)
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return "", "", err
+	}
+
+	size, err := io.Copy(file, io.MultiReader(bytes.NewReader(head), io.LimitReader(proof, configs.Basic.ClaimProofMaxSize+1)))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && size > configs.Basic.ClaimProofMaxSize {
+		err = customErrors.BuildBadRequestError("The proof cannot be bigger than %d MB.", configs.Basic.ClaimProofMaxSize>>20)
+	}
+	if err != nil {
+		os.Remove(filePath)
+		return "", "", err
+	}
+
+	return proofType, fmt.Sprintf("%s/%s", strings.TrimSuffix(configs.Basic.ClaimProofsPath, "/"), fileName), nil
+}
+
+// proofFormat returns the type and the extension of the proof, an empty extension when it is not supported
+func proofFormat(head []byte) (string, string) {
+
+	switch http.DetectContentType(head) {
+	case "image/png":
+		return badgeDAO.ProofTypePhoto, "png"
+	case "image/jpeg":
+		return badgeDAO.ProofTypePhoto, "jpg"
+	case "video/mp4":
+		return badgeDAO.ProofTypeVideo, "mp4"
+	case "video/webm":
+		return badgeDAO.ProofTypeVideo, "webm"
+	}
+
+	return "", ""
+}
+
+func removeProof(proof *string, ctxLog *log.Entry) {
+
+	if proof == nil {
+		return
+	}
+
+	filePath := filepath.Join(configs.Basic.ClaimProofsDir, filepath.Base(*proof))
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		ctxLog.Warnf("BADGES_SERVICE: Proof %s could not be deleted: %s", filePath, err)
+	}
+}
+
+func mapBadgeClaims(claims []*badgeDAO.BadgeClaim) models.BadgeClaimsResponse {
+
+	response := make(models.BadgeClaimsResponse, len(claims))
+	for i, claim := range claims {
+		response[i] = mapBadgeClaim(claim)
+	}
+
+	return response
+}
+
+func mapBadgeClaim(claim *badgeDAO.BadgeClaim) *models.BadgeClaim {
+
+	response := models.BadgeClaim{
+		ID:         claim.ID,
+		UserID:     claim.UserID,
+		BadgeID:    int32(claim.BadgeID),
+		Status:     claim.Status,
+		ProofType:  claim.ProofType,
+		Proof:      claim.Proof,
+		WitnessID:  claim.WitnessID,
+		ReviewerID: claim.ReviewerID,
+		Vouches:    make([]string, len(claim.Vouches)),
+		CreatedAt:  strfmt.DateTime(claim.CreatedAt),
+	}
+
+	for i, vouch := range claim.Vouches {
+		response.Vouches[i] = vouch.FriendID
+	}
+
+	if claim.ReviewedAt != nil {
+		reviewedAt := strfmt.DateTime(*claim.ReviewedAt)
+		response.ReviewedAt = &reviewedAt
+	}
+
+	return &response
+}
@@ -0,0 +1,178 @@
+package badge_service
+
+import (
+	"cmp"
+	configs "gym-badges-api/config/gym-badges-server"
+	badgeDAO "gym-badges-api/internal/repository/badge"
+	userDAO "gym-badges-api/internal/repository/user"
+	"gym-badges-api/models"
+	"math"
+	"slices"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+// *******************************************************************
+// PROGRESS
+// *******************************************************************
+
+func (s badgesService) GetClosestBadges(userID string, acceptLanguage *string, ctxLog *log.Entry) (*models.ClosestBadgesResponse, error) {
+
+	ctxLog.Debugf("BADGES_SERVICE: Processing GetClosestBadges for user: %s", userID)
+
+	var (
+		eg           *errgroup.Group
+		user         *userDAO.User
+		catalog      map[int16]*badgeDAO.Badge
+		translations *badgeTranslations
+	)
+
+	eg = new(errgroup.Group)
+
+	eg.Go(func() error {
+		var err error
+		user, err = s.userDAO.GetUserWithBadges(userID, ctxLog)
+		return err
+	})
+
+	eg.Go(func() error {
+		var err error
+		catalog, err = s.getCatalog(ctxLog)
+		return err
+	})
+
+	eg.Go(func() error {
+		var err error
+		translations, err = s.getTranslations(acceptLanguage, ctxLog)
+		return err
+	})
+
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	userBadgesMap := make(map[int16]bool)
+	for _, badge := range user.Badges {
+		userBadgesMap[badge.ID] = true
+	}
+
+	progress, err := s.getProgress(userID, userBadgesMap, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	response := models.ClosestBadgesResponse{
+		Badges: make([]*models.Badge, 0, len(progress)),
+	}
+
+	for badgeID, badgeProgress := range progress {
+
+		badge, ok := catalog[badgeID]
+		if !ok {
+			continue
+		}
+
+		// Only the badges that can be awarded right now
+		if badge.ParentBadgeID != 0 && !userBadgesMap[badge.ParentBadgeID] {
+			continue
+		}
+
+		translations.localize(badge)
+
+		response.Badges = append(response.Badges, &models.Badge{
+			Description:   badge.Description,
+			ID:            int32(badge.ID),
+			Image:         badge.Image,
+			Name:          badge.Name,
+			Exp:           badge.Exp,
+			RequiresProof: badge.RequiresProof,
+			Progress:      badgeProgress,
+		})
+	}
+
+	// Closest first, the ones with less left to do break the ties
+	slices.SortFunc(response.Badges, func(a, b *models.Badge) int {
+		return cmp.Or(
+			cmp.Compare(b.Progress.Percent, a.Progress.Percent),
+			cmp.Compare(a.Progress.Target-a.Progress.Current, b.Progress.Target-b.Progress.Current),
+			cmp.Compare(a.ID, b.ID),
+		)
+	})
+
+	if len(response.Badges) > configs.Basic.ClosestBadgesSize {
+		response.Badges = response.Badges[:configs.Basic.ClosestBadgesSize]
+	}
+
+	return &response, nil
+}
+
+// getProgress returns the progress of the user toward the auto badges not achieved yet, by badge id.
+// Only the rules with a minimum of a countable metric have progress
+func (s badgesService) getProgress(userID string, achieved map[int16]bool, ctxLog *log.Entry) (map[int16]*models.BadgeProgress, error) {
+
+	rules, err := s.badgeDAO.GetBadgeRules(ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	// The progress only follows the main clause, so the conditions are not needed
+	pending := make([]*badgeDAO.BadgeRule, 0)
+	for _, rule := range rules {
+		if !achieved[rule.BadgeID] && progressTarget(rule.BadgeRuleClause) > 0 {
+			pending = append(pending, &badgeDAO.BadgeRule{BadgeID: rule.BadgeID, BadgeRuleClause: rule.BadgeRuleClause})
+		}
+	}
+
+	progress := make(map[int16]*models.BadgeProgress, len(pending))
+	if len(pending) == 0 {
+		return progress, nil
+	}
+
+	metrics, err := s.getMetrics(userID, pending, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range pending {
+
+		target := progressTarget(rule.BadgeRuleClause)
+		current := metrics[clauseKey(rule.BadgeRuleClause)]
+
+		progress[rule.BadgeID] = &models.BadgeProgress{
+			Current: current,
+			Target:  target,
+			Percent: math.Round(min(float64(current)/float64(target), 1)*1000) / 10,
+			Unit:    metricUnits[rule.Metric],
+		}
+	}
+
+	return progress, nil
+}
+
+// progressTarget returns the value of the metric that meets the clause, 0 when it has no progress
+func progressTarget(clause badgeDAO.BadgeRuleClause) int64 {
+
+	if _, ok := metricUnits[clause.Metric]; !ok {
+		return 0
+	}
+
+	switch clause.Comparator {
+	case badgeDAO.ComparatorGTE:
+		return max(clause.Threshold, 0)
+	case badgeDAO.ComparatorGT:
+		return max(clause.Threshold+1, 0)
+	}
+
+	return 0
+}
+
+// metricUnits Countable metrics, the rankings are not since a lower value is better
+var metricUnits = map[string]string{
+	badgeDAO.MetricStreak:          "weeks",
+	badgeDAO.MetricAttendances:     "gym sessions",
+	badgeDAO.MetricAccountAgeDays:  "days",
+	badgeDAO.MetricTrainingHours:   "hours",
+	badgeDAO.MetricSessionsBetween: "sessions",
+	badgeDAO.MetricFriendsCount:    "friends",
+}
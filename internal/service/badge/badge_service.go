@@ -1,38 +1,43 @@
 package badge_service
 
 import (
+	configs "gym-badges-api/config/gym-badges-server"
 	customErrors "gym-badges-api/internal/custom-errors"
 	badgeDAO "gym-badges-api/internal/repository/badge"
 	userDAO "gym-badges-api/internal/repository/user"
+	eventsService "gym-badges-api/internal/service/events"
 	"gym-badges-api/models"
+	"time"
 
+	"github.com/go-openapi/strfmt"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 )
 
-func NewBadgeService(userDAO userDAO.IUserDAO, badgeDAO badgeDAO.IBadgeDAO) IBadgeService {
+func NewBadgeService(userDAO userDAO.IUserDAO, badgeDAO badgeDAO.IBadgeDAO, eventsService eventsService.IEventsService) IBadgeService {
 	return &badgesService{
-		userDAO:  userDAO,
-		badgeDAO: badgeDAO,
+		userDAO:       userDAO,
+		badgeDAO:      badgeDAO,
+		eventsService: eventsService,
 	}
 }
 
 type badgesService struct {
-	userDAO  userDAO.IUserDAO
-	badgeDAO badgeDAO.IBadgeDAO
+	userDAO       userDAO.IUserDAO
+	badgeDAO      badgeDAO.IBadgeDAO
+	eventsService eventsService.IEventsService
 }
 
-func (s badgesService) GetBadgesByUserID(userID string, ctxLog *log.Entry) (models.BadgesByUserResponse, error) {
+func (s badgesService) GetBadgesByUserID(userID string, acceptLanguage *string, ctxLog *log.Entry) (models.BadgesByUserResponse, error) {
 
 	ctxLog.Debugf("BADGES_SERVICE: Processing GetBadgesByUserID for user: %s", userID)
 
-	// Check auto achievable badges
-	s.checkAutoBadges(userID, ctxLog)
-
 	var (
-		eg     *errgroup.Group
-		user   *userDAO.User
-		badges []*badgeDAO.Badge
+		eg           *errgroup.Group
+		user         *userDAO.User
+		badges       []*badgeDAO.Badge
+		stats        map[int16]*badgeDAO.BadgeStat
+		translations *badgeTranslations
 	)
 
 	eg = new(errgroup.Group)
@@ -55,28 +60,56 @@ func (s badgesService) GetBadgesByUserID(userID string, ctxLog *log.Entry) (mode
 		return nil
 	})
 
+	eg.Go(func() error {
+		var err error
+		stats, err = s.getBadgeStats(ctxLog)
+		return err
+	})
+
+	eg.Go(func() error {
+		var err error
+		translations, err = s.getTranslations(acceptLanguage, ctxLog)
+		return err
+	})
+
 	if err := eg.Wait(); err != nil {
 		return nil, err
 	}
 
+	translations.localize(badges...)
+
 	userBadgesMap := make(map[int16]bool)
 	for _, badge := range user.Badges {
 		userBadgesMap[badge.ID] = true
 	}
 
+	progress, err := s.getProgress(userID, userBadgesMap, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
 	response := make(models.BadgesByUserResponse, 0)
 
 	badgeMap := make(map[int32][]*models.Badge)
 
 	for _, badge := range badges {
 
+		// Retired badges are only shown to the users that achieved them
+		if badge.RetiredAt != nil && !userBadgesMap[badge.ID] {
+			continue
+		}
+
 		b := models.Badge{
-			Achieved:    userBadgesMap[badge.ID],
-			Description: badge.Description,
-			ID:          int32(badge.ID),
-			Image:       badge.Image,
-			Name:        badge.Name,
-			Exp:         badge.Exp,
+			Achieved:      userBadgesMap[badge.ID],
+			Description:   badge.Description,
+			ID:            int32(badge.ID),
+			Image:         badge.Image,
+			Name:          badge.Name,
+			Exp:           badge.Exp,
+			Criteria:      mapCriteria(badge.Criteria),
+			RequiresProof: badge.RequiresProof,
+			Stats:         mapBadgeStats(stats[badge.ID]),
+			Progress:      progress[badge.ID],
 		}
 
 		if badge.ParentBadgeID == 0 {
@@ -93,6 +126,20 @@ func (s badgesService) GetBadgesByUserID(userID string, ctxLog *log.Entry) (mode
 	return response, nil
 }
 
+func mapCriteria(criteria *badgeDAO.BadgeCriteria) *models.BadgeCriteria {
+
+	if criteria == nil {
+		return nil
+	}
+
+	return &models.BadgeCriteria{
+		Exercise:           criteria.Exercise,
+		MinWeight:          criteria.MinWeight,
+		MinReps:            criteria.MinReps,
+		MinBodyweightRatio: criteria.MinBodyweightRatio,
+	}
+}
+
 func addChildren(badge *models.Badge, auxMap map[int32][]*models.Badge) {
 
 	children := auxMap[badge.ID]
@@ -112,14 +159,47 @@ func (s badgesService) AddBadge(userID string, badgeID int16, ctxLog *log.Entry)
 
 	ctxLog.Debugf("BADGES_SERVICE: Processing AddBadge for user: %s", userID)
 
-	user, err := s.userDAO.GetUserWithBadges(userID, ctxLog)
+	return s.addBadge(userID, badgeID, badgeDAO.AwardSourceManual, nil, ctxLog)
+}
+
+// addBadge awards the badge with the parent rule, recording where the award comes from
+func (s badgesService) addBadge(userID string, badgeID int16, source string, grantedBy *string, ctxLog *log.Entry) error {
+
+	award, err := s.buildAward(userID, badgeID, source, grantedBy, ctxLog)
 	if err != nil {
 		return err
 	}
 
+	// The exp is granted with the award, so a concurrent award of the same badge fails with Conflict
+	if err := s.badgeDAO.AddBadge(award, ctxLog); err != nil {
+		return err
+	}
+
+	s.eventsService.Publish(eventsService.Event{Type: eventsService.ExperienceChanged, UserID: userID}, ctxLog)
+
+	return nil
+}
+
+// buildAward checks the badge can be awarded to the user from the source, following the parent rule
+func (s badgesService) buildAward(userID string, badgeID int16, source string, grantedBy *string, ctxLog *log.Entry) (*badgeDAO.UserBadge, error) {
+
+	user, err := s.userDAO.GetUserWithBadges(userID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
 	badge, err := s.badgeDAO.GetBadge(badgeID, ctxLog)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if badge.RetiredAt != nil {
+		return nil, customErrors.BuildForbiddenError("Badge %d is retired and cannot be achieved anymore.", badge.ID)
+	}
+
+	// These badges are only awarded once their claim is verified
+	if badge.RequiresProof && source != badgeDAO.AwardSourceClaim {
+		return nil, customErrors.BuildForbiddenError("Badge %d needs a claim with a proof or a witness.", badge.ID)
 	}
 
 	// Check user already has badge's parent
@@ -132,16 +212,17 @@ func (s badgesService) AddBadge(userID string, badgeID int16, ctxLog *log.Entry)
 	}
 
 	if !hasParent {
-		return customErrors.BuildForbiddenError("Parent Badge %d is needed first to mark badge %d as completed.", badge.ParentBadgeID, badge.ID)
-	}
-
-	err = s.badgeDAO.AddBadge(userID, badgeID, ctxLog)
-	if err != nil {
-		return err
+		return nil, customErrors.BuildForbiddenError("Parent Badge %d is needed first to mark badge %d as completed.", badge.ParentBadgeID, badge.ID)
 	}
 
-	// Add exp to user
-	return s.userDAO.AddExperience(userID, badge.Exp, ctxLog)
+	return &badgeDAO.UserBadge{
+		UserID:     userID,
+		BadgeID:    badgeID,
+		AwardedAt:  time.Now(),
+		Source:     source,
+		ExpGranted: badge.Exp,
+		GrantedBy:  grantedBy,
+	}, nil
 }
 
 func (s badgesService) DeleteBadge(userID string, badgeID int16, ctxLog *log.Entry) error {
@@ -171,11 +252,79 @@ func (s badgesService) DeleteBadge(userID string, badgeID int16, ctxLog *log.Ent
 		return customErrors.BuildForbiddenError("Cannot delete badge %d because user has children badges.", badge.ID)
 	}
 
-	err = s.badgeDAO.DeleteBadge(userID, badgeID, ctxLog)
-	if err != nil {
+	// The exp the badge granted is taken back with the award
+	if _, err := s.badgeDAO.DeleteBadge(userID, badgeID, ctxLog); err != nil {
+		return err
+	}
+
+	s.eventsService.Publish(eventsService.Event{Type: eventsService.ExperienceChanged, UserID: userID}, ctxLog)
+
+	return nil
+}
+
+// *******************************************************************
+// ACHIEVEMENT TIMELINE
+// *******************************************************************
+
+func (s badgesService) GetBadgeTimeline(userID string, page int32, acceptLanguage *string, ctxLog *log.Entry) (*models.BadgeTimelineResponse, error) {
+
+	ctxLog.Debugf("BADGES_SERVICE: Processing GetBadgeTimeline for user: %s", userID)
+
+	offset := (page - 1) * configs.Basic.BadgeTimelinePageSize
+	size := configs.Basic.BadgeTimelinePageSize
+
+	var (
+		eg           *errgroup.Group
+		awards       []*badgeDAO.UserBadge
+		catalog      map[int16]*badgeDAO.Badge
+		translations *badgeTranslations
+	)
+
+	eg = new(errgroup.Group)
+
+	eg.Go(func() error {
+		var err error
+		awards, err = s.badgeDAO.GetBadgeAwards(userID, offset, size, ctxLog)
 		return err
+	})
+
+	eg.Go(func() error {
+		var err error
+		catalog, err = s.getCatalog(ctxLog)
+		return err
+	})
+
+	eg.Go(func() error {
+		var err error
+		translations, err = s.getTranslations(acceptLanguage, ctxLog)
+		return err
+	})
+
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	response := models.BadgeTimelineResponse{
+		Awards: make([]*models.BadgeAward, 0, len(awards)),
+	}
+
+	for _, award := range awards {
+
+		b := models.BadgeAward{
+			BadgeID:    int32(award.BadgeID),
+			AwardedAt:  strfmt.DateTime(award.AwardedAt),
+			Source:     award.Source,
+			ExpGranted: award.ExpGranted,
+		}
+
+		if badge, ok := catalog[award.BadgeID]; ok {
+			translations.localize(badge)
+			b.Name = badge.Name
+			b.Image = badge.Image
+		}
+
+		response.Awards = append(response.Awards, &b)
 	}
 
-	// Add exp to user
-	return s.userDAO.AddExperience(userID, -badge.Exp, ctxLog)
+	return &response, nil
 }
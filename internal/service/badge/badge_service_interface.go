@@ -1,13 +1,66 @@
 package badge_service
 
 import (
+	workoutDAO "gym-badges-api/internal/repository/workout"
 	"gym-badges-api/models"
+	"io"
 
 	log "github.com/sirupsen/logrus"
 )
 
 type IBadgeService interface {
-	GetBadgesByUserID(userID string, ctxLog *log.Entry) (models.BadgesByUserResponse, error)
+	// The names and descriptions are in the supported language the client prefers, English by default
+	GetBadgesByUserID(userID string, acceptLanguage *string, ctxLog *log.Entry) (models.BadgesByUserResponse, error)
 	AddBadge(userID string, badgeID int16, ctxLog *log.Entry) error
 	DeleteBadge(userID string, badgeID int16, ctxLog *log.Entry) error
+	// Returns the badges the user achieved, newest first
+	GetBadgeTimeline(userID string, page int32, acceptLanguage *string, ctxLog *log.Entry) (*models.BadgeTimelineResponse, error)
+	// Returns the auto badges the user is closest to achieve, with their progress
+	GetClosestBadges(userID string, acceptLanguage *string, ctxLog *log.Entry) (*models.ClosestBadgesResponse, error)
+	// Statistics of the catalog, rarest badges first
+	GetBadgeStats(ctxLog *log.Entry) (*models.BadgeStatsResponse, error)
+	// Recomputes the statistics, run periodically
+	RefreshBadgeStats(ctxLog *log.Entry) error
+	// Awards the badges whose rules are met
+	CheckAutoBadges(userID string, ctxLog *log.Entry) error
+	// Checks the auto badges of the users at the top of the global ranking, whose position changes when other
+	// users gain experience. Run periodically
+	CheckGlobalRankBadges(ctxLog *log.Entry) error
+	// Awards the badges whose criteria are met by a logged set, and opens a claim for the ones that require
+	// proof. Returns the awarded badges
+	CheckStrengthBadges(userID string, exercises []workoutDAO.WorkoutExercise, ctxLog *log.Entry) ([]int16, error)
+
+	// ******** Catalog administration, only for admins **********
+
+	CreateBadge(adminID string, request *models.CreateBadgeRequest, ctxLog *log.Entry) (*models.AdminBadge, error)
+	EditBadge(adminID string, badgeID int16, request *models.EditBadgeRequest, ctxLog *log.Entry) (*models.AdminBadge, error)
+	// Moves the badge below another one, or to the root when the parent is 0
+	ReparentBadge(adminID string, badgeID int16, parentBadgeID int16, ctxLog *log.Entry) (*models.AdminBadge, error)
+	RetireBadge(adminID string, badgeID int16, ctxLog *log.Entry) error
+	// Sorts the children of the parent, the root badges when it is 0
+	ReorderBadges(adminID string, parentBadgeID int16, badgeIDs []int16, ctxLog *log.Entry) error
+	UploadBadgeImage(adminID string, badgeID int16, file io.Reader, ctxLog *log.Entry) (*models.AdminBadge, error)
+	GetBadgeAudit(adminID string, badgeID int16, ctxLog *log.Entry) (models.BadgeAuditResponse, error)
+	// Sets the rule that awards the badge automatically, replacing the old one
+	SetBadgeRule(adminID string, badgeID int16, request *models.BadgeRule, ctxLog *log.Entry) (*models.BadgeRule, error)
+	DeleteBadgeRule(adminID string, badgeID int16, ctxLog *log.Entry) error
+	GetBadgeTranslations(adminID string, badgeID int16, ctxLog *log.Entry) (*models.BadgeTranslationsResponse, error)
+	// Sets the name and description of the badge in a supported locale, replacing the old ones
+	SetBadgeTranslation(adminID string, badgeID int16, locale string, request *models.BadgeTranslationRequest, ctxLog *log.Entry) (*models.BadgeTranslation, error)
+	DeleteBadgeTranslation(adminID string, badgeID int16, locale string, ctxLog *log.Entry) error
+	// Awards the badge to the user, with the parent rule of the claims. Badges that require proof need a claim
+	GrantBadge(adminID string, badgeID int16, userID string, ctxLog *log.Entry) error
+
+	// ******** Badge claims **********
+
+	// Claims a badge that requires proof, with an uploaded photo or video, a witness or both
+	ClaimBadge(userID string, badgeID int16, witnessID *string, proof io.Reader, ctxLog *log.Entry) (*models.BadgeClaim, error)
+	// Only the user and the friends can see the claims
+	GetBadgeClaims(authUserID string, userID string, ctxLog *log.Entry) (models.BadgeClaimsResponse, error)
+	// A friend of the user vouches for the claim, which is approved with enough vouches
+	VouchBadgeClaim(friendID string, claimID int64, ctxLog *log.Entry) (*models.BadgeClaim, error)
+	// Pending claims of every user, only for moderators
+	GetPendingBadgeClaims(moderatorID string, ctxLog *log.Entry) (models.BadgeClaimsResponse, error)
+	// Approves or rejects the claim. Rejecting an approved claim takes the badge back
+	ReviewBadgeClaim(moderatorID string, claimID int64, approve bool, ctxLog *log.Entry) (*models.BadgeClaim, error)
 }
@@ -2,13 +2,26 @@ package badge_service
 
 import (
 	"errors"
+	configs "gym-badges-api/config/gym-badges-server"
+	customErrors "gym-badges-api/internal/custom-errors"
 	badgeDAO "gym-badges-api/internal/repository/badge"
 	userDAO "gym-badges-api/internal/repository/user"
+	workoutDAO "gym-badges-api/internal/repository/workout"
+	eventsService "gym-badges-api/internal/service/events"
 	mockDAO "gym-badges-api/mocks/dao"
+	mockService "gym-badges-api/mocks/service"
+	"gym-badges-api/models"
 	toolsLogging "gym-badges-api/tools/logging"
 	toolsTesting "gym-badges-api/tools/testing"
+	"gym-badges-api/tools/utils"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/go-openapi/strfmt"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	log "github.com/sirupsen/logrus"
@@ -22,17 +35,21 @@ func TestServiceBadgeSuite(t *testing.T) {
 var _ = Describe("SERVICE: Badge Test Suite", func() {
 
 	var (
-		mockCtrl     *gomock.Controller
-		mockUserDAO  *mockDAO.MockIUserDAO
-		mockBadgeDAO *mockDAO.MockIBadgeDAO
-		service      IBadgeService
+		mockCtrl          *gomock.Controller
+		mockUserDAO       *mockDAO.MockIUserDAO
+		mockBadgeDAO      *mockDAO.MockIBadgeDAO
+		mockEventsService *mockService.MockIEventsService
+		service           IBadgeService
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockUserDAO = mockDAO.NewMockIUserDAO(mockCtrl)
 		mockBadgeDAO = mockDAO.NewMockIBadgeDAO(mockCtrl)
-		service = NewBadgeService(mockUserDAO, mockBadgeDAO)
+		mockEventsService = mockService.NewMockIEventsService(mockCtrl)
+		service = NewBadgeService(mockUserDAO, mockBadgeDAO, mockEventsService)
+
+		mockEventsService.EXPECT().Publish(gomock.Any(), gomock.Any()).AnyTimes()
 	})
 
 	AfterEach(func() {
@@ -105,6 +122,19 @@ var _ = Describe("SERVICE: Badge Test Suite", func() {
 				Name:   "John",
 				Badges: badges[:2],
 			}
+
+			// No auto badge rules
+			mockBadgeDAO.EXPECT().GetBadgeRules(ctxLogger).
+				AnyTimes().
+				Return([]*badgeDAO.BadgeRule{}, nil)
+
+			mockBadgeDAO.EXPECT().GetBadgeStats(ctxLogger).
+				AnyTimes().
+				Return([]*badgeDAO.BadgeStat{}, nil)
+
+			mockBadgeDAO.EXPECT().GetBadgeTranslations(gomock.Any(), ctxLogger).
+				AnyTimes().
+				Return([]*badgeDAO.BadgeTranslation{}, nil)
 		})
 
 		It("CASE: Successful get badges by user_id", func() {
@@ -117,7 +147,7 @@ var _ = Describe("SERVICE: Badge Test Suite", func() {
 				Times(1).
 				Return(badges, nil)
 
-			response, err := service.GetBadgesByUserID(userID, ctxLogger)
+			response, err := service.GetBadgesByUserID(userID, nil, ctxLogger)
 			Expect(err).To(BeNil())
 			Expect(len(response)).To(Equal(2))
 
@@ -169,7 +199,7 @@ var _ = Describe("SERVICE: Badge Test Suite", func() {
 				Times(1).
 				Return(badges, nil)
 
-			response, err := service.GetBadgesByUserID(userID, ctxLogger)
+			response, err := service.GetBadgesByUserID(userID, nil, ctxLogger)
 			Expect(err).To(BeNil())
 			Expect(len(response)).To(Equal(2))
 
@@ -202,7 +232,7 @@ var _ = Describe("SERVICE: Badge Test Suite", func() {
 				Times(1).
 				Return(badges, nil)
 
-			response, err := service.GetBadgesByUserID(userID, ctxLogger)
+			response, err := service.GetBadgesByUserID(userID, nil, ctxLogger)
 			Expect(err).To(Not(BeNil()))
 			Expect(response).To(BeNil())
 		})
@@ -217,11 +247,1458 @@ var _ = Describe("SERVICE: Badge Test Suite", func() {
 				Times(1).
 				Return(nil, errors.New("timeout"))
 
-			response, err := service.GetBadgesByUserID(userID, ctxLogger)
+			response, err := service.GetBadgesByUserID(userID, nil, ctxLogger)
+			Expect(err).To(Not(BeNil()))
+			Expect(response).To(BeNil())
+		})
+
+	})
+
+	Context("Badge statistics", func() {
+
+		var (
+			ctxLogger *log.Entry
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+		})
+
+		It("CASE: Successful get badge stats sorted by rarity", func() {
+
+			refreshedAt := time.Now()
+			firstAchievedAt := refreshedAt.AddDate(0, -1, 0)
+
+			mockBadgeDAO.EXPECT().GetBadgeStats(ctxLogger).
+				Times(1).
+				Return([]*badgeDAO.BadgeStat{
+					{BadgeID: 1, Holders: 10, ActiveHolders: 6, ActiveUsers: 8, RefreshedAt: refreshedAt},
+					{BadgeID: 2, Holders: 3, ActiveHolders: 1, ActiveUsers: 8, RefreshedAt: refreshedAt,
+						FirstAchieverID: utils.NewString("john"), FirstAchievedAt: &firstAchievedAt,
+						AvgDaysToEarn: utils.NewFloat64(12.345)},
+					{BadgeID: 3, Holders: 1, ActiveHolders: 1, ActiveUsers: 8, RefreshedAt: refreshedAt},
+				}, nil)
+
+			response, err := service.GetBadgeStats(ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response.ActiveUsers).To(Equal(int64(8)))
+			Expect(time.Time(*response.RefreshedAt)).To(BeTemporally("==", refreshedAt))
+			Expect(len(response.Badges)).To(Equal(3))
+
+			Expect(response.Badges[0].BadgeID).To(Equal(int32(3)))
+			Expect(response.Badges[0].ActivePercentage).To(Equal(12.5))
+			Expect(response.Badges[1].BadgeID).To(Equal(int32(2)))
+			Expect(*response.Badges[1].FirstAchieverID).To(Equal("john"))
+			Expect(*response.Badges[1].AvgDaysToEarn).To(Equal(12.3))
+			Expect(response.Badges[2].BadgeID).To(Equal(int32(1)))
+			Expect(response.Badges[2].ActivePercentage).To(Equal(75.0))
+			Expect(response.Badges[2].AvgDaysToEarn).To(BeNil())
+		})
+
+		It("CASE: Get badge stats failed cause badge dao respond with a error", func() {
+
+			mockBadgeDAO.EXPECT().GetBadgeStats(ctxLogger).
+				Times(1).
+				Return(nil, errors.New("timeout"))
+
+			response, err := service.GetBadgeStats(ctxLogger)
 			Expect(err).To(Not(BeNil()))
 			Expect(response).To(BeNil())
 		})
 
+		It("CASE: Refresh badge stats counts users active in the configured window", func() {
+
+			mockBadgeDAO.EXPECT().RefreshBadgeStats(gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(activeSince time.Time, _ *log.Entry) error {
+					expected := time.Now().AddDate(0, 0, -configs.Basic.ActiveUserDays)
+					Expect(activeSince).To(BeTemporally("~", expected, time.Minute))
+					return nil
+				})
+
+			Expect(service.RefreshBadgeStats(ctxLogger)).To(Succeed())
+		})
+
+		It("CASE: Badges include their statistics", func() {
+
+			user := userDAO.User{ID: "admin"}
+
+			mockUserDAO.EXPECT().GetUserWithBadges(user.ID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockBadgeDAO.EXPECT().GetBadges(ctxLogger).
+				Times(1).
+				Return([]*badgeDAO.Badge{{ID: 1, Name: "badge1"}, {ID: 2, Name: "badge2"}}, nil)
+
+			mockBadgeDAO.EXPECT().GetBadgeRules(ctxLogger).
+				AnyTimes().
+				Return([]*badgeDAO.BadgeRule{}, nil)
+
+			mockBadgeDAO.EXPECT().GetBadgeStats(ctxLogger).
+				Times(1).
+				Return([]*badgeDAO.BadgeStat{{BadgeID: 1, Holders: 4, ActiveHolders: 1, ActiveUsers: 4}}, nil)
+
+			mockBadgeDAO.EXPECT().GetBadgeTranslations(gomock.Any(), ctxLogger).
+				AnyTimes().
+				Return([]*badgeDAO.BadgeTranslation{}, nil)
+
+			response, err := service.GetBadgesByUserID(user.ID, nil, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response[0].Stats.Holders).To(Equal(int64(4)))
+			Expect(response[0].Stats.ActivePercentage).To(Equal(25.0))
+			Expect(response[1].Stats).To(BeNil())
+		})
+
+	})
+
+	Context("Badge progress", func() {
+
+		var (
+			ctxLogger *log.Entry
+			userID    string
+			badges    []*badgeDAO.Badge
+			rules     []*badgeDAO.BadgeRule
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+
+			userID = "admin"
+
+			configs.Basic.ClosestBadgesSize = 3
+
+			badges = []*badgeDAO.Badge{
+				{ID: 1, Name: "badge1"},
+				{ID: 2, Name: "badge2", ParentBadgeID: 1},
+				{ID: 3, Name: "badge3", ParentBadgeID: 4},
+				{ID: 4, Name: "badge4"},
+				{ID: 5, Name: "badge5"},
+				{ID: 6, Name: "badge6"},
+			}
+
+			rules = []*badgeDAO.BadgeRule{
+				{BadgeID: 1, BadgeRuleClause: badgeDAO.BadgeRuleClause{Metric: badgeDAO.MetricStreak, Comparator: badgeDAO.ComparatorGTE, Threshold: 2}},
+				{BadgeID: 2, BadgeRuleClause: badgeDAO.BadgeRuleClause{Metric: badgeDAO.MetricAttendances, Comparator: badgeDAO.ComparatorGTE, Threshold: 100}},
+				{BadgeID: 3, BadgeRuleClause: badgeDAO.BadgeRuleClause{Metric: badgeDAO.MetricFriendsCount, Comparator: badgeDAO.ComparatorGT, Threshold: 4}},
+				{BadgeID: 5, BadgeRuleClause: badgeDAO.BadgeRuleClause{Metric: badgeDAO.MetricStreak, Comparator: badgeDAO.ComparatorGTE, Threshold: 8},
+					Conditions: []badgeDAO.BadgeRuleCondition{
+						{BadgeRuleClause: badgeDAO.BadgeRuleClause{Metric: badgeDAO.MetricGlobalRank, Comparator: badgeDAO.ComparatorLTE, Threshold: 10}},
+					}},
+				{BadgeID: 6, BadgeRuleClause: badgeDAO.BadgeRuleClause{Metric: badgeDAO.MetricGlobalRank, Comparator: badgeDAO.ComparatorLTE, Threshold: 10}},
+			}
+
+			mockUserDAO.EXPECT().GetUserWithBadges(userID, ctxLogger).
+				Times(1).
+				Return(&userDAO.User{ID: userID, Badges: badges[:1]}, nil)
+
+			mockBadgeDAO.EXPECT().GetBadges(ctxLogger).
+				Times(1).
+				Return(badges, nil)
+
+			mockBadgeDAO.EXPECT().GetBadgeRules(ctxLogger).
+				Times(1).
+				Return(rules, nil)
+
+			// Only the countable metrics of the badges not achieved are needed
+			mockUserDAO.EXPECT().GetUser(userID, ctxLogger).
+				Times(1).
+				Return(&userDAO.User{ID: userID, Streak: 6}, nil)
+
+			mockUserDAO.EXPECT().GetAttendanceCount(userID, ctxLogger).
+				Times(1).
+				Return(int32(37), nil)
+
+			mockUserDAO.EXPECT().GetFriendsCount(userID, ctxLogger).
+				Times(1).
+				Return(int32(7), nil)
+
+			mockBadgeDAO.EXPECT().GetBadgeTranslations(gomock.Any(), ctxLogger).
+				AnyTimes().
+				Return([]*badgeDAO.BadgeTranslation{}, nil)
+		})
+
+		It("CASE: Badges not achieved include their progress", func() {
+
+			mockBadgeDAO.EXPECT().GetBadgeStats(ctxLogger).
+				Times(1).
+				Return([]*badgeDAO.BadgeStat{}, nil)
+
+			response, err := service.GetBadgesByUserID(userID, nil, ctxLogger)
+			Expect(err).To(BeNil())
+
+			Expect(response[0].ID).To(Equal(int32(1)))
+			Expect(response[0].Progress).To(BeNil())
+			Expect(response[0].Children[0].Progress).To(Equal(&models.BadgeProgress{Current: 37, Target: 100, Percent: 37, Unit: "gym sessions"}))
+			Expect(response[1].Children[0].Progress).To(Equal(&models.BadgeProgress{Current: 7, Target: 5, Percent: 100, Unit: "friends"}))
+			Expect(response[2].Progress).To(Equal(&models.BadgeProgress{Current: 6, Target: 8, Percent: 75, Unit: "weeks"}))
+			Expect(response[3].Progress).To(BeNil())
+		})
+
+		It("CASE: Closest badges are the ones that can be awarded, closest first", func() {
+
+			response, err := service.GetClosestBadges(userID, nil, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(len(response.Badges)).To(Equal(2))
+
+			Expect(response.Badges[0].ID).To(Equal(int32(5)))
+			Expect(response.Badges[0].Progress.Percent).To(Equal(75.0))
+			Expect(response.Badges[1].ID).To(Equal(int32(2)))
+			Expect(response.Badges[1].Progress.Current).To(Equal(int64(37)))
+		})
+
+		It("CASE: Closest badges are limited by the configured size", func() {
+
+			configs.Basic.ClosestBadgesSize = 1
+
+			response, err := service.GetClosestBadges(userID, nil, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(len(response.Badges)).To(Equal(1))
+			Expect(response.Badges[0].ID).To(Equal(int32(5)))
+		})
+
+	})
+
+	Context("Badge awards", func() {
+
+		var (
+			ctxLogger *log.Entry
+			userID    string
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+
+			userID = "admin"
+		})
+
+		It("CASE: Claim a badge as a manual award", func() {
+
+			mockUserDAO.EXPECT().GetUserWithBadges(userID, ctxLogger).
+				Times(1).
+				Return(&userDAO.User{ID: userID, Badges: []*badgeDAO.Badge{{ID: 1}}}, nil)
+
+			mockBadgeDAO.EXPECT().GetBadge(int16(2), ctxLogger).
+				Times(1).
+				Return(&badgeDAO.Badge{ID: 2, Exp: 100, ParentBadgeID: 1}, nil)
+
+			mockBadgeDAO.EXPECT().AddBadge(gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(award *badgeDAO.UserBadge, _ *log.Entry) error {
+					Expect(award.Source).To(Equal(badgeDAO.AwardSourceManual))
+					Expect(award.ExpGranted).To(Equal(int64(100)))
+					Expect(award.AwardedAt).To(BeTemporally("~", time.Now(), time.Minute))
+					Expect(award.GrantedBy).To(BeNil())
+					return nil
+				})
+
+			Expect(service.AddBadge(userID, 2, ctxLogger)).To(Succeed())
+		})
+
+		It("CASE: Deleting a badge takes back the exp it granted", func() {
+
+			mockUserDAO.EXPECT().GetUserWithBadges(userID, ctxLogger).
+				Times(1).
+				Return(&userDAO.User{ID: userID, Badges: []*badgeDAO.Badge{{ID: 1, Exp: 150}}}, nil)
+
+			mockBadgeDAO.EXPECT().GetBadge(int16(1), ctxLogger).
+				Times(1).
+				Return(&badgeDAO.Badge{ID: 1, Exp: 150}, nil)
+
+			mockBadgeDAO.EXPECT().DeleteBadge(userID, int16(1), ctxLogger).
+				Times(1).
+				Return(&badgeDAO.UserBadge{UserID: userID, BadgeID: 1, ExpGranted: 100}, nil)
+
+			Expect(service.DeleteBadge(userID, 1, ctxLogger)).To(Succeed())
+		})
+
+		It("CASE: Successful get badge timeline", func() {
+
+			configs.Basic.BadgeTimelinePageSize = 2
+
+			awardedAt := time.Date(2024, 3, 1, 18, 30, 0, 0, time.UTC)
+
+			mockBadgeDAO.EXPECT().GetBadgeAwards(userID, int32(2), int32(2), ctxLogger).
+				Times(1).
+				Return([]*badgeDAO.UserBadge{
+					{UserID: userID, BadgeID: 2, AwardedAt: awardedAt, Source: badgeDAO.AwardSourceAuto, ExpGranted: 200},
+					{UserID: userID, BadgeID: 1, AwardedAt: awardedAt.AddDate(0, 0, -1), Source: badgeDAO.AwardSourceManual, ExpGranted: 100},
+				}, nil)
+
+			mockBadgeDAO.EXPECT().GetBadges(ctxLogger).
+				Times(1).
+				Return([]*badgeDAO.Badge{
+					{ID: 1, Name: "Root", Image: "/image-1.jpg", Exp: 100},
+					{ID: 2, Name: "Child", Image: "/image-2.jpg", Exp: 250, ParentBadgeID: 1},
+				}, nil)
+
+			mockBadgeDAO.EXPECT().GetBadgeTranslations(gomock.Any(), ctxLogger).
+				AnyTimes().
+				Return([]*badgeDAO.BadgeTranslation{}, nil)
+
+			response, err := service.GetBadgeTimeline(userID, 2, nil, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response.Awards).To(Equal([]*models.BadgeAward{
+				{BadgeID: 2, Name: "Child", Image: "/image-2.jpg", AwardedAt: strfmt.DateTime(awardedAt), Source: badgeDAO.AwardSourceAuto, ExpGranted: 200},
+				{BadgeID: 1, Name: "Root", Image: "/image-1.jpg", AwardedAt: strfmt.DateTime(awardedAt.AddDate(0, 0, -1)), Source: badgeDAO.AwardSourceManual, ExpGranted: 100},
+			}))
+		})
+
+		It("CASE: Get badge timeline failed cause the user does not exist", func() {
+
+			configs.Basic.BadgeTimelinePageSize = 2
+
+			mockBadgeDAO.EXPECT().GetBadgeAwards(userID, int32(0), int32(2), ctxLogger).
+				Times(1).
+				Return(nil, customErrors.BuildNotFoundError("User not found"))
+
+			mockBadgeDAO.EXPECT().GetBadges(ctxLogger).
+				AnyTimes().
+				Return([]*badgeDAO.Badge{}, nil)
+
+			mockBadgeDAO.EXPECT().GetBadgeTranslations(gomock.Any(), ctxLogger).
+				AnyTimes().
+				Return([]*badgeDAO.BadgeTranslation{}, nil)
+
+			_, err := service.GetBadgeTimeline(userID, 1, nil, ctxLogger)
+			Expect(errors.As(err, &customErrors.NotFound)).To(BeTrue())
+		})
+	})
+
+	Context("Badge claims", func() {
+
+		var (
+			ctxLogger *log.Entry
+			userID    string
+			friendID  string
+			owned     map[int16]bool
+			catalog   map[int16]*badgeDAO.Badge
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+
+			userID = "user"
+			friendID = "friend"
+			owned = map[int16]bool{1: true}
+
+			catalog = map[int16]*badgeDAO.Badge{
+				1: {ID: 1, Name: "Root", Exp: 100},
+				2: {ID: 2, Name: "Squat 150kg for 5 reps", Exp: 500, ParentBadgeID: 1, RequiresProof: true},
+			}
+
+			configs.Basic.ClaimVouches = 2
+			configs.Basic.ClaimProofsDir = GinkgoT().TempDir()
+			configs.Basic.ClaimProofsPath = "/proofs"
+			configs.Basic.ClaimProofMaxSize = 1 << 20
+
+			mockBadgeDAO.EXPECT().GetBadge(gomock.Any(), ctxLogger).
+				AnyTimes().
+				DoAndReturn(func(badgeID int16, _ *log.Entry) (*badgeDAO.Badge, error) {
+					return catalog[badgeID], nil
+				})
+
+			mockUserDAO.EXPECT().GetUserWithBadges(userID, ctxLogger).
+				AnyTimes().
+				DoAndReturn(func(_ string, _ *log.Entry) (*userDAO.User, error) {
+					user := userDAO.User{ID: userID}
+					for badgeID := range owned {
+						user.Badges = append(user.Badges, catalog[badgeID])
+					}
+					return &user, nil
+				})
+
+			mockUserDAO.EXPECT().GetFriendIDs(userID, ctxLogger).
+				AnyTimes().
+				Return([]string{friendID, "witness"}, nil)
+
+			mockUserDAO.EXPECT().GetUser("moderator", ctxLogger).
+				AnyTimes().
+				Return(&userDAO.User{ID: "moderator", Admin: true}, nil)
+		})
+
+		It("CASE: Badges that require proof cannot be marked as achieved", func() {
+
+			err := service.AddBadge(userID, 2, ctxLogger)
+			Expect(errors.As(err, &customErrors.Forbidden)).To(BeTrue())
+		})
+
+		It("CASE: Claim a badge failed cause it has no proof or the witness is not a friend", func() {
+
+			_, err := service.ClaimBadge(userID, 2, nil, nil, ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+
+			mockBadgeDAO.EXPECT().GetBadgeClaims(userID, badgeDAO.ClaimStatusPending, ctxLogger).
+				AnyTimes().
+				Return([]*badgeDAO.BadgeClaim{}, nil)
+
+			_, err = service.ClaimBadge(userID, 2, utils.NewString("stranger"), nil, ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+
+			_, err = service.ClaimBadge(userID, 2, nil, strings.NewReader("not a video"), ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+		})
+
+		It("CASE: Claim a badge with a photo and a witness", func() {
+
+			mockBadgeDAO.EXPECT().GetBadgeClaims(userID, badgeDAO.ClaimStatusPending, ctxLogger).
+				Times(1).
+				Return([]*badgeDAO.BadgeClaim{}, nil)
+
+			mockBadgeDAO.EXPECT().CreateBadgeClaim(gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(claim *badgeDAO.BadgeClaim, _ *log.Entry) error {
+					claim.ID = 7
+					return nil
+				})
+
+			photo := "\xff\xd8\xff\xe0 squat"
+
+			response, err := service.ClaimBadge(userID, 2, utils.NewString("witness"), strings.NewReader(photo), ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response.ID).To(Equal(int64(7)))
+			Expect(response.Status).To(Equal(badgeDAO.ClaimStatusPending))
+			Expect(*response.ProofType).To(Equal(badgeDAO.ProofTypePhoto))
+			Expect(*response.WitnessID).To(Equal("witness"))
+			Expect(*response.Proof).To(HavePrefix("/proofs/"))
+
+			content, err := os.ReadFile(filepath.Join(configs.Basic.ClaimProofsDir, filepath.Base(*response.Proof)))
+			Expect(err).To(BeNil())
+			Expect(string(content)).To(Equal(photo))
+		})
+
+		It("CASE: Claim a badge failed cause it already has a pending claim", func() {
+
+			mockBadgeDAO.EXPECT().GetBadgeClaims(userID, badgeDAO.ClaimStatusPending, ctxLogger).
+				Times(1).
+				Return([]*badgeDAO.BadgeClaim{{ID: 7, UserID: userID, BadgeID: 2}}, nil)
+
+			_, err := service.ClaimBadge(userID, 2, utils.NewString("witness"), nil, ctxLogger)
+			Expect(errors.As(err, &customErrors.Conflict)).To(BeTrue())
+		})
+
+		It("CASE: Enough vouches of friends approve the claim", func() {
+
+			claim := badgeDAO.BadgeClaim{
+				ID:      7,
+				UserID:  userID,
+				BadgeID: 2,
+				Status:  badgeDAO.ClaimStatusPending,
+				Vouches: []badgeDAO.BadgeClaimVouch{{ClaimID: 7, FriendID: "witness"}},
+			}
+
+			mockBadgeDAO.EXPECT().GetBadgeClaim(int64(7), ctxLogger).
+				Times(1).
+				Return(&claim, nil)
+
+			mockBadgeDAO.EXPECT().AddClaimVouch(gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(vouch *badgeDAO.BadgeClaimVouch, _ *log.Entry) error {
+					Expect(vouch.ClaimID).To(Equal(int64(7)))
+					Expect(vouch.FriendID).To(Equal(friendID))
+					return nil
+				})
+
+			// The award and the approval are written together
+			mockBadgeDAO.EXPECT().ApproveBadgeClaim(&claim, gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(claim *badgeDAO.BadgeClaim, award *badgeDAO.UserBadge, _ *log.Entry) error {
+					Expect(claim.Status).To(Equal(badgeDAO.ClaimStatusApproved))
+					Expect(award.BadgeID).To(Equal(int16(2)))
+					Expect(award.Source).To(Equal(badgeDAO.AwardSourceClaim))
+					// The experience is only granted on approval
+					Expect(award.ExpGranted).To(Equal(int64(500)))
+					Expect(award.GrantedBy).To(BeNil())
+					return nil
+				})
+
+			response, err := service.VouchBadgeClaim(friendID, 7, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response.Status).To(Equal(badgeDAO.ClaimStatusApproved))
+			Expect(response.Vouches).To(Equal([]string{"witness", friendID}))
+			Expect(response.ReviewerID).To(BeNil())
+		})
+
+		It("CASE: A vouch reaching the threshold after a concurrent approval succeeds", func() {
+
+			claim := badgeDAO.BadgeClaim{
+				ID:      7,
+				UserID:  userID,
+				BadgeID: 2,
+				Status:  badgeDAO.ClaimStatusPending,
+				Vouches: []badgeDAO.BadgeClaimVouch{{ClaimID: 7, FriendID: "witness"}},
+			}
+
+			approved := badgeDAO.BadgeClaim{
+				ID:      7,
+				UserID:  userID,
+				BadgeID: 2,
+				Status:  badgeDAO.ClaimStatusApproved,
+				Vouches: []badgeDAO.BadgeClaimVouch{{ClaimID: 7, FriendID: "witness"}, {ClaimID: 7, FriendID: friendID}},
+			}
+
+			mockBadgeDAO.EXPECT().GetBadgeClaim(int64(7), ctxLogger).
+				Times(1).
+				Return(&claim, nil)
+
+			// Read again once the approval conflicts
+			mockBadgeDAO.EXPECT().GetBadgeClaim(int64(7), ctxLogger).
+				Times(1).
+				Return(&approved, nil)
+
+			mockBadgeDAO.EXPECT().AddClaimVouch(gomock.Any(), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			mockBadgeDAO.EXPECT().ApproveBadgeClaim(&claim, gomock.Any(), ctxLogger).
+				Times(1).
+				Return(customErrors.BuildConflictError("The claim is not pending anymore."))
+
+			response, err := service.VouchBadgeClaim(friendID, 7, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response.Status).To(Equal(badgeDAO.ClaimStatusApproved))
+			Expect(response.Vouches).To(Equal([]string{"witness", friendID}))
+		})
+
+		It("CASE: Vouch for a claim failed cause the user is not a friend", func() {
+
+			mockBadgeDAO.EXPECT().GetBadgeClaim(int64(7), ctxLogger).
+				Times(1).
+				Return(&badgeDAO.BadgeClaim{ID: 7, UserID: userID, BadgeID: 2, Status: badgeDAO.ClaimStatusPending}, nil)
+
+			_, err := service.VouchBadgeClaim("stranger", 7, ctxLogger)
+			Expect(errors.As(err, &customErrors.Forbidden)).To(BeTrue())
+		})
+
+		It("CASE: Rejecting an approved claim takes back the badge and its experience", func() {
+
+			owned[2] = true
+
+			proof := filepath.Join(configs.Basic.ClaimProofsDir, "proof.mp4")
+			Expect(os.WriteFile(proof, []byte("video"), 0644)).To(Succeed())
+
+			claim := badgeDAO.BadgeClaim{
+				ID:      7,
+				UserID:  userID,
+				BadgeID: 2,
+				Status:  badgeDAO.ClaimStatusApproved,
+				Proof:   utils.NewString("/proofs/proof.mp4"),
+			}
+
+			mockBadgeDAO.EXPECT().GetBadgeClaim(int64(7), ctxLogger).
+				Times(2).
+				Return(&claim, nil)
+
+			mockBadgeDAO.EXPECT().DeleteBadge(userID, int16(2), ctxLogger).
+				Times(1).
+				Return(&badgeDAO.UserBadge{UserID: userID, BadgeID: 2, ExpGranted: 500}, nil)
+
+			mockBadgeDAO.EXPECT().UpdateBadgeClaim(&claim, ctxLogger).
+				Times(1).
+				Return(nil)
+
+			response, err := service.ReviewBadgeClaim("moderator", 7, false, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response.Status).To(Equal(badgeDAO.ClaimStatusRejected))
+			Expect(response.Proof).To(BeNil())
+			Expect(*response.ReviewerID).To(Equal("moderator"))
+			Expect(proof).ToNot(BeAnExistingFile())
+
+			_, err = service.ReviewBadgeClaim("moderator", 7, true, ctxLogger)
+			Expect(errors.As(err, &customErrors.Conflict)).To(BeTrue())
+		})
+	})
+
+	Context("Check strength badges", func() {
+
+		var (
+			ctxLogger  *log.Entry
+			userID     string
+			owned      map[int16]bool
+			catalog    map[int16]*badgeDAO.Badge
+			bodyweight *float32
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+
+			userID = "admin"
+			bodyweight = utils.NewFloat32(80)
+
+			// User already has "Do ten push-ups"
+			owned = map[int16]bool{2: true}
+
+			catalog = map[int16]*badgeDAO.Badge{
+				2: {ID: 2, ParentBadgeID: 1, Exp: 247},
+				7: {ID: 7, ParentBadgeID: 2, Exp: 389,
+					Criteria: &badgeDAO.BadgeCriteria{BadgeID: 7, Exercise: "Bench press", MinWeight: 20, MinReps: 10}},
+				8: {ID: 8, ParentBadgeID: 7, Exp: 570,
+					Criteria: &badgeDAO.BadgeCriteria{BadgeID: 8, Exercise: "Bench press", MinWeight: 30, MinReps: 10}},
+				10: {ID: 10, ParentBadgeID: 9, Exp: 1213,
+					Criteria: &badgeDAO.BadgeCriteria{BadgeID: 10, Exercise: "Bench press", MinWeight: 60, MinReps: 10}},
+				12: {ID: 12, ParentBadgeID: 11, Exp: 3013,
+					Criteria: &badgeDAO.BadgeCriteria{BadgeID: 12, Exercise: "Bench press", MinWeight: 100, MinReps: 5}},
+				55: {ID: 55, ParentBadgeID: 54, Exp: 1000},
+				56: {ID: 56, ParentBadgeID: 55, Exp: 1213,
+					Criteria: &badgeDAO.BadgeCriteria{BadgeID: 56, Exercise: "Squat", MinWeight: 0, MinReps: 5,
+						MinBodyweightRatio: utils.NewFloat32(1.5)}},
+				57: {ID: 57, ParentBadgeID: 55, Exp: 1500, RequiresProof: true,
+					Criteria: &badgeDAO.BadgeCriteria{BadgeID: 57, Exercise: "Deadlift", MinWeight: 250, MinReps: 1}},
+			}
+
+			mockBadgeDAO.EXPECT().GetBadgesWithCriteria(ctxLogger).
+				AnyTimes().
+				Return([]*badgeDAO.Badge{catalog[7], catalog[8], catalog[10], catalog[12], catalog[56], catalog[57]}, nil)
+
+			mockUserDAO.EXPECT().GetUser(userID, ctxLogger).
+				AnyTimes().
+				DoAndReturn(func(_ string, _ *log.Entry) (*userDAO.User, error) {
+					return &userDAO.User{ID: userID, Weight: bodyweight}, nil
+				})
+
+			mockUserDAO.EXPECT().GetUserWithBadges(userID, ctxLogger).
+				AnyTimes().
+				DoAndReturn(func(_ string, _ *log.Entry) (*userDAO.User, error) {
+					user := userDAO.User{ID: userID}
+					for badgeID := range owned {
+						user.Badges = append(user.Badges, catalog[badgeID])
+					}
+					return &user, nil
+				})
+
+			mockBadgeDAO.EXPECT().GetBadge(gomock.Any(), ctxLogger).
+				AnyTimes().
+				DoAndReturn(func(badgeID int16, _ *log.Entry) (*badgeDAO.Badge, error) {
+					return catalog[badgeID], nil
+				})
+
+			mockBadgeDAO.EXPECT().CheckBadge(userID, gomock.Any(), ctxLogger).
+				AnyTimes().
+				DoAndReturn(func(_ string, badgeID int16, _ *log.Entry) (bool, error) {
+					return owned[badgeID], nil
+				})
+
+			mockBadgeDAO.EXPECT().AddBadge(gomock.Any(), ctxLogger).
+				AnyTimes().
+				DoAndReturn(func(award *badgeDAO.UserBadge, _ *log.Entry) error {
+					Expect(award.UserID).To(Equal(userID))
+					Expect(award.Source).To(Equal(badgeDAO.AwardSourceAuto))
+					owned[award.BadgeID] = true
+					return nil
+				})
+		})
+
+		It("CASE: Badges met in the same workout are awarded following the parent rule", func() {
+
+			exercises := []workoutDAO.WorkoutExercise{
+				{
+					Name: "bench press",
+					Sets: []workoutDAO.WorkoutSet{
+						{Reps: 10, Weight: 35},
+						{Reps: 5, Weight: 100}, // Meets badge 12 but its parent is missing
+					},
+				},
+			}
+
+			awarded, err := service.CheckStrengthBadges(userID, exercises, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(awarded).To(Equal([]int16{7, 8}))
+			Expect(owned[12]).To(BeFalse())
+			Expect(owned[10]).To(BeFalse())
+		})
+
+		It("CASE: Bodyweight relative criteria", func() {
+
+			owned[55] = true
+
+			exercises := []workoutDAO.WorkoutExercise{
+				{Name: "Squat", Sets: []workoutDAO.WorkoutSet{{Reps: 5, Weight: 110}}},
+			}
+
+			awarded, err := service.CheckStrengthBadges(userID, exercises, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(awarded).To(BeEmpty())
+
+			exercises[0].Sets[0].Weight = 120
+
+			awarded, err = service.CheckStrengthBadges(userID, exercises, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(awarded).To(Equal([]int16{56}))
+		})
+
+		It("CASE: Bodyweight relative criteria are not met without a logged weight", func() {
+
+			owned[55] = true
+			bodyweight = nil
+
+			exercises := []workoutDAO.WorkoutExercise{
+				{Name: "Squat", Sets: []workoutDAO.WorkoutSet{{Reps: 5, Weight: 200}}},
+			}
+
+			awarded, err := service.CheckStrengthBadges(userID, exercises, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(awarded).To(BeEmpty())
+		})
+
+		It("CASE: Badges that require proof open a claim instead of being awarded", func() {
+
+			owned[55] = true
+
+			mockBadgeDAO.EXPECT().GetBadgeClaims(userID, badgeDAO.ClaimStatusPending, ctxLogger).
+				Times(1).
+				Return([]*badgeDAO.BadgeClaim{}, nil)
+
+			mockBadgeDAO.EXPECT().CreateBadgeClaim(gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(claim *badgeDAO.BadgeClaim, _ *log.Entry) error {
+					Expect(claim.UserID).To(Equal(userID))
+					Expect(claim.BadgeID).To(Equal(int16(57)))
+					Expect(claim.Status).To(Equal(badgeDAO.ClaimStatusPending))
+					Expect(claim.Proof).To(BeNil())
+					return nil
+				})
+
+			exercises := []workoutDAO.WorkoutExercise{
+				{Name: "Deadlift", Sets: []workoutDAO.WorkoutSet{{Reps: 1, Weight: 260}}},
+			}
+
+			awarded, err := service.CheckStrengthBadges(userID, exercises, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(awarded).To(BeEmpty())
+			Expect(owned[57]).To(BeFalse())
+		})
+
+		It("CASE: Badges that require proof are not claimed twice", func() {
+
+			owned[55] = true
+
+			mockBadgeDAO.EXPECT().GetBadgeClaims(userID, badgeDAO.ClaimStatusPending, ctxLogger).
+				Times(1).
+				Return([]*badgeDAO.BadgeClaim{{ID: 3, UserID: userID, BadgeID: 57}}, nil)
+
+			exercises := []workoutDAO.WorkoutExercise{
+				{Name: "Deadlift", Sets: []workoutDAO.WorkoutSet{{Reps: 1, Weight: 260}}},
+			}
+
+			awarded, err := service.CheckStrengthBadges(userID, exercises, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(awarded).To(BeEmpty())
+		})
+
+	})
+
+	Context("Check auto badges", func() {
+
+		var (
+			ctxLogger   *log.Entry
+			userID      string
+			owned       map[int16]bool
+			catalog     map[int16]*badgeDAO.Badge
+			rules       []*badgeDAO.BadgeRule
+			streak      int32
+			friends     int32
+			friendsRank int64
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+
+			userID = "admin"
+			owned = map[int16]bool{-6: true, -7: true}
+			streak = 4
+			friends = 10
+			friendsRank = 1
+
+			catalog = map[int16]*badgeDAO.Badge{
+				66: {ID: 66, ParentBadgeID: -6, Exp: 125},
+				67: {ID: 67, ParentBadgeID: 66, Exp: 247},
+				68: {ID: 68, ParentBadgeID: 67, Exp: 389},
+				87: {ID: 87, ParentBadgeID: -7, Exp: 247},
+				93: {ID: 93, ParentBadgeID: 87, Exp: 823},
+				94: {ID: 94, ParentBadgeID: 87, Exp: 389},
+			}
+
+			rules = []*badgeDAO.BadgeRule{
+				{BadgeID: 66, BadgeRuleClause: badgeDAO.BadgeRuleClause{Metric: badgeDAO.MetricStreak, Comparator: badgeDAO.ComparatorGTE, Threshold: 1}},
+				{BadgeID: 67, BadgeRuleClause: badgeDAO.BadgeRuleClause{Metric: badgeDAO.MetricStreak, Comparator: badgeDAO.ComparatorGTE, Threshold: 4}},
+				{BadgeID: 68, BadgeRuleClause: badgeDAO.BadgeRuleClause{Metric: badgeDAO.MetricStreak, Comparator: badgeDAO.ComparatorGTE, Threshold: 10}},
+				{BadgeID: 87, BadgeRuleClause: badgeDAO.BadgeRuleClause{Metric: badgeDAO.MetricFriendsCount, Comparator: badgeDAO.ComparatorGTE, Threshold: 5}},
+				{
+					BadgeID:         93,
+					BadgeRuleClause: badgeDAO.BadgeRuleClause{Metric: badgeDAO.MetricFriendsRank, Comparator: badgeDAO.ComparatorLTE, Threshold: 1},
+					Conditions: []badgeDAO.BadgeRuleCondition{
+						{BadgeID: 93, BadgeRuleClause: badgeDAO.BadgeRuleClause{Metric: badgeDAO.MetricFriendsCount, Comparator: badgeDAO.ComparatorGTE, Threshold: 10}},
+					},
+				},
+			}
+
+			mockBadgeDAO.EXPECT().GetBadgeRules(ctxLogger).
+				AnyTimes().
+				DoAndReturn(func(_ *log.Entry) ([]*badgeDAO.BadgeRule, error) {
+					return rules, nil
+				})
+
+			mockUserDAO.EXPECT().GetUser(userID, ctxLogger).
+				AnyTimes().
+				DoAndReturn(func(_ string, _ *log.Entry) (*userDAO.User, error) {
+					return &userDAO.User{ID: userID, Streak: streak}, nil
+				})
+
+			mockUserDAO.EXPECT().GetFriendsCount(userID, ctxLogger).
+				AnyTimes().
+				DoAndReturn(func(_ string, _ *log.Entry) (int32, error) {
+					return friends, nil
+				})
+
+			mockUserDAO.EXPECT().GetUserWithFriendsRank(userID, ctxLogger).
+				AnyTimes().
+				DoAndReturn(func(_ string, _ *log.Entry) (*userDAO.User, int64, error) {
+					return &userDAO.User{ID: userID}, friendsRank, nil
+				})
+
+			mockUserDAO.EXPECT().GetUserWithBadges(userID, ctxLogger).
+				AnyTimes().
+				DoAndReturn(func(_ string, _ *log.Entry) (*userDAO.User, error) {
+					user := userDAO.User{ID: userID}
+					for badgeID := range owned {
+						user.Badges = append(user.Badges, &badgeDAO.Badge{ID: badgeID})
+					}
+					return &user, nil
+				})
+
+			mockBadgeDAO.EXPECT().GetBadge(gomock.Any(), ctxLogger).
+				AnyTimes().
+				DoAndReturn(func(badgeID int16, _ *log.Entry) (*badgeDAO.Badge, error) {
+					return catalog[badgeID], nil
+				})
+
+			mockBadgeDAO.EXPECT().CheckBadge(userID, gomock.Any(), ctxLogger).
+				AnyTimes().
+				DoAndReturn(func(_ string, badgeID int16, _ *log.Entry) (bool, error) {
+					return owned[badgeID], nil
+				})
+
+			mockBadgeDAO.EXPECT().AddBadge(gomock.Any(), ctxLogger).
+				AnyTimes().
+				DoAndReturn(func(award *badgeDAO.UserBadge, _ *log.Entry) error {
+					Expect(award.UserID).To(Equal(userID))
+					Expect(award.Source).To(Equal(badgeDAO.AwardSourceAuto))
+					owned[award.BadgeID] = true
+					return nil
+				})
+		})
+
+		It("CASE: Rules met are awarded following the parent rule", func() {
+
+			Expect(service.CheckAutoBadges(userID, ctxLogger)).To(Succeed())
+			Expect(owned[66]).To(BeTrue())
+			Expect(owned[67]).To(BeTrue())
+			Expect(owned[68]).To(BeFalse())
+			Expect(owned[87]).To(BeTrue())
+			Expect(owned[93]).To(BeTrue())
+		})
+
+		It("CASE: Every condition of a rule has to be met", func() {
+
+			friends = 5
+
+			Expect(service.CheckAutoBadges(userID, ctxLogger)).To(Succeed())
+			Expect(owned[87]).To(BeTrue())
+			Expect(owned[93]).To(BeFalse())
+		})
+
+		It("CASE: Rules with unknown metrics or comparators are never met", func() {
+
+			rules = []*badgeDAO.BadgeRule{
+				{BadgeID: 66, BadgeRuleClause: badgeDAO.BadgeRuleClause{Metric: "steps", Comparator: badgeDAO.ComparatorGTE, Threshold: 0}},
+				{BadgeID: 87, BadgeRuleClause: badgeDAO.BadgeRuleClause{Metric: badgeDAO.MetricFriendsCount, Comparator: "about", Threshold: 0}},
+			}
+
+			Expect(service.CheckAutoBadges(userID, ctxLogger)).To(Succeed())
+			Expect(owned[66]).To(BeFalse())
+			Expect(owned[87]).To(BeFalse())
+		})
+
+		It("CASE: Check auto badges failed cause a metric cannot be read", func() {
+
+			rules = append(rules, &badgeDAO.BadgeRule{BadgeID: 72,
+				BadgeRuleClause: badgeDAO.BadgeRuleClause{Metric: badgeDAO.MetricAttendances, Comparator: badgeDAO.ComparatorGTE, Threshold: 100}})
+
+			mockUserDAO.EXPECT().GetAttendanceCount(userID, ctxLogger).
+				Times(1).
+				Return(int32(0), errors.New("database error"))
+
+			Expect(service.CheckAutoBadges(userID, ctxLogger)).ToNot(Succeed())
+			Expect(owned).To(HaveLen(2))
+		})
+
+		It("CASE: Global rank badges are checked for the users at the top of the global ranking", func() {
+
+			rules = append(rules, &badgeDAO.BadgeRule{BadgeID: 94,
+				BadgeRuleClause: badgeDAO.BadgeRuleClause{Metric: badgeDAO.MetricGlobalRank, Comparator: badgeDAO.ComparatorLT, Threshold: 4}})
+
+			mockUserDAO.EXPECT().GetUsersOrderedByExp(int64(0), int32(3), ctxLogger).
+				Times(1).
+				Return([]*userDAO.User{{ID: userID}}, nil)
+
+			// The user moved up when other users lost experience
+			mockUserDAO.EXPECT().GetUserWithGlobalRank(userID, ctxLogger).
+				Times(1).
+				Return(&userDAO.User{ID: userID}, int64(3), nil)
+
+			Expect(service.CheckGlobalRankBadges(ctxLogger)).To(Succeed())
+			Expect(owned[94]).To(BeTrue())
+		})
+
+		It("CASE: No user is checked without global rank rules", func() {
+
+			mockUserDAO.EXPECT().GetUsersOrderedByExp(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			Expect(service.CheckGlobalRankBadges(ctxLogger)).To(Succeed())
+			Expect(owned).To(HaveLen(2))
+		})
+
+	})
+
+	// The unique award and its exp grant are guaranteed by the DAO transaction. These cases only cover how the
+	// service handles the Conflict the DAO returns to the awards that lose the race
+	Context("Concurrent award conflicts", func() {
+
+		var (
+			ctxLogger *log.Entry
+			userID    string
+			mutex     sync.Mutex
+			owned     map[int16]bool
+			workers   int
+		)
+
+		// award runs the function in every worker at the same time and waits for all of them
+		award := func(function func() error) []error {
+
+			errs := make([]error, workers)
+
+			var wg sync.WaitGroup
+			for i := range workers {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					errs[i] = function()
+				}()
+			}
+			wg.Wait()
+
+			return errs
+		}
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+
+			userID = "admin"
+			owned = make(map[int16]bool)
+			workers = 10
+
+			mockBadgeDAO.EXPECT().GetBadgeRules(ctxLogger).
+				AnyTimes().
+				Return([]*badgeDAO.BadgeRule{
+					{BadgeID: 66, BadgeRuleClause: badgeDAO.BadgeRuleClause{Metric: badgeDAO.MetricStreak, Comparator: badgeDAO.ComparatorGTE, Threshold: 1}},
+				}, nil)
+
+			mockUserDAO.EXPECT().GetUser(userID, ctxLogger).
+				AnyTimes().
+				Return(&userDAO.User{ID: userID, Streak: 4}, nil)
+
+			mockUserDAO.EXPECT().GetUserWithBadges(userID, ctxLogger).
+				AnyTimes().
+				Return(&userDAO.User{ID: userID, Badges: []*badgeDAO.Badge{{ID: -6}}}, nil)
+
+			mockBadgeDAO.EXPECT().GetBadge(int16(66), ctxLogger).
+				AnyTimes().
+				Return(&badgeDAO.Badge{ID: 66, ParentBadgeID: -6, Exp: 125}, nil)
+
+			// Every worker checks before any award is stored
+			mockBadgeDAO.EXPECT().CheckBadge(userID, int16(66), ctxLogger).
+				AnyTimes().
+				Return(false, nil)
+
+			// Follows the contract of the DAO: Conflict once the user has the badge
+			mockBadgeDAO.EXPECT().AddBadge(gomock.Any(), ctxLogger).
+				AnyTimes().
+				DoAndReturn(func(award *badgeDAO.UserBadge, _ *log.Entry) error {
+					mutex.Lock()
+					defer mutex.Unlock()
+
+					if owned[award.BadgeID] {
+						return customErrors.BuildConflictError("Badge %d already achieved.", award.BadgeID)
+					}
+					owned[award.BadgeID] = true
+					return nil
+				})
+		})
+
+		It("CASE: Auto checks losing the race skip the badge without failing", func() {
+
+			for _, err := range award(func() error { return service.CheckAutoBadges(userID, ctxLogger) }) {
+				Expect(err).To(BeNil())
+			}
+
+			Expect(owned[66]).To(BeTrue())
+		})
+
+		It("CASE: Manual awards losing the race return the Conflict", func() {
+
+			errs := award(func() error { return service.AddBadge(userID, 66, ctxLogger) })
+
+			succeeded := 0
+			for _, err := range errs {
+				if err == nil {
+					succeeded++
+					continue
+				}
+				Expect(errors.As(err, &customErrors.Conflict)).To(BeTrue())
+			}
+
+			Expect(succeeded).To(Equal(1))
+		})
+
+	})
+
+	Context("Catalog administration", func() {
+
+		var (
+			ctxLogger *log.Entry
+			adminID   string
+			admin     bool
+			catalog   []*badgeDAO.Badge
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+
+			adminID = "admin"
+			admin = true
+
+			retiredAt := time.Now().AddDate(0, -1, 0)
+
+			catalog = []*badgeDAO.Badge{
+				{ID: 1, Name: "Root", Exp: 100},
+				{ID: 2, Name: "Child", Exp: 200, ParentBadgeID: 1, Position: 0},
+				{ID: 3, Name: "Second child", Exp: 300, ParentBadgeID: 1, Position: 1},
+				{ID: 4, Name: "Grandchild", Exp: 400, ParentBadgeID: 2},
+				{ID: 5, Name: "Old", Exp: 500, ParentBadgeID: 1, Position: 2, RetiredAt: &retiredAt},
+			}
+
+			mockUserDAO.EXPECT().GetUser(adminID, ctxLogger).
+				AnyTimes().
+				DoAndReturn(func(_ string, _ *log.Entry) (*userDAO.User, error) {
+					return &userDAO.User{ID: adminID, Admin: admin}, nil
+				})
+
+			mockBadgeDAO.EXPECT().GetBadges(ctxLogger).
+				AnyTimes().
+				Return(catalog, nil)
+		})
+
+		It("CASE: Only admins can change the catalog", func() {
+
+			admin = false
+
+			_, err := service.CreateBadge(adminID, &models.CreateBadgeRequest{Name: "New"}, ctxLogger)
+			Expect(errors.As(err, &customErrors.Forbidden)).To(BeTrue())
+
+			err = service.RetireBadge(adminID, 4, ctxLogger)
+			Expect(errors.As(err, &customErrors.Forbidden)).To(BeTrue())
+		})
+
+		It("CASE: Create a badge after its siblings", func() {
+
+			var created badgeDAO.Badge
+			mockBadgeDAO.EXPECT().CreateBadge(gomock.Any(), gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(badge *badgeDAO.Badge, audit badgeDAO.BadgeAudit, _ *log.Entry) error {
+					Expect(audit.Action).To(Equal(badgeDAO.BadgeActionCreate))
+					Expect(audit.AdminID).To(Equal(adminID))
+					badge.ID = 6
+					created = *badge
+					return nil
+				})
+
+			response, err := service.CreateBadge(adminID, &models.CreateBadgeRequest{
+				Name:          " New ",
+				Exp:           250,
+				ParentBadgeID: utils.NewInt32(1),
+			}, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(created.Name).To(Equal("New"))
+			Expect(created.ParentBadgeID).To(Equal(int16(1)))
+			Expect(created.Position).To(Equal(int16(3)))
+			Expect(response.ID).To(Equal(int32(6)))
+			Expect(*response.ParentBadgeID).To(Equal(int32(1)))
+		})
+
+		It("CASE: Create a badge failed cause the parent is missing or retired", func() {
+
+			_, err := service.CreateBadge(adminID, &models.CreateBadgeRequest{
+				Name:          "New",
+				ParentBadgeID: utils.NewInt32(99),
+			}, ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+
+			_, err = service.CreateBadge(adminID, &models.CreateBadgeRequest{
+				Name:          "New",
+				ParentBadgeID: utils.NewInt32(5),
+			}, ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+		})
+
+		It("CASE: Create a badge failed cause the ids are out of range", func() {
+
+			mockBadgeDAO.EXPECT().CreateBadge(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			_, err := service.CreateBadge(adminID, &models.CreateBadgeRequest{ID: 40000, Name: "New"}, ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+
+			// It would be badge 1 once cut to 16 bits
+			_, err = service.CreateBadge(adminID, &models.CreateBadgeRequest{Name: "New", ParentBadgeID: utils.NewInt32(65537)}, ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+		})
+
+		It("CASE: Create a badge failed cause the id is taken", func() {
+
+			_, err := service.CreateBadge(adminID, &models.CreateBadgeRequest{ID: 2, Name: "New"}, ctxLogger)
+			Expect(errors.As(err, &customErrors.Conflict)).To(BeTrue())
+		})
+
+		It("CASE: Edit the exp of a badge", func() {
+
+			mockBadgeDAO.EXPECT().GetBadge(int16(2), ctxLogger).
+				Times(1).
+				Return(catalog[1], nil)
+
+			mockBadgeDAO.EXPECT().UpdateBadges(gomock.Any(), gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(badges []*badgeDAO.Badge, audits []badgeDAO.BadgeAudit, _ *log.Entry) ([]string, error) {
+					Expect(badges).To(HaveLen(1))
+					Expect(badges[0].Exp).To(Equal(int64(150)))
+					Expect(audits[0].Action).To(Equal(badgeDAO.BadgeActionEdit))
+					Expect(audits[0].Changes).To(Equal("exp: 200 -> 150"))
+					return []string{"user", "friend"}, nil
+				})
+
+			// The holders of the badge have other experience now. Own mock, the shared one accepts any event
+			events := mockService.NewMockIEventsService(mockCtrl)
+			events.EXPECT().Publish(eventsService.Event{Type: eventsService.ExperienceChanged, UserID: "user"}, ctxLogger).
+				Times(1)
+			events.EXPECT().Publish(eventsService.Event{Type: eventsService.ExperienceChanged, UserID: "friend"}, ctxLogger).
+				Times(1)
+
+			response, err := NewBadgeService(mockUserDAO, mockBadgeDAO, events).EditBadge(adminID, 2, &models.EditBadgeRequest{Exp: utils.NewInt64(150)}, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response.Name).To(Equal("Child"))
+			Expect(response.Exp).To(Equal(int64(150)))
+		})
+
+		It("CASE: Reparent a badge failed cause it would be below itself", func() {
+
+			_, err := service.ReparentBadge(adminID, 2, 4, ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+
+			_, err = service.ReparentBadge(adminID, 99, 1, ctxLogger)
+			Expect(errors.As(err, &customErrors.NotFound)).To(BeTrue())
+		})
+
+		It("CASE: Retire a badge failed cause a badge depends on it", func() {
+
+			err := service.RetireBadge(adminID, 2, ctxLogger)
+			Expect(errors.As(err, &customErrors.Conflict)).To(BeTrue())
+
+			err = service.RetireBadge(adminID, 5, ctxLogger)
+			Expect(errors.As(err, &customErrors.Conflict)).To(BeTrue())
+		})
+
+		It("CASE: Retire a badge without active children", func() {
+
+			mockBadgeDAO.EXPECT().UpdateBadges(gomock.Any(), gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(badges []*badgeDAO.Badge, audits []badgeDAO.BadgeAudit, _ *log.Entry) ([]string, error) {
+					Expect(badges[0].ID).To(Equal(int16(4)))
+					Expect(badges[0].RetiredAt).ToNot(BeNil())
+					Expect(audits[0].Action).To(Equal(badgeDAO.BadgeActionRetire))
+					return []string{}, nil
+				})
+
+			Expect(service.RetireBadge(adminID, 4, ctxLogger)).To(Succeed())
+		})
+
+		It("CASE: Reorder the children of a badge", func() {
+
+			err := service.ReorderBadges(adminID, 1, []int16{3, 2}, ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+
+			err = service.ReorderBadges(adminID, 1, []int16{3, 2, 4, 5}, ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+
+			mockBadgeDAO.EXPECT().UpdateBadges(gomock.Any(), gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(badges []*badgeDAO.Badge, audits []badgeDAO.BadgeAudit, _ *log.Entry) ([]string, error) {
+					// Badge 5 keeps its position
+					Expect(badges).To(HaveLen(2))
+					Expect(audits).To(HaveLen(2))
+					return []string{}, nil
+				})
+
+			Expect(service.ReorderBadges(adminID, 1, []int16{3, 2, 5}, ctxLogger)).To(Succeed())
+			Expect(catalog[2].Position).To(Equal(int16(0)))
+			Expect(catalog[1].Position).To(Equal(int16(1)))
+		})
+
+		It("CASE: Grant a badge to a user", func() {
+
+			mockBadgeDAO.EXPECT().CheckBadge("user", int16(2), ctxLogger).
+				Times(1).
+				Return(false, nil)
+
+			mockUserDAO.EXPECT().GetUserWithBadges("user", ctxLogger).
+				Times(1).
+				Return(&userDAO.User{ID: "user", Badges: []*badgeDAO.Badge{catalog[0]}}, nil)
+
+			mockBadgeDAO.EXPECT().GetBadge(int16(2), ctxLogger).
+				Times(1).
+				Return(catalog[1], nil)
+
+			mockBadgeDAO.EXPECT().AddBadge(gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(award *badgeDAO.UserBadge, _ *log.Entry) error {
+					Expect(award.UserID).To(Equal("user"))
+					Expect(award.BadgeID).To(Equal(int16(2)))
+					Expect(award.Source).To(Equal(badgeDAO.AwardSourceAdmin))
+					Expect(award.ExpGranted).To(Equal(int64(200)))
+					Expect(award.GrantedBy).To(Equal(&adminID))
+					return nil
+				})
+
+			Expect(service.GrantBadge(adminID, 2, "user", ctxLogger)).To(Succeed())
+		})
+
+		It("CASE: Grant a badge failed cause the user already has it", func() {
+
+			mockBadgeDAO.EXPECT().CheckBadge("user", int16(2), ctxLogger).
+				Times(1).
+				Return(true, nil)
+
+			err := service.GrantBadge(adminID, 2, "user", ctxLogger)
+			Expect(errors.As(err, &customErrors.Conflict)).To(BeTrue())
+		})
+
+		It("CASE: Upload the image of a badge", func() {
+
+			configs.Basic.BadgeImagesDir = GinkgoT().TempDir()
+			configs.Basic.BadgeImagesPath = "/image/badge"
+
+			mockBadgeDAO.EXPECT().GetBadge(int16(2), ctxLogger).
+				AnyTimes().
+				Return(catalog[1], nil)
+
+			_, err := service.UploadBadgeImage(adminID, 2, strings.NewReader("not an image"), ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+
+			// The scripts of an SVG would run from the API origin
+			_, err = service.UploadBadgeImage(adminID, 2, strings.NewReader(`<svg onload="alert(1)"></svg>`), ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+
+			mockBadgeDAO.EXPECT().UpdateBadges(gomock.Any(), gomock.Any(), ctxLogger).
+				Times(1).
+				Return([]string{}, nil)
+
+			png := "\x89PNG\r\n\x1a\n" + strings.Repeat("\x00", 16)
+
+			response, err := service.UploadBadgeImage(adminID, 2, strings.NewReader(png), ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response.Image).To(HavePrefix("/image/badge/2.png?v="))
+			Expect(filepath.Join(configs.Basic.BadgeImagesDir, "2.png")).To(BeAnExistingFile())
+		})
+
+		It("CASE: Set the rule of a badge", func() {
+
+			mockBadgeDAO.EXPECT().GetBadge(int16(93), ctxLogger).
+				AnyTimes().
+				Return(&badgeDAO.Badge{ID: 93}, nil)
+
+			_, err := service.SetBadgeRule(adminID, 93, &models.BadgeRule{Metric: "steps", Comparator: "gte"}, ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+
+			// Only the sessions between metric has a window
+			_, err = service.SetBadgeRule(adminID, 93, &models.BadgeRule{
+				Metric:     badgeDAO.MetricFriendsRank,
+				Comparator: badgeDAO.ComparatorLTE,
+				Threshold:  1,
+				FromMinute: utils.NewInt32(0),
+				ToMinute:   utils.NewInt32(420),
+			}, ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+
+			mockBadgeDAO.EXPECT().SaveBadgeRule(gomock.Any(), gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(rule *badgeDAO.BadgeRule, audit badgeDAO.BadgeAudit, _ *log.Entry) error {
+					Expect(rule.BadgeID).To(Equal(int16(93)))
+					Expect(rule.Conditions).To(HaveLen(1))
+					Expect(audit.Action).To(Equal(badgeDAO.BadgeActionRule))
+					Expect(audit.Changes).To(Equal("rule: friends_rank lte 1 and friends_count gte 10"))
+					return nil
+				})
+
+			response, err := service.SetBadgeRule(adminID, 93, &models.BadgeRule{
+				Metric:     badgeDAO.MetricFriendsRank,
+				Comparator: badgeDAO.ComparatorLTE,
+				Threshold:  1,
+				Conditions: []*models.BadgeRuleClause{
+					{Metric: badgeDAO.MetricFriendsCount, Comparator: badgeDAO.ComparatorGTE, Threshold: 10},
+				},
+			}, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response.Conditions).To(HaveLen(1))
+		})
+
+	})
+
+	Context("Badge translations", func() {
+
+		var (
+			ctxLogger *log.Entry
+			adminID   string
+			admin     bool
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+
+			adminID = "admin"
+			admin = true
+
+			configs.Basic.Locales = []string{"en", "es"}
+			configs.Basic.DefaultLocale = "en"
+
+			mockUserDAO.EXPECT().GetUser(adminID, ctxLogger).
+				AnyTimes().
+				DoAndReturn(func(_ string, _ *log.Entry) (*userDAO.User, error) {
+					return &userDAO.User{ID: adminID, Admin: admin}, nil
+				})
+		})
+
+		It("CASE: The preferred supported locale is negotiated", func() {
+
+			Expect(negotiateLocale(nil)).To(Equal("en"))
+			Expect(negotiateLocale(utils.NewString(""))).To(Equal("en"))
+			Expect(negotiateLocale(utils.NewString("fr-FR"))).To(Equal("en"))
+			Expect(negotiateLocale(utils.NewString("ES"))).To(Equal("es"))
+			Expect(negotiateLocale(utils.NewString("fr-FR, es;q=0.5"))).To(Equal("es"))
+			Expect(negotiateLocale(utils.NewString("en;q=0.4, es-MX;q=0.8"))).To(Equal("es"))
+			Expect(negotiateLocale(utils.NewString("es-AR,es;q=0.9,en;q=0.8"))).To(Equal("es"))
+			Expect(negotiateLocale(utils.NewString("es;q=high"))).To(Equal("en"))
+		})
+
+		It("CASE: Badges are translated with the English fallback", func() {
+
+			mockUserDAO.EXPECT().GetUserWithBadges(adminID, ctxLogger).
+				Times(1).
+				Return(&userDAO.User{ID: adminID}, nil)
+
+			mockBadgeDAO.EXPECT().GetBadges(ctxLogger).
+				Times(1).
+				Return([]*badgeDAO.Badge{
+					{ID: -1, Name: "chest"},
+					{ID: 1, Name: "Do five push-ups", Description: "Five in a row", ParentBadgeID: -1},
+					{ID: 2, Name: "Do ten push-ups", ParentBadgeID: 1},
+				}, nil)
+
+			mockBadgeDAO.EXPECT().GetBadgeRules(ctxLogger).
+				Times(1).
+				Return([]*badgeDAO.BadgeRule{}, nil)
+
+			mockBadgeDAO.EXPECT().GetBadgeStats(ctxLogger).
+				Times(1).
+				Return([]*badgeDAO.BadgeStat{}, nil)
+
+			mockBadgeDAO.EXPECT().GetBadgeTranslations([]string{"en", "es"}, ctxLogger).
+				Times(1).
+				Return([]*badgeDAO.BadgeTranslation{
+					{BadgeID: -1, Locale: "es", Name: "Pecho"},
+					{BadgeID: -1, Locale: "en", Name: "Chest", Description: "Chest exercises"},
+					{BadgeID: 1, Locale: "es", Name: "Haz cinco flexiones"},
+				}, nil)
+
+			response, err := service.GetBadgesByUserID(adminID, utils.NewString("es-ES,es;q=0.9"), ctxLogger)
+			Expect(err).To(BeNil())
+
+			Expect(response[0].Name).To(Equal("Pecho"))
+			Expect(response[0].Description).To(Equal("Chest exercises"))
+			Expect(response[0].Children[0].Name).To(Equal("Haz cinco flexiones"))
+			Expect(response[0].Children[0].Description).To(Equal("Five in a row"))
+			Expect(response[0].Children[0].Children[0].Name).To(Equal("Do ten push-ups"))
+		})
+
+		It("CASE: Successful set badge translation", func() {
+
+			mockBadgeDAO.EXPECT().GetBadge(int16(1), ctxLogger).
+				Times(1).
+				Return(&badgeDAO.Badge{ID: 1, Name: "Do five push-ups"}, nil)
+
+			mockBadgeDAO.EXPECT().SaveBadgeTranslation(gomock.Any(), gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(translation *badgeDAO.BadgeTranslation, audit badgeDAO.BadgeAudit, _ *log.Entry) error {
+					Expect(*translation).To(Equal(badgeDAO.BadgeTranslation{BadgeID: 1, Locale: "es", Name: "Haz cinco flexiones"}))
+					Expect(audit.Action).To(Equal(badgeDAO.BadgeActionTranslate))
+					Expect(audit.AdminID).To(Equal(adminID))
+					return nil
+				})
+
+			response, err := service.SetBadgeTranslation(adminID, 1, "ES", &models.BadgeTranslationRequest{
+				Name: utils.NewString(" Haz cinco flexiones "),
+			}, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response).To(Equal(&models.BadgeTranslation{Locale: "es", Name: "Haz cinco flexiones"}))
+		})
+
+		It("CASE: Set badge translation failed cause the locale is not supported", func() {
+
+			_, err := service.SetBadgeTranslation(adminID, 1, "fr", &models.BadgeTranslationRequest{
+				Name: utils.NewString("Faire cinq pompes"),
+			}, ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+		})
+
+		It("CASE: Set badge translation failed cause the name is empty", func() {
+
+			_, err := service.SetBadgeTranslation(adminID, 1, "es", &models.BadgeTranslationRequest{
+				Name: utils.NewString("  "),
+			}, ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+		})
+
+		It("CASE: Set badge translation failed cause the user is not an admin", func() {
+
+			admin = false
+
+			_, err := service.SetBadgeTranslation(adminID, 1, "es", &models.BadgeTranslationRequest{
+				Name: utils.NewString("Haz cinco flexiones"),
+			}, ctxLogger)
+			Expect(errors.As(err, &customErrors.Forbidden)).To(BeTrue())
+		})
+
+		It("CASE: Successful delete badge translation", func() {
+
+			mockBadgeDAO.EXPECT().DeleteBadgeTranslation(int16(1), "es", gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(_ int16, _ string, audit badgeDAO.BadgeAudit, _ *log.Entry) error {
+					Expect(audit.Changes).To(Equal("es translation removed"))
+					return nil
+				})
+
+			Expect(service.DeleteBadgeTranslation(adminID, 1, "es", ctxLogger)).To(Succeed())
+		})
+
 	})
 
 })
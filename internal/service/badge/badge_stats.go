@@ -0,0 +1,98 @@
+package badge_service
+
+import (
+	"cmp"
+	configs "gym-badges-api/config/gym-badges-server"
+	badgeDAO "gym-badges-api/internal/repository/badge"
+	"gym-badges-api/models"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	log "github.com/sirupsen/logrus"
+)
+
+// *******************************************************************
+// STATISTICS
+// *******************************************************************
+
+func (s badgesService) RefreshBadgeStats(ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("BADGES_SERVICE: Processing RefreshBadgeStats")
+
+	activeSince := time.Now().AddDate(0, 0, -configs.Basic.ActiveUserDays)
+
+	return s.badgeDAO.RefreshBadgeStats(activeSince, ctxLog)
+}
+
+func (s badgesService) GetBadgeStats(ctxLog *log.Entry) (*models.BadgeStatsResponse, error) {
+
+	ctxLog.Debugf("BADGES_SERVICE: Processing GetBadgeStats")
+
+	stats, err := s.badgeDAO.GetBadgeStats(ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	response := models.BadgeStatsResponse{
+		Badges: make([]*models.BadgeStats, 0, len(stats)),
+	}
+
+	for _, stat := range stats {
+		response.ActiveUsers = stat.ActiveUsers
+		refreshedAt := strfmt.DateTime(stat.RefreshedAt)
+		response.RefreshedAt = &refreshedAt
+		response.Badges = append(response.Badges, mapBadgeStats(stat))
+	}
+
+	// Rarest first
+	slices.SortStableFunc(response.Badges, func(a, b *models.BadgeStats) int {
+		return cmp.Or(cmp.Compare(a.ActivePercentage, b.ActivePercentage), cmp.Compare(a.Holders, b.Holders))
+	})
+
+	return &response, nil
+}
+
+// getBadgeStats returns the statistics of each badge
+func (s badgesService) getBadgeStats(ctxLog *log.Entry) (map[int16]*badgeDAO.BadgeStat, error) {
+
+	stats, err := s.badgeDAO.GetBadgeStats(ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	statsMap := make(map[int16]*badgeDAO.BadgeStat, len(stats))
+	for _, stat := range stats {
+		statsMap[stat.BadgeID] = stat
+	}
+
+	return statsMap, nil
+}
+
+func mapBadgeStats(stat *badgeDAO.BadgeStat) *models.BadgeStats {
+
+	if stat == nil {
+		return nil
+	}
+
+	response := models.BadgeStats{
+		BadgeID:         int32(stat.BadgeID),
+		Holders:         stat.Holders,
+		FirstAchieverID: stat.FirstAchieverID,
+	}
+
+	if stat.ActiveUsers > 0 {
+		response.ActivePercentage = math.Round(float64(stat.ActiveHolders)*10000/float64(stat.ActiveUsers)) / 100
+	}
+	if stat.FirstAchievedAt != nil {
+		firstAchievedAt := strfmt.DateTime(*stat.FirstAchievedAt)
+		response.FirstAchievedAt = &firstAchievedAt
+	}
+	if stat.AvgDaysToEarn != nil {
+		avgDays := math.Round(*stat.AvgDaysToEarn*10) / 10
+		response.AvgDaysToEarn = &avgDays
+	}
+
+	return &response
+}
@@ -0,0 +1,209 @@
+package badge_service
+
+import (
+	"fmt"
+	configs "gym-badges-api/config/gym-badges-server"
+	customErrors "gym-badges-api/internal/custom-errors"
+	badgeDAO "gym-badges-api/internal/repository/badge"
+	"gym-badges-api/models"
+	"slices"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// *******************************************************************
+// TRANSLATIONS
+// *******************************************************************
+
+func (s badgesService) GetBadgeTranslations(adminID string, badgeID int16, ctxLog *log.Entry) (*models.BadgeTranslationsResponse, error) {
+
+	ctxLog.Debugf("BADGES_SERVICE: Processing GetBadgeTranslations %d for admin: %s", badgeID, adminID)
+
+	if err := s.checkAdmin(adminID, ctxLog); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.badgeDAO.GetBadge(badgeID, ctxLog); err != nil {
+		return nil, err
+	}
+
+	translations, err := s.badgeDAO.GetTranslationsOfBadge(badgeID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	response := models.BadgeTranslationsResponse{
+		Translations: make([]*models.BadgeTranslation, 0, len(translations)),
+	}
+
+	for _, translation := range translations {
+		response.Translations = append(response.Translations, mapBadgeTranslation(translation))
+	}
+
+	return &response, nil
+}
+
+func (s badgesService) SetBadgeTranslation(adminID string, badgeID int16, locale string,
+	request *models.BadgeTranslationRequest, ctxLog *log.Entry) (*models.BadgeTranslation, error) {
+
+	ctxLog.Debugf("BADGES_SERVICE: Processing SetBadgeTranslation %d (%s) for admin: %s", badgeID, locale, adminID)
+
+	if err := s.checkAdmin(adminID, ctxLog); err != nil {
+		return nil, err
+	}
+
+	locale = strings.ToLower(locale)
+	if !slices.Contains(configs.Basic.Locales, locale) {
+		return nil, customErrors.BuildBadRequestError("Locale %s is not supported.", locale)
+	}
+
+	name := ""
+	if request.Name != nil {
+		name = strings.TrimSpace(*request.Name)
+	}
+	if name == "" {
+		return nil, customErrors.BuildBadRequestError("The translation needs a name.")
+	}
+
+	if _, err := s.badgeDAO.GetBadge(badgeID, ctxLog); err != nil {
+		return nil, err
+	}
+
+	translation := badgeDAO.BadgeTranslation{
+		BadgeID:     badgeID,
+		Locale:      locale,
+		Name:        name,
+		Description: strings.TrimSpace(request.Description),
+	}
+
+	audit := badgeDAO.BadgeAudit{
+		BadgeID: badgeID,
+		AdminID: adminID,
+		Action:  badgeDAO.BadgeActionTranslate,
+		Changes: fmt.Sprintf("%s: name: %q; description: %q", locale, translation.Name, translation.Description),
+	}
+
+	if err := s.badgeDAO.SaveBadgeTranslation(&translation, audit, ctxLog); err != nil {
+		return nil, err
+	}
+
+	ctxLog.Infof("BADGES_SERVICE: Badge %d translated to %s by admin %s", badgeID, locale, adminID)
+
+	return mapBadgeTranslation(&translation), nil
+}
+
+func (s badgesService) DeleteBadgeTranslation(adminID string, badgeID int16, locale string, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("BADGES_SERVICE: Processing DeleteBadgeTranslation %d (%s) for admin: %s", badgeID, locale, adminID)
+
+	if err := s.checkAdmin(adminID, ctxLog); err != nil {
+		return err
+	}
+
+	locale = strings.ToLower(locale)
+
+	audit := badgeDAO.BadgeAudit{
+		BadgeID: badgeID,
+		AdminID: adminID,
+		Action:  badgeDAO.BadgeActionTranslate,
+		Changes: fmt.Sprintf("%s translation removed", locale),
+	}
+
+	return s.badgeDAO.DeleteBadgeTranslation(badgeID, locale, audit, ctxLog)
+}
+
+// badgeTranslations Names and descriptions of the badges in a locale, by badge id
+type badgeTranslations struct {
+	names        map[int16]string
+	descriptions map[int16]string
+}
+
+// getTranslations returns the translations of the locale the client prefers, over the default locale ones
+func (s badgesService) getTranslations(acceptLanguage *string, ctxLog *log.Entry) (*badgeTranslations, error) {
+
+	locales := []string{configs.Basic.DefaultLocale}
+	if locale := negotiateLocale(acceptLanguage); locale != configs.Basic.DefaultLocale {
+		locales = append(locales, locale)
+	}
+
+	translations, err := s.badgeDAO.GetBadgeTranslations(locales, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	response := badgeTranslations{
+		names:        make(map[int16]string),
+		descriptions: make(map[int16]string),
+	}
+
+	// The preferred locale goes last, so it replaces the default one
+	for _, locale := range locales {
+		for _, translation := range translations {
+			if translation.Locale != locale {
+				continue
+			}
+			if translation.Name != "" {
+				response.names[translation.BadgeID] = translation.Name
+			}
+			if translation.Description != "" {
+				response.descriptions[translation.BadgeID] = translation.Description
+			}
+		}
+	}
+
+	return &response, nil
+}
+
+// localize replaces the names and descriptions of the badges with the translated ones
+func (t *badgeTranslations) localize(badges ...*badgeDAO.Badge) {
+
+	for _, badge := range badges {
+		if name, ok := t.names[badge.ID]; ok {
+			badge.Name = name
+		}
+		if description, ok := t.descriptions[badge.ID]; ok {
+			badge.Description = description
+		}
+	}
+}
+
+// negotiateLocale returns the supported locale with the highest weight in an Accept-Language header, the default
+// locale when there is none. Only the language of a tag is used, so es-AR is es
+func negotiateLocale(acceptLanguage *string) string {
+
+	locale, weight := configs.Basic.DefaultLocale, 0.0
+
+	if acceptLanguage == nil {
+		return locale
+	}
+
+	for _, item := range strings.Split(*acceptLanguage, ",") {
+
+		tag, params, _ := strings.Cut(item, ";")
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			var err error
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+
+		language, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if q > weight && slices.Contains(configs.Basic.Locales, language) {
+			locale, weight = language, q
+		}
+	}
+
+	return locale
+}
+
+func mapBadgeTranslation(translation *badgeDAO.BadgeTranslation) *models.BadgeTranslation {
+	return &models.BadgeTranslation{
+		Locale:      translation.Locale,
+		Name:        translation.Name,
+		Description: translation.Description,
+	}
+}
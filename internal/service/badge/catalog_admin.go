@@ -0,0 +1,668 @@
+package badge_service
+
+import (
+	"fmt"
+	configs "gym-badges-api/config/gym-badges-server"
+	customErrors "gym-badges-api/internal/custom-errors"
+	badgeDAO "gym-badges-api/internal/repository/badge"
+	eventsService "gym-badges-api/internal/service/events"
+	"gym-badges-api/models"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	log "github.com/sirupsen/logrus"
+)
+
+// Badge images are small icons
+const maxBadgeImageSize = 1 << 20
+
+// *******************************************************************
+// CATALOG ADMINISTRATION
+// *******************************************************************
+
+func (s badgesService) CreateBadge(adminID string, request *models.CreateBadgeRequest, ctxLog *log.Entry) (*models.AdminBadge, error) {
+
+	ctxLog.Debugf("BADGES_SERVICE: Processing CreateBadge for admin: %s", adminID)
+
+	if err := s.checkAdmin(adminID, ctxLog); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(request.Name) == "" {
+		return nil, customErrors.BuildBadRequestError("The badge needs a name.")
+	}
+	if request.ID < math.MinInt16 || request.ID > math.MaxInt16 {
+		return nil, customErrors.BuildBadRequestError("The badge id has to be between %d and %d.", math.MinInt16, math.MaxInt16)
+	}
+	if request.ParentBadgeID != nil && (*request.ParentBadgeID < math.MinInt16 || *request.ParentBadgeID > math.MaxInt16) {
+		return nil, customErrors.BuildBadRequestError("Parent badge %d does not exist.", *request.ParentBadgeID)
+	}
+	if request.Exp < 0 {
+		return nil, customErrors.BuildBadRequestError("The exp of a badge cannot be negative.")
+	}
+
+	catalog, err := s.getCatalog(ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	badge := badgeDAO.Badge{
+		ID:            int16(request.ID),
+		Name:          strings.TrimSpace(request.Name),
+		Description:   request.Description,
+		Exp:           request.Exp,
+		RequiresProof: request.RequiresProof,
+	}
+	if request.ParentBadgeID != nil {
+		badge.ParentBadgeID = int16(*request.ParentBadgeID)
+	}
+
+	if _, ok := catalog[badge.ID]; ok && badge.ID != 0 {
+		return nil, customErrors.BuildConflictError("Badge %d already exists.", badge.ID)
+	}
+	if err := validateParent(catalog, badge.ID, badge.ParentBadgeID); err != nil {
+		return nil, err
+	}
+
+	badge.Position = nextPosition(catalog, badge.ParentBadgeID)
+
+	audit := badgeDAO.BadgeAudit{
+		AdminID: adminID,
+		Action:  badgeDAO.BadgeActionCreate,
+		Changes: fmt.Sprintf("name: %q; description: %q; exp: %d; parent: %d; requires proof: %t", badge.Name, badge.Description,
+			badge.Exp, badge.ParentBadgeID, badge.RequiresProof),
+	}
+
+	if err := s.badgeDAO.CreateBadge(&badge, audit, ctxLog); err != nil {
+		return nil, err
+	}
+
+	ctxLog.Infof("BADGES_SERVICE: Badge %d created by admin %s", badge.ID, adminID)
+
+	return mapAdminBadge(&badge), nil
+}
+
+func (s badgesService) EditBadge(adminID string, badgeID int16, request *models.EditBadgeRequest, ctxLog *log.Entry) (*models.AdminBadge, error) {
+
+	ctxLog.Debugf("BADGES_SERVICE: Processing EditBadge %d for admin: %s", badgeID, adminID)
+
+	if err := s.checkAdmin(adminID, ctxLog); err != nil {
+		return nil, err
+	}
+
+	badge, err := s.badgeDAO.GetBadge(badgeID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]string, 0, 4)
+
+	if name := strings.TrimSpace(request.Name); name != "" && name != badge.Name {
+		changes = append(changes, fmt.Sprintf("name: %q -> %q", badge.Name, name))
+		badge.Name = name
+	}
+	if request.Description != nil && *request.Description != badge.Description {
+		changes = append(changes, fmt.Sprintf("description: %q -> %q", badge.Description, *request.Description))
+		badge.Description = *request.Description
+	}
+	if request.Exp != nil && *request.Exp != badge.Exp {
+		if *request.Exp < 0 {
+			return nil, customErrors.BuildBadRequestError("The exp of a badge cannot be negative.")
+		}
+		changes = append(changes, fmt.Sprintf("exp: %d -> %d", badge.Exp, *request.Exp))
+		badge.Exp = *request.Exp
+	}
+	if request.RequiresProof != nil && *request.RequiresProof != badge.RequiresProof {
+		changes = append(changes, fmt.Sprintf("requires proof: %t -> %t", badge.RequiresProof, *request.RequiresProof))
+		badge.RequiresProof = *request.RequiresProof
+	}
+
+	if len(changes) == 0 {
+		return mapAdminBadge(badge), nil
+	}
+
+	if err := s.saveBadge(adminID, badge, badgeDAO.BadgeActionEdit, strings.Join(changes, "; "), ctxLog); err != nil {
+		return nil, err
+	}
+
+	return mapAdminBadge(badge), nil
+}
+
+func (s badgesService) ReparentBadge(adminID string, badgeID int16, parentBadgeID int16, ctxLog *log.Entry) (*models.AdminBadge, error) {
+
+	ctxLog.Debugf("BADGES_SERVICE: Processing ReparentBadge %d for admin: %s", badgeID, adminID)
+
+	if err := s.checkAdmin(adminID, ctxLog); err != nil {
+		return nil, err
+	}
+
+	catalog, err := s.getCatalog(ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	badge, ok := catalog[badgeID]
+	if !ok {
+		return nil, customErrors.BuildNotFoundError("Badge not found")
+	}
+
+	if badge.ParentBadgeID == parentBadgeID {
+		return mapAdminBadge(badge), nil
+	}
+
+	if err := validateParent(catalog, badgeID, parentBadgeID); err != nil {
+		return nil, err
+	}
+
+	changes := fmt.Sprintf("parent: %d -> %d", badge.ParentBadgeID, parentBadgeID)
+
+	// The badge goes after its new siblings
+	badge.ParentBadgeID = parentBadgeID
+	badge.Position = nextPosition(catalog, parentBadgeID)
+
+	if err := s.saveBadge(adminID, badge, badgeDAO.BadgeActionReparent, changes, ctxLog); err != nil {
+		return nil, err
+	}
+
+	return mapAdminBadge(badge), nil
+}
+
+func (s badgesService) RetireBadge(adminID string, badgeID int16, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("BADGES_SERVICE: Processing RetireBadge %d for admin: %s", badgeID, adminID)
+
+	if err := s.checkAdmin(adminID, ctxLog); err != nil {
+		return err
+	}
+
+	catalog, err := s.getCatalog(ctxLog)
+	if err != nil {
+		return err
+	}
+
+	badge, ok := catalog[badgeID]
+	if !ok {
+		return customErrors.BuildNotFoundError("Badge not found")
+	}
+
+	if badge.RetiredAt != nil {
+		return customErrors.BuildConflictError("Badge %d is already retired.", badgeID)
+	}
+
+	// The badges below would not be achievable anymore
+	for _, child := range catalog {
+		if child.ParentBadgeID == badgeID && child.RetiredAt == nil {
+			return customErrors.BuildConflictError("Badge %d cannot be retired while badge %d depends on it.", badgeID, child.ID)
+		}
+	}
+
+	now := time.Now()
+	badge.RetiredAt = &now
+
+	return s.saveBadge(adminID, badge, badgeDAO.BadgeActionRetire, "retired", ctxLog)
+}
+
+func (s badgesService) ReorderBadges(adminID string, parentBadgeID int16, badgeIDs []int16, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("BADGES_SERVICE: Processing ReorderBadges of parent %d for admin: %s", parentBadgeID, adminID)
+
+	if err := s.checkAdmin(adminID, ctxLog); err != nil {
+		return err
+	}
+
+	catalog, err := s.getCatalog(ctxLog)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := catalog[parentBadgeID]; parentBadgeID != 0 && !ok {
+		return customErrors.BuildNotFoundError("Badge not found")
+	}
+
+	// The new order has to list every child once
+	children := 0
+	for _, badge := range catalog {
+		if badge.ParentBadgeID == parentBadgeID {
+			children++
+		}
+	}
+
+	listed := make(map[int16]bool)
+	for _, id := range badgeIDs {
+		badge, ok := catalog[id]
+		if !ok || badge.ParentBadgeID != parentBadgeID || listed[id] {
+			return customErrors.BuildBadRequestError("Badge %d is not a child of badge %d or is repeated.", id, parentBadgeID)
+		}
+		listed[id] = true
+	}
+	if len(listed) != children {
+		return customErrors.BuildBadRequestError("The new order has to list the %d children of badge %d.", children, parentBadgeID)
+	}
+
+	badges := make([]*badgeDAO.Badge, 0, len(badgeIDs))
+	audits := make([]badgeDAO.BadgeAudit, 0, len(badgeIDs))
+
+	for i, id := range badgeIDs {
+
+		badge := catalog[id]
+		if badge.Position == int16(i) {
+			continue
+		}
+
+		audits = append(audits, badgeDAO.BadgeAudit{
+			BadgeID: id,
+			AdminID: adminID,
+			Action:  badgeDAO.BadgeActionReorder,
+			Changes: fmt.Sprintf("position: %d -> %d", badge.Position, i),
+		})
+
+		badge.Position = int16(i)
+		badges = append(badges, badge)
+	}
+
+	if len(badges) == 0 {
+		return nil
+	}
+
+	// The positions do not change the experience of any user
+	_, err = s.badgeDAO.UpdateBadges(badges, audits, ctxLog)
+	return err
+}
+
+func (s badgesService) UploadBadgeImage(adminID string, badgeID int16, file io.Reader, ctxLog *log.Entry) (*models.AdminBadge, error) {
+
+	ctxLog.Debugf("BADGES_SERVICE: Processing UploadBadgeImage %d for admin: %s", badgeID, adminID)
+
+	if err := s.checkAdmin(adminID, ctxLog); err != nil {
+		return nil, err
+	}
+
+	badge, err := s.badgeDAO.GetBadge(badgeID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := io.ReadAll(io.LimitReader(file, maxBadgeImageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > maxBadgeImageSize {
+		return nil, customErrors.BuildBadRequestError("The image cannot be bigger than %d KB.", maxBadgeImageSize/1024)
+	}
+
+	extension := imageExtension(content)
+	if extension == "" {
+		return nil, customErrors.BuildBadRequestError("The image has to be a PNG or JPEG file.")
+	}
+
+	if err := os.MkdirAll(configs.Basic.BadgeImagesDir, 0755); err != nil {
+		return nil, err
+	}
+
+	fileName := fmt.Sprintf("%d.%s", badge.ID, extension)
+	if err := os.WriteFile(filepath.Join(configs.Basic.BadgeImagesDir, fileName), content, 0644); err != nil {
+		return nil, err
+	}
+
+	// The version makes clients load the new image instead of a cached one
+	image := fmt.Sprintf("%s/%s?v=%d", strings.TrimSuffix(configs.Basic.BadgeImagesPath, "/"), fileName, time.Now().Unix())
+	changes := fmt.Sprintf("image: %q -> %q", badge.Image, image)
+	badge.Image = image
+
+	if err := s.saveBadge(adminID, badge, badgeDAO.BadgeActionImage, changes, ctxLog); err != nil {
+		return nil, err
+	}
+
+	return mapAdminBadge(badge), nil
+}
+
+func (s badgesService) GetBadgeAudit(adminID string, badgeID int16, ctxLog *log.Entry) (models.BadgeAuditResponse, error) {
+
+	ctxLog.Debugf("BADGES_SERVICE: Processing GetBadgeAudit %d for admin: %s", badgeID, adminID)
+
+	if err := s.checkAdmin(adminID, ctxLog); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.badgeDAO.GetBadge(badgeID, ctxLog); err != nil {
+		return nil, err
+	}
+
+	audit, err := s.badgeDAO.GetBadgeAudit(badgeID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make(models.BadgeAuditResponse, len(audit))
+	for i, entry := range audit {
+		response[i] = &models.BadgeAuditEntry{
+			AdminID: entry.AdminID,
+			Action:  entry.Action,
+			Changes: entry.Changes,
+			Date:    strfmt.DateTime(entry.CreatedAt),
+		}
+	}
+
+	return response, nil
+}
+
+func (s badgesService) SetBadgeRule(adminID string, badgeID int16, request *models.BadgeRule, ctxLog *log.Entry) (*models.BadgeRule, error) {
+
+	ctxLog.Debugf("BADGES_SERVICE: Processing SetBadgeRule %d for admin: %s", badgeID, adminID)
+
+	if err := s.checkAdmin(adminID, ctxLog); err != nil {
+		return nil, err
+	}
+
+	badge, err := s.badgeDAO.GetBadge(badgeID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	if badge.RetiredAt != nil {
+		return nil, customErrors.BuildConflictError("Badge %d is retired.", badgeID)
+	}
+
+	rule := badgeDAO.BadgeRule{BadgeID: badgeID}
+
+	rule.BadgeRuleClause, err = mapRuleClause(request.Metric, request.Comparator, request.Threshold, request.FromMinute, request.ToMinute)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, condition := range request.Conditions {
+		clause, err := mapRuleClause(condition.Metric, condition.Comparator, condition.Threshold, condition.FromMinute, condition.ToMinute)
+		if err != nil {
+			return nil, err
+		}
+		rule.Conditions = append(rule.Conditions, badgeDAO.BadgeRuleCondition{BadgeID: badgeID, BadgeRuleClause: clause})
+	}
+
+	audit := badgeDAO.BadgeAudit{
+		BadgeID: badgeID,
+		AdminID: adminID,
+		Action:  badgeDAO.BadgeActionRule,
+		Changes: describeRule(&rule),
+	}
+
+	if err := s.badgeDAO.SaveBadgeRule(&rule, audit, ctxLog); err != nil {
+		return nil, err
+	}
+
+	ctxLog.Infof("BADGES_SERVICE: Rule of badge %d set by admin %s: %s", badgeID, adminID, audit.Changes)
+
+	return mapBadgeRule(&rule), nil
+}
+
+func (s badgesService) DeleteBadgeRule(adminID string, badgeID int16, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("BADGES_SERVICE: Processing DeleteBadgeRule %d for admin: %s", badgeID, adminID)
+
+	if err := s.checkAdmin(adminID, ctxLog); err != nil {
+		return err
+	}
+
+	audit := badgeDAO.BadgeAudit{
+		BadgeID: badgeID,
+		AdminID: adminID,
+		Action:  badgeDAO.BadgeActionRule,
+		Changes: "rule removed",
+	}
+
+	return s.badgeDAO.DeleteBadgeRule(badgeID, audit, ctxLog)
+}
+
+func (s badgesService) GrantBadge(adminID string, badgeID int16, userID string, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("BADGES_SERVICE: Processing GrantBadge %d to user %s for admin: %s", badgeID, userID, adminID)
+
+	if err := s.checkAdmin(adminID, ctxLog); err != nil {
+		return err
+	}
+
+	hasBadge, err := s.badgeDAO.CheckBadge(userID, badgeID, ctxLog)
+	if err != nil {
+		return err
+	}
+
+	if hasBadge {
+		return customErrors.BuildConflictError("User %s already has badge %d.", userID, badgeID)
+	}
+
+	if err := s.addBadge(userID, badgeID, badgeDAO.AwardSourceAdmin, &adminID, ctxLog); err != nil {
+		return err
+	}
+
+	ctxLog.Infof("BADGES_SERVICE: Badge %d granted to user %s by admin %s", badgeID, userID, adminID)
+
+	return nil
+}
+
+func (s badgesService) checkAdmin(adminID string, ctxLog *log.Entry) error {
+
+	user, err := s.userDAO.GetUser(adminID, ctxLog)
+	if err != nil {
+		return err
+	}
+
+	if !user.Admin {
+		return customErrors.BuildForbiddenError("Only catalog administrators can change badges.")
+	}
+
+	return nil
+}
+
+func (s badgesService) getCatalog(ctxLog *log.Entry) (map[int16]*badgeDAO.Badge, error) {
+
+	badges, err := s.badgeDAO.GetBadges(ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := make(map[int16]*badgeDAO.Badge, len(badges))
+	for _, badge := range badges {
+		catalog[badge.ID] = badge
+	}
+
+	return catalog, nil
+}
+
+func (s badgesService) saveBadge(adminID string, badge *badgeDAO.Badge, action string, changes string, ctxLog *log.Entry) error {
+
+	audit := badgeDAO.BadgeAudit{
+		BadgeID: badge.ID,
+		AdminID: adminID,
+		Action:  action,
+		Changes: changes,
+	}
+
+	userIDs, err := s.badgeDAO.UpdateBadges([]*badgeDAO.Badge{badge}, []badgeDAO.BadgeAudit{audit}, ctxLog)
+	if err != nil {
+		return err
+	}
+
+	ctxLog.Infof("BADGES_SERVICE: Badge %d changed by admin %s (%s): %s", badge.ID, adminID, action, changes)
+
+	// The holders of a badge whose exp changed have other experience now
+	for _, userID := range userIDs {
+		s.eventsService.Publish(eventsService.Event{Type: eventsService.ExperienceChanged, UserID: userID}, ctxLog)
+	}
+
+	return nil
+}
+
+// validateParent checks the parent exists, can be achieved and is not below the badge itself
+func validateParent(catalog map[int16]*badgeDAO.Badge, badgeID int16, parentBadgeID int16) error {
+
+	if parentBadgeID == 0 {
+		return nil
+	}
+
+	parent, ok := catalog[parentBadgeID]
+	if !ok {
+		return customErrors.BuildBadRequestError("Parent badge %d does not exist.", parentBadgeID)
+	}
+	if parent.RetiredAt != nil {
+		return customErrors.BuildBadRequestError("Parent badge %d is retired.", parentBadgeID)
+	}
+
+	// Walking up the tree from the new parent must not reach the badge. The steps are bounded in case the
+	// stored tree already has a cycle
+	for id, steps := parentBadgeID, 0; id != 0 && steps <= len(catalog); steps++ {
+		if id == badgeID {
+			return customErrors.BuildBadRequestError("Badge %d cannot be below itself.", badgeID)
+		}
+		ancestor, ok := catalog[id]
+		if !ok {
+			break
+		}
+		id = ancestor.ParentBadgeID
+	}
+
+	return nil
+}
+
+// nextPosition returns the position after the last child of the parent
+func nextPosition(catalog map[int16]*badgeDAO.Badge, parentBadgeID int16) int16 {
+
+	var position int16
+	for _, badge := range catalog {
+		if badge.ParentBadgeID == parentBadgeID {
+			position = max(position, badge.Position+1)
+		}
+	}
+
+	return position
+}
+
+// imageExtension returns the extension of the image format, empty when it is not supported
+func imageExtension(content []byte) string {
+
+	switch http.DetectContentType(content) {
+	case "image/png":
+		return "png"
+	case "image/jpeg":
+		return "jpg"
+	}
+
+	// SVG is not accepted, its scripts would run from the origin of the API
+	return ""
+}
+
+func mapAdminBadge(badge *badgeDAO.Badge) *models.AdminBadge {
+
+	response := models.AdminBadge{
+		ID:            int32(badge.ID),
+		Name:          badge.Name,
+		Description:   badge.Description,
+		Image:         badge.Image,
+		Exp:           badge.Exp,
+		Position:      int32(badge.Position),
+		RequiresProof: badge.RequiresProof,
+	}
+
+	if badge.ParentBadgeID != 0 {
+		parent := int32(badge.ParentBadgeID)
+		response.ParentBadgeID = &parent
+	}
+	if badge.RetiredAt != nil {
+		retiredAt := strfmt.DateTime(*badge.RetiredAt)
+		response.RetiredAt = &retiredAt
+	}
+
+	return &response
+}
+
+// mapRuleClause validates a clause of a rule. Only the sessions between metric has a window
+func mapRuleClause(metric, comparator string, threshold int64, fromMinute, toMinute *int32) (badgeDAO.BadgeRuleClause, error) {
+
+	clause := badgeDAO.BadgeRuleClause{Metric: metric, Comparator: comparator, Threshold: threshold}
+
+	if !knownMetrics[metric] {
+		return clause, customErrors.BuildBadRequestError("Unknown metric %q.", metric)
+	}
+	if !knownComparators[comparator] {
+		return clause, customErrors.BuildBadRequestError("Unknown comparator %q.", comparator)
+	}
+	if threshold < 0 {
+		return clause, customErrors.BuildBadRequestError("The threshold cannot be negative.")
+	}
+
+	if metric != badgeDAO.MetricSessionsBetween {
+		if fromMinute != nil || toMinute != nil {
+			return clause, customErrors.BuildBadRequestError("Only the %s metric has a window of the day.", badgeDAO.MetricSessionsBetween)
+		}
+		return clause, nil
+	}
+
+	if fromMinute == nil || toMinute == nil || *fromMinute < 0 || *fromMinute >= *toMinute || *toMinute > 24*60 {
+		return clause, customErrors.BuildBadRequestError("The %s metric needs a window with 0 <= from_minute < to_minute <= 1440.",
+			badgeDAO.MetricSessionsBetween)
+	}
+
+	from, to := int16(*fromMinute), int16(*toMinute)
+	clause.FromMinute = &from
+	clause.ToMinute = &to
+
+	return clause, nil
+}
+
+func describeClause(clause badgeDAO.BadgeRuleClause) string {
+
+	description := fmt.Sprintf("%s %s %d", clause.Metric, clause.Comparator, clause.Threshold)
+	if clause.FromMinute != nil && clause.ToMinute != nil {
+		description += fmt.Sprintf(" [%d, %d)", *clause.FromMinute, *clause.ToMinute)
+	}
+
+	return description
+}
+
+func describeRule(rule *badgeDAO.BadgeRule) string {
+
+	clauses := []string{describeClause(rule.BadgeRuleClause)}
+	for _, condition := range rule.Conditions {
+		clauses = append(clauses, describeClause(condition.BadgeRuleClause))
+	}
+
+	return "rule: " + strings.Join(clauses, " and ")
+}
+
+func mapBadgeRule(rule *badgeDAO.BadgeRule) *models.BadgeRule {
+
+	response := models.BadgeRule{
+		Metric:     rule.Metric,
+		Comparator: rule.Comparator,
+		Threshold:  rule.Threshold,
+		FromMinute: mapMinute(rule.FromMinute),
+		ToMinute:   mapMinute(rule.ToMinute),
+		Conditions: make([]*models.BadgeRuleClause, len(rule.Conditions)),
+	}
+
+	for i, condition := range rule.Conditions {
+		response.Conditions[i] = &models.BadgeRuleClause{
+			Metric:     condition.Metric,
+			Comparator: condition.Comparator,
+			Threshold:  condition.Threshold,
+			FromMinute: mapMinute(condition.FromMinute),
+			ToMinute:   mapMinute(condition.ToMinute),
+		}
+	}
+
+	return &response
+}
+
+func mapMinute(minute *int16) *int32 {
+
+	if minute == nil {
+		return nil
+	}
+
+	value := int32(*minute)
+	return &value
+}
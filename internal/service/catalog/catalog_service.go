@@ -0,0 +1,369 @@
+package catalog_service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	customErrors "gym-badges-api/internal/custom-errors"
+	badgeDAO "gym-badges-api/internal/repository/badge"
+	"slices"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// catalogVersion Version of the catalog format this build understands
+	catalogVersion = 1
+	// seedAdminID Author of the audit entries of the seeded changes
+	seedAdminID = "catalog-seed"
+)
+
+func NewCatalogService(badgeDAO badgeDAO.IBadgeDAO) ICatalogService {
+	return &catalogService{
+		badgeDAO: badgeDAO,
+	}
+}
+
+type catalogService struct {
+	badgeDAO badgeDAO.IBadgeDAO
+}
+
+// Catalog Versioned file with the whole badge catalog. The order of the badges with the same parent is their
+// position
+type Catalog struct {
+	Version int            `yaml:"version"`
+	Badges  []CatalogBadge `yaml:"badges"`
+}
+
+type CatalogBadge struct {
+	ID            int16  `yaml:"id"`
+	Name          string `yaml:"name"`
+	Description   string `yaml:"description"`
+	Image         string `yaml:"image"`
+	Parent        int16  `yaml:"parent"` // 0 for the categories at the root
+	Exp           int64  `yaml:"exp"`
+	RequiresProof bool   `yaml:"requires_proof"`
+	Retired       bool   `yaml:"retired"`
+}
+
+// CatalogDiff Changes needed to deploy a catalog
+type CatalogDiff struct {
+	Created []*badgeDAO.Badge // Parents first
+	Updated []*badgeDAO.Badge
+	// Readable list of the changed fields of each updated badge
+	Changes   map[int16]string
+	Unchanged int
+	// Deployed badges missing from the catalog
+	Undeclared []*badgeDAO.Badge
+}
+
+// Empty returns whether the catalog is already deployed
+func (d *CatalogDiff) Empty() bool {
+	return len(d.Created) == 0 && len(d.Updated) == 0
+}
+
+// String reports the diff, one line for each badge that is created, updated or missing from the catalog
+func (d *CatalogDiff) String() string {
+
+	var report strings.Builder
+
+	for _, badge := range d.Created {
+		fmt.Fprintf(&report, "+ %d %q\n", badge.ID, badge.Name)
+	}
+	for _, badge := range d.Updated {
+		fmt.Fprintf(&report, "~ %d %q: %s\n", badge.ID, badge.Name, d.Changes[badge.ID])
+	}
+	for _, badge := range d.Undeclared {
+		fmt.Fprintf(&report, "? %d %q is deployed but missing from the catalog\n", badge.ID, badge.Name)
+	}
+
+	fmt.Fprintf(&report, "%d to create, %d to update, %d unchanged, %d missing from the catalog\n",
+		len(d.Created), len(d.Updated), d.Unchanged, len(d.Undeclared))
+
+	return report.String()
+}
+
+// *******************************************************************
+// CATALOG
+// *******************************************************************
+
+func (s catalogService) LoadCatalog(content []byte, ctxLog *log.Entry) (*Catalog, error) {
+
+	ctxLog.Debugf("CATALOG_SERVICE: Processing LoadCatalog")
+
+	var catalog Catalog
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(&catalog); err != nil {
+		return nil, customErrors.BuildBadRequestError("The catalog is not valid YAML: %s", err)
+	}
+
+	if err := validateCatalog(&catalog); err != nil {
+		return nil, err
+	}
+
+	return &catalog, nil
+}
+
+func (s catalogService) DiffCatalog(catalog *Catalog, ctxLog *log.Entry) (*CatalogDiff, error) {
+
+	ctxLog.Debugf("CATALOG_SERVICE: Processing DiffCatalog")
+
+	deployed, err := s.badgeDAO.GetBadges(ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	deployedMap := make(map[int16]*badgeDAO.Badge, len(deployed))
+	for _, badge := range deployed {
+		deployedMap[badge.ID] = badge
+	}
+
+	diff := CatalogDiff{
+		Created:    make([]*badgeDAO.Badge, 0),
+		Updated:    make([]*badgeDAO.Badge, 0),
+		Changes:    make(map[int16]string),
+		Undeclared: make([]*badgeDAO.Badge, 0),
+	}
+
+	declared := make(map[int16]bool, len(catalog.Badges))
+	positions := make(map[int16]int16)
+	now := time.Now()
+
+	for _, entry := range sortParentsFirst(catalog.Badges) {
+
+		declared[entry.ID] = true
+
+		badge := badgeDAO.Badge{
+			ID:            entry.ID,
+			Name:          entry.Name,
+			Description:   entry.Description,
+			Image:         entry.Image,
+			Exp:           entry.Exp,
+			ParentBadgeID: entry.Parent,
+			Position:      positions[entry.Parent],
+			RequiresProof: entry.RequiresProof,
+		}
+		positions[entry.Parent]++
+
+		stored, ok := deployedMap[entry.ID]
+
+		// A retired badge keeps the date it was retired
+		if entry.Retired {
+			badge.RetiredAt = &now
+			if ok && stored.RetiredAt != nil {
+				badge.RetiredAt = stored.RetiredAt
+			}
+		}
+
+		if !ok {
+			diff.Created = append(diff.Created, &badge)
+			continue
+		}
+
+		changes := describeChanges(stored, &badge)
+		if len(changes) == 0 {
+			diff.Unchanged++
+			continue
+		}
+
+		diff.Updated = append(diff.Updated, &badge)
+		diff.Changes[badge.ID] = strings.Join(changes, "; ")
+	}
+
+	for _, badge := range deployed {
+		if !declared[badge.ID] {
+			diff.Undeclared = append(diff.Undeclared, badge)
+		}
+	}
+
+	return &diff, nil
+}
+
+func (s catalogService) ApplyCatalog(diff *CatalogDiff, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("CATALOG_SERVICE: Processing ApplyCatalog")
+
+	if diff.Empty() {
+		return nil
+	}
+
+	audits := make([]badgeDAO.BadgeAudit, 0, len(diff.Created)+len(diff.Updated))
+
+	for _, badge := range diff.Created {
+		audits = append(audits, badgeDAO.BadgeAudit{
+			BadgeID: badge.ID,
+			AdminID: seedAdminID,
+			Action:  badgeDAO.BadgeActionCreate,
+			Changes: fmt.Sprintf("name: %q; description: %q; exp: %d; parent: %d; requires proof: %t", badge.Name, badge.Description,
+				badge.Exp, badge.ParentBadgeID, badge.RequiresProof),
+		})
+	}
+
+	for _, badge := range diff.Updated {
+		audits = append(audits, badgeDAO.BadgeAudit{
+			BadgeID: badge.ID,
+			AdminID: seedAdminID,
+			Action:  badgeDAO.BadgeActionEdit,
+			Changes: diff.Changes[badge.ID],
+		})
+	}
+
+	if err := s.badgeDAO.SeedBadges(diff.Created, diff.Updated, audits, ctxLog); err != nil {
+		return err
+	}
+
+	ctxLog.Infof("CATALOG_SERVICE: Catalog seeded, %d badges created and %d updated", len(diff.Created), len(diff.Updated))
+
+	return nil
+}
+
+// validateCatalog checks the ids, the tree of parents, the images and the exp of the badges
+func validateCatalog(catalog *Catalog) error {
+
+	problems := make([]error, 0)
+	report := func(message string, args ...any) {
+		problems = append(problems, customErrors.BuildBadRequestError(message, args...))
+	}
+
+	if catalog.Version != catalogVersion {
+		report("Catalog version %d is not supported, it must be %d.", catalog.Version, catalogVersion)
+	}
+
+	badges := make(map[int16]*CatalogBadge, len(catalog.Badges))
+	images := make(map[string]int16)
+
+	for i := range catalog.Badges {
+
+		badge := &catalog.Badges[i]
+
+		if badge.ID == 0 {
+			report("Badge %q needs an id other than 0.", badge.Name)
+			continue
+		}
+		if _, ok := badges[badge.ID]; ok {
+			report("Badge %d is declared more than once.", badge.ID)
+			continue
+		}
+		badges[badge.ID] = badge
+
+		if strings.TrimSpace(badge.Name) == "" {
+			report("Badge %d needs a name.", badge.ID)
+		}
+		if badge.Exp < 0 {
+			report("The exp of badge %d cannot be negative.", badge.ID)
+		}
+
+		// The categories have no image
+		if badge.Image != "" {
+			if other, ok := images[badge.Image]; ok {
+				report("Badges %d and %d have the same image %s.", other, badge.ID, badge.Image)
+			}
+			images[badge.Image] = badge.ID
+		}
+	}
+
+	for i := range catalog.Badges {
+
+		// Only the first declaration of a badge is checked
+		badge := &catalog.Badges[i]
+		if badge.Parent == 0 || badges[badge.ID] != badge {
+			continue
+		}
+
+		parent, ok := badges[badge.Parent]
+		if !ok {
+			report("Parent %d of badge %d does not exist.", badge.Parent, badge.ID)
+			continue
+		}
+		if parent.Retired && !badge.Retired {
+			report("Parent %d of badge %d is retired.", badge.Parent, badge.ID)
+		}
+		if badge.Exp <= parent.Exp {
+			report("The exp of badge %d (%d) must be greater than the exp of its parent %d (%d).", badge.ID, badge.Exp,
+				parent.ID, parent.Exp)
+		}
+	}
+
+	// Walking up from every badge must reach a root, otherwise the badge is in a subtree without root. The steps
+	// are bounded by the size of the catalog, as a longer walk has a cycle
+	for _, badge := range catalog.Badges {
+
+		id, steps := badge.ID, 0
+		for ; steps <= len(badges); steps++ {
+			current, ok := badges[id]
+			if !ok || current.Parent == 0 {
+				break
+			}
+			id = current.Parent
+		}
+
+		if steps > len(badges) {
+			report("Badge %d is in a cycle of parents, so it has no root.", badge.ID)
+		}
+	}
+
+	return errors.Join(problems...)
+}
+
+// sortParentsFirst orders the badges by their depth in the tree, keeping the catalog order in each level
+func sortParentsFirst(badges []CatalogBadge) []CatalogBadge {
+
+	parents := make(map[int16]int16, len(badges))
+	for _, badge := range badges {
+		parents[badge.ID] = badge.Parent
+	}
+
+	depth := func(badge CatalogBadge) int {
+		levels := 0
+		for id := badge.Parent; id != 0 && levels < len(badges); id = parents[id] {
+			levels++
+		}
+		return levels
+	}
+
+	sorted := slices.Clone(badges)
+	slices.SortStableFunc(sorted, func(a, b CatalogBadge) int {
+		return depth(a) - depth(b)
+	})
+
+	return sorted
+}
+
+// describeChanges lists the fields of the deployed badge that the catalog changes, with their old and new values
+func describeChanges(stored *badgeDAO.Badge, badge *badgeDAO.Badge) []string {
+
+	changes := make([]string, 0)
+
+	if stored.Name != badge.Name {
+		changes = append(changes, fmt.Sprintf("name: %q -> %q", stored.Name, badge.Name))
+	}
+	if stored.Description != badge.Description {
+		changes = append(changes, fmt.Sprintf("description: %q -> %q", stored.Description, badge.Description))
+	}
+	if stored.Image != badge.Image {
+		changes = append(changes, fmt.Sprintf("image: %q -> %q", stored.Image, badge.Image))
+	}
+	if stored.Exp != badge.Exp {
+		changes = append(changes, fmt.Sprintf("exp: %d -> %d", stored.Exp, badge.Exp))
+	}
+	if stored.ParentBadgeID != badge.ParentBadgeID {
+		changes = append(changes, fmt.Sprintf("parent: %d -> %d", stored.ParentBadgeID, badge.ParentBadgeID))
+	}
+	if stored.Position != badge.Position {
+		changes = append(changes, fmt.Sprintf("position: %d -> %d", stored.Position, badge.Position))
+	}
+	if stored.RequiresProof != badge.RequiresProof {
+		changes = append(changes, fmt.Sprintf("requires proof: %t -> %t", stored.RequiresProof, badge.RequiresProof))
+	}
+	if (stored.RetiredAt != nil) != (badge.RetiredAt != nil) {
+		changes = append(changes, fmt.Sprintf("retired: %t -> %t", stored.RetiredAt != nil, badge.RetiredAt != nil))
+	}
+
+	return changes
+}
@@ -0,0 +1,15 @@
+package catalog_service
+
+import (
+	log "github.com/sirupsen/logrus"
+)
+
+type ICatalogService interface {
+	// Parses and validates a catalog file. Every problem of the catalog is reported in the error
+	LoadCatalog(content []byte, ctxLog *log.Entry) (*Catalog, error)
+	// Compares the catalog with the deployed badges
+	DiffCatalog(catalog *Catalog, ctxLog *log.Entry) (*CatalogDiff, error)
+	// Creates and updates the deployed badges that differ from the catalog. The badges missing from the
+	// catalog are left untouched
+	ApplyCatalog(diff *CatalogDiff, ctxLog *log.Entry) error
+}
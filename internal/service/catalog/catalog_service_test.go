@@ -0,0 +1,233 @@
+package catalog_service
+
+import (
+	"errors"
+	customErrors "gym-badges-api/internal/custom-errors"
+	badgeDAO "gym-badges-api/internal/repository/badge"
+	mockDAO "gym-badges-api/mocks/dao"
+	toolsLogging "gym-badges-api/tools/logging"
+	toolsTesting "gym-badges-api/tools/testing"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	log "github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+)
+
+func TestServiceCatalogSuite(t *testing.T) {
+	toolsTesting.ConfigureTestSuite(t, "SERVICE: Catalog Test Suite")
+}
+
+var _ = Describe("SERVICE: Catalog Test Suite", func() {
+
+	var (
+		mockCtrl     *gomock.Controller
+		mockBadgeDAO *mockDAO.MockIBadgeDAO
+		service      ICatalogService
+		ctxLogger    *log.Entry
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockBadgeDAO = mockDAO.NewMockIBadgeDAO(mockCtrl)
+		service = NewCatalogService(mockBadgeDAO)
+		ctxLogger = toolsLogging.BuildLogger()
+	})
+
+	AfterEach(func() {
+		defer mockCtrl.Finish()
+	})
+
+	Context("Load catalog", func() {
+
+		It("CASE: Successful load of a valid catalog", func() {
+
+			catalog, err := service.LoadCatalog([]byte(`
+version: 1
+badges:
+  - id: -1
+    name: chest
+    exp: 0
+  - id: 1
+    name: Do five push-ups
+    image: /image/badge/1.svg
+    parent: -1
+    exp: 125
+  - id: 2
+    name: Do ten push-ups
+    image: /image/badge/2.svg
+    parent: 1
+    exp: 247
+    requires_proof: true
+`), ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(catalog.Badges).To(Equal([]CatalogBadge{
+				{ID: -1, Name: "chest"},
+				{ID: 1, Name: "Do five push-ups", Image: "/image/badge/1.svg", Parent: -1, Exp: 125},
+				{ID: 2, Name: "Do ten push-ups", Image: "/image/badge/2.svg", Parent: 1, Exp: 247, RequiresProof: true},
+			}))
+		})
+
+		It("CASE: Load catalog failed cause a field is unknown", func() {
+
+			_, err := service.LoadCatalog([]byte(`
+version: 1
+badges:
+  - id: 1
+    name: Do five push-ups
+    experience: 125
+`), ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+		})
+
+		It("CASE: Load catalog reports every problem of the tree", func() {
+
+			_, err := service.LoadCatalog([]byte(`
+version: 2
+badges:
+  - id: -1
+    name: chest
+    exp: 0
+  - id: 1
+    name: Do five push-ups
+    image: /image/badge/1.svg
+    parent: -1
+    exp: 125
+  - id: 1
+    name: Duplicated
+    exp: 10
+  - id: 2
+    name: Do ten push-ups
+    image: /image/badge/1.svg
+    parent: 1
+    exp: 100
+  - id: 3
+    name: Orphan
+    parent: 9
+    exp: 300
+  - id: 4
+    name: Loop A
+    parent: 5
+    exp: 400
+  - id: 5
+    name: Loop B
+    parent: 4
+    exp: 500
+  - id: 6
+    name: Old
+    parent: -1
+    exp: 100
+    retired: true
+  - id: 7
+    name: ""
+    parent: 6
+    exp: 200
+`), ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+			Expect(err.Error()).To(Equal(`Catalog version 2 is not supported, it must be 1.
+Badge 1 is declared more than once.
+Badges 1 and 2 have the same image /image/badge/1.svg.
+Badge 7 needs a name.
+The exp of badge 2 (100) must be greater than the exp of its parent 1 (125).
+Parent 9 of badge 3 does not exist.
+The exp of badge 4 (400) must be greater than the exp of its parent 5 (500).
+Parent 6 of badge 7 is retired.
+Badge 4 is in a cycle of parents, so it has no root.
+Badge 5 is in a cycle of parents, so it has no root.`))
+		})
+
+	})
+
+	Context("Diff and apply catalog", func() {
+
+		var (
+			catalog   *Catalog
+			retiredAt time.Time
+		)
+
+		BeforeEach(func() {
+			retiredAt = time.Now().AddDate(0, -1, 0)
+
+			catalog = &Catalog{
+				Version: 1,
+				Badges: []CatalogBadge{
+					{ID: 3, Name: "New child", Parent: 4, Exp: 300},
+					{ID: -1, Name: "chest"},
+					{ID: 1, Name: "Do five push-ups", Image: "/image/badge/1.svg", Parent: -1, Exp: 150},
+					{ID: 4, Name: "New parent", Parent: -1, Exp: 200},
+					{ID: 5, Name: "Old", Parent: -1, Exp: 100, Retired: true},
+				},
+			}
+		})
+
+		It("CASE: Successful diff of the catalog", func() {
+
+			mockBadgeDAO.EXPECT().GetBadges(ctxLogger).
+				Times(1).
+				Return([]*badgeDAO.Badge{
+					{ID: -1, Name: "chest"},
+					{ID: 1, Name: "Do five push-ups", Image: "/image/badge/1.svg", ParentBadgeID: -1, Exp: 125},
+					{ID: 5, Name: "Old", ParentBadgeID: -1, Exp: 100, Position: 2, RetiredAt: &retiredAt},
+					{ID: 9, Name: "Manual"},
+				}, nil)
+
+			diff, err := service.DiffCatalog(catalog, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(diff.Empty()).To(BeFalse())
+
+			// Parents first
+			Expect(len(diff.Created)).To(Equal(2))
+			Expect(diff.Created[0].ID).To(Equal(int16(4)))
+			Expect(diff.Created[0].Position).To(Equal(int16(1)))
+			Expect(diff.Created[1].ID).To(Equal(int16(3)))
+
+			Expect(len(diff.Updated)).To(Equal(1))
+			Expect(diff.Updated[0].ID).To(Equal(int16(1)))
+			Expect(diff.Changes[1]).To(Equal("exp: 125 -> 150"))
+
+			// The retired badge keeps its date
+			Expect(diff.Unchanged).To(Equal(2))
+
+			Expect(len(diff.Undeclared)).To(Equal(1))
+			Expect(diff.Undeclared[0].ID).To(Equal(int16(9)))
+
+			Expect(diff.String()).To(Equal(`+ 4 "New parent"
++ 3 "New child"
+~ 1 "Do five push-ups": exp: 125 -> 150
+? 9 "Manual" is deployed but missing from the catalog
+2 to create, 1 to update, 2 unchanged, 1 missing from the catalog
+`))
+		})
+
+		It("CASE: Successful apply of the catalog", func() {
+
+			diff := &CatalogDiff{
+				Created: []*badgeDAO.Badge{{ID: 4, Name: "New parent", ParentBadgeID: -1, Exp: 200}},
+				Updated: []*badgeDAO.Badge{{ID: 1, Name: "Do five push-ups", Exp: 150}},
+				Changes: map[int16]string{1: "exp: 125 -> 150"},
+			}
+
+			mockBadgeDAO.EXPECT().SeedBadges(diff.Created, diff.Updated, gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(_ []*badgeDAO.Badge, _ []*badgeDAO.Badge, audits []badgeDAO.BadgeAudit, _ *log.Entry) error {
+					Expect(len(audits)).To(Equal(2))
+					Expect(audits[0].Action).To(Equal(badgeDAO.BadgeActionCreate))
+					Expect(audits[0].BadgeID).To(Equal(int16(4)))
+					Expect(audits[1]).To(Equal(badgeDAO.BadgeAudit{BadgeID: 1, AdminID: seedAdminID,
+						Action: badgeDAO.BadgeActionEdit, Changes: "exp: 125 -> 150"}))
+					return nil
+				})
+
+			Expect(service.ApplyCatalog(diff, ctxLogger)).To(Succeed())
+		})
+
+		It("CASE: Applying a deployed catalog changes nothing", func() {
+
+			Expect(service.ApplyCatalog(&CatalogDiff{Unchanged: 5}, ctxLogger)).To(Succeed())
+		})
+
+	})
+
+})
@@ -0,0 +1,143 @@
+package events_service
+
+import (
+	"fmt"
+	configs "gym-badges-api/config/gym-badges-server"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Domain events published by the services
+const (
+	AttendanceAdded   = "attendance_added"
+	FriendAdded       = "friend_added"
+	ExperienceChanged = "experience_changed"
+	RankChanged       = "rank_changed"
+	NewPersonalRecord = "new_personal_record"
+)
+
+// Event Something that happened to a user
+type Event struct {
+	Type   string
+	UserID string
+}
+
+type Handler func(event Event, ctxLog *log.Entry) error
+
+type subscription struct {
+	name    string
+	handler Handler
+}
+
+// delivery is an event for one subscriber, so that a failing subscriber is retried alone
+type delivery struct {
+	event        Event
+	subscription subscription
+	ctxLog       *log.Entry
+	attempt      int
+	delay        time.Duration
+}
+
+func NewEventsService() IEventsService {
+
+	s := &eventsService{
+		subscriptions: make(map[string][]subscription),
+		queue:         make(chan delivery, max(configs.Basic.EventQueueSize, 1)),
+		maxRetries:    configs.Basic.EventMaxRetries,
+		retryDelay:    configs.Basic.EventRetryDelay,
+	}
+
+	for range max(configs.Basic.EventWorkers, 1) {
+		go s.work()
+	}
+
+	return s
+}
+
+type eventsService struct {
+	mutex         sync.RWMutex
+	subscriptions map[string][]subscription
+	queue         chan delivery
+	maxRetries    int
+	retryDelay    time.Duration
+}
+
+func (s *eventsService) Subscribe(name string, handler Handler, eventTypes ...string) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, eventType := range eventTypes {
+		s.subscriptions[eventType] = append(s.subscriptions[eventType], subscription{name: name, handler: handler})
+	}
+}
+
+func (s *eventsService) Publish(event Event, ctxLog *log.Entry) {
+
+	ctxLog.Debugf("EVENTS_SERVICE: Publishing %s for user: %s", event.Type, event.UserID)
+
+	s.mutex.RLock()
+	subscriptions := s.subscriptions[event.Type]
+	s.mutex.RUnlock()
+
+	for _, sub := range subscriptions {
+		s.enqueue(delivery{event: event, subscription: sub, ctxLog: ctxLog, delay: s.retryDelay})
+	}
+}
+
+func (s *eventsService) enqueue(d delivery) {
+	select {
+	case s.queue <- d:
+	default:
+		// The caller is never blocked, a full queue only loses the ordering
+		d.ctxLog.Warnf("EVENTS_SERVICE: Queue full, delivering %s to %s apart", d.event.Type, d.subscription.name)
+		go s.deliver(d)
+	}
+}
+
+func (s *eventsService) work() {
+	for d := range s.queue {
+		s.deliver(d)
+	}
+}
+
+// deliver runs the handler once. A failure is queued again after an exponential backoff, the worker
+// goes on with the other deliveries in the meantime.
+func (s *eventsService) deliver(d delivery) {
+
+	err := s.run(d)
+	if err == nil {
+		return
+	}
+
+	if d.attempt >= s.maxRetries {
+		d.ctxLog.Errorf("EVENTS_SERVICE: %s failed handling %s for user %s after %d attempts: %s",
+			d.subscription.name, d.event.Type, d.event.UserID, d.attempt+1, err)
+		return
+	}
+
+	d.ctxLog.Warnf("EVENTS_SERVICE: %s failed handling %s for user %s, retrying in %s: %s",
+		d.subscription.name, d.event.Type, d.event.UserID, d.delay, err)
+
+	retry := d
+	retry.attempt++
+	retry.delay *= 2
+
+	time.AfterFunc(d.delay, func() {
+		s.enqueue(retry)
+	})
+}
+
+// run keeps a panicking handler from stopping the worker
+func (s *eventsService) run(d delivery) (err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	return d.subscription.handler(d.event, d.ctxLog)
+}
@@ -0,0 +1,12 @@
+package events_service
+
+import (
+	log "github.com/sirupsen/logrus"
+)
+
+type IEventsService interface {
+	// Queues the event for its subscribers without waiting for them
+	Publish(event Event, ctxLog *log.Entry)
+	// Registers the handler for the event types. Handlers run asynchronously and are retried when they fail
+	Subscribe(name string, handler Handler, eventTypes ...string)
+}
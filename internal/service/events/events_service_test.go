@@ -0,0 +1,125 @@
+package events_service
+
+import (
+	"errors"
+	configs "gym-badges-api/config/gym-badges-server"
+	toolsLogging "gym-badges-api/tools/logging"
+	toolsTesting "gym-badges-api/tools/testing"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	log "github.com/sirupsen/logrus"
+)
+
+func TestServiceEventsSuite(t *testing.T) {
+	toolsTesting.ConfigureTestSuite(t, "SERVICE: Events Test Suite")
+}
+
+var _ = Describe("SERVICE: Events Test Suite", func() {
+
+	var (
+		ctxLogger *log.Entry
+		service   IEventsService
+	)
+
+	BeforeEach(func() {
+		ctxLogger = toolsLogging.BuildLogger()
+
+		configs.Basic.EventWorkers = 2
+		configs.Basic.EventQueueSize = 10
+		configs.Basic.EventMaxRetries = 2
+		configs.Basic.EventRetryDelay = time.Millisecond
+
+		service = NewEventsService()
+	})
+
+	It("CASE: Events are delivered to the subscribers of their type", func() {
+
+		received := make(chan Event, 10)
+
+		service.Subscribe("badges", func(event Event, _ *log.Entry) error {
+			received <- event
+			return nil
+		}, AttendanceAdded, FriendAdded)
+
+		service.Publish(Event{Type: AttendanceAdded, UserID: "admin"}, ctxLogger)
+		service.Publish(Event{Type: RankChanged, UserID: "admin"}, ctxLogger)
+		service.Publish(Event{Type: FriendAdded, UserID: "friend"}, ctxLogger)
+
+		// The workers do not keep the order
+		events := make([]Event, 2)
+		Eventually(received).Should(Receive(&events[0]))
+		Eventually(received).Should(Receive(&events[1]))
+		Expect(events).To(ConsistOf(Event{Type: AttendanceAdded, UserID: "admin"}, Event{Type: FriendAdded, UserID: "friend"}))
+		Consistently(received, 20*time.Millisecond).ShouldNot(Receive())
+	})
+
+	It("CASE: Failed handlers are retried", func() {
+
+		var attempts atomic.Int32
+
+		service.Subscribe("badges", func(_ Event, _ *log.Entry) error {
+			if attempts.Add(1) < 3 {
+				return errors.New("database error")
+			}
+			return nil
+		}, ExperienceChanged)
+
+		service.Publish(Event{Type: ExperienceChanged, UserID: "admin"}, ctxLogger)
+
+		Eventually(attempts.Load).Should(Equal(int32(3)))
+		Consistently(attempts.Load, 20*time.Millisecond).Should(Equal(int32(3)))
+	})
+
+	It("CASE: Handlers stop being retried after the last retry, and panics are handled as failures", func() {
+
+		var attempts, delivered atomic.Int32
+
+		service.Subscribe("failing", func(_ Event, _ *log.Entry) error {
+			attempts.Add(1)
+			panic("nil pointer")
+		}, ExperienceChanged)
+
+		// A failing subscriber does not affect the others
+		service.Subscribe("ranks", func(_ Event, _ *log.Entry) error {
+			delivered.Add(1)
+			return nil
+		}, ExperienceChanged)
+
+		service.Publish(Event{Type: ExperienceChanged, UserID: "admin"}, ctxLogger)
+
+		Eventually(attempts.Load).Should(Equal(int32(3)))
+		Consistently(attempts.Load, 20*time.Millisecond).Should(Equal(int32(3)))
+		Expect(delivered.Load()).To(Equal(int32(1)))
+	})
+
+	It("CASE: A handler waiting for its retry does not stall the queue", func() {
+
+		configs.Basic.EventWorkers = 1
+		configs.Basic.EventRetryDelay = time.Hour
+		service = NewEventsService()
+
+		var attempts atomic.Int32
+		service.Subscribe("failing", func(_ Event, _ *log.Entry) error {
+			attempts.Add(1)
+			return errors.New("database error")
+		}, ExperienceChanged)
+
+		received := make(chan Event, 10)
+		service.Subscribe("badges", func(event Event, _ *log.Entry) error {
+			received <- event
+			return nil
+		}, AttendanceAdded)
+
+		service.Publish(Event{Type: ExperienceChanged, UserID: "admin"}, ctxLogger)
+		Eventually(attempts.Load).Should(Equal(int32(1)))
+
+		// The only worker is free while the retry waits
+		service.Publish(Event{Type: AttendanceAdded, UserID: "admin"}, ctxLogger)
+		Eventually(received).Should(Receive(Equal(Event{Type: AttendanceAdded, UserID: "admin"})))
+		Expect(attempts.Load()).To(Equal(int32(1)))
+	})
+})
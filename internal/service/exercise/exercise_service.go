@@ -0,0 +1,137 @@
+package exercise_service
+
+import (
+	"errors"
+	configs "gym-badges-api/config/gym-badges-server"
+	"gym-badges-api/internal/constants"
+	customErrors "gym-badges-api/internal/custom-errors"
+	exerciseDAO "gym-badges-api/internal/repository/exercise"
+	"gym-badges-api/models"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// Badge trees of the muscle groups are rooted at -1 (chest) ... -5 (core)
+	firstMuscleCategory = -5
+	lastMuscleCategory  = -1
+)
+
+func NewExerciseService(exerciseDAO exerciseDAO.IExerciseDAO) IExerciseService {
+	return &exerciseService{
+		ExerciseDAO: exerciseDAO,
+	}
+}
+
+type exerciseService struct {
+	ExerciseDAO exerciseDAO.IExerciseDAO
+}
+
+func (s exerciseService) SearchExercises(userID string, name string, muscleGroup string, equipment string,
+	category int32, page int32, ctxLog *log.Entry) (*models.ExercisesResponse, error) {
+
+	ctxLog.Debugf("EXERCISE_SERVICE: Processing SearchExercises request for user: %s", userID)
+
+	offset := (page - 1) * configs.Basic.ExercisesPageSize
+	size := configs.Basic.ExercisesPageSize
+
+	filter := exerciseDAO.ExerciseFilter{
+		Name:            strings.TrimSpace(name),
+		MuscleGroup:     muscleGroup,
+		Equipment:       equipment,
+		BadgeCategoryID: int16(category),
+	}
+
+	exercises, err := s.ExerciseDAO.SearchExercises(userID, filter, offset, size, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	response := models.ExercisesResponse{
+		Exercises: make([]*models.Exercise, len(exercises)),
+	}
+
+	for i, exercise := range exercises {
+		response.Exercises[i] = mapExercise(exercise)
+	}
+
+	return &response, nil
+}
+
+func (s exerciseService) GetExercise(userID string, exerciseID int64, ctxLog *log.Entry) (*models.Exercise, error) {
+
+	ctxLog.Debugf("EXERCISE_SERVICE: Processing GetExercise request for user: %s exercise: %d", userID, exerciseID)
+
+	exercise, err := s.ExerciseDAO.GetExercise(userID, exerciseID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapExercise(exercise), nil
+}
+
+func (s exerciseService) CreateExercise(userID string, request *models.CreateExerciseRequest,
+	ctxLog *log.Entry) (*models.Exercise, error) {
+
+	ctxLog.Debugf("EXERCISE_SERVICE: Processing CreateExercise request for user: %s", userID)
+
+	name := strings.TrimSpace(request.Name)
+
+	switch {
+	case name == constants.EmptyString:
+		return nil, customErrors.BuildBadRequestError("Exercise name cannot be empty.")
+	case len(request.MuscleGroups) == 0:
+		return nil, customErrors.BuildBadRequestError("At least one muscle group is required.")
+	case request.Category < firstMuscleCategory || request.Category > lastMuscleCategory:
+		return nil, customErrors.BuildBadRequestError("Category must be a muscle badge category.")
+	}
+
+	exerciseInDB, err := s.ExerciseDAO.GetExerciseByName(userID, name, ctxLog)
+	if err != nil && !errors.As(err, &customErrors.NotFoundError{}) {
+		return nil, err
+	}
+
+	if exerciseInDB != nil {
+		return nil, customErrors.BuildConflictError("exercise %s already exists", exerciseInDB.Name)
+	}
+
+	exercise := exerciseDAO.Exercise{
+		Name:            name,
+		MuscleGroups:    request.MuscleGroups,
+		Equipment:       request.Equipment,
+		BadgeCategoryID: int16(request.Category),
+		CreatorID:       &userID,
+	}
+
+	if err = s.ExerciseDAO.CreateExercise(&exercise, ctxLog); err != nil {
+		return nil, err
+	}
+
+	return mapExercise(&exercise), nil
+}
+
+func (s exerciseService) DeleteExercise(userID string, exerciseID int64, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("EXERCISE_SERVICE: Processing DeleteExercise request for user: %s exercise: %d", userID, exerciseID)
+
+	return s.ExerciseDAO.DeleteExercise(userID, exerciseID, ctxLog)
+}
+
+func mapExercise(exercise *exerciseDAO.Exercise) *models.Exercise {
+
+	response := models.Exercise{
+		ID:           exercise.ID,
+		Name:         exercise.Name,
+		MuscleGroups: exercise.MuscleGroups,
+		Equipment:    exercise.Equipment,
+		Category:     int32(exercise.BadgeCategoryID),
+		Custom:       exercise.CreatorID != nil,
+	}
+
+	if exercise.BadgeCategory != nil {
+		response.CategoryName = exercise.BadgeCategory.Name
+	}
+
+	return &response
+}
@@ -0,0 +1,16 @@
+package exercise_service
+
+import (
+	"gym-badges-api/models"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type IExerciseService interface {
+	// Empty filters are not applied. A category of 0 means any category
+	SearchExercises(userID string, name string, muscleGroup string, equipment string, category int32, page int32,
+		ctxLog *log.Entry) (*models.ExercisesResponse, error)
+	GetExercise(userID string, exerciseID int64, ctxLog *log.Entry) (*models.Exercise, error)
+	CreateExercise(userID string, request *models.CreateExerciseRequest, ctxLog *log.Entry) (*models.Exercise, error)
+	DeleteExercise(userID string, exerciseID int64, ctxLog *log.Entry) error
+}
@@ -0,0 +1,207 @@
+package exercise_service
+
+import (
+	configs "gym-badges-api/config/gym-badges-server"
+	customErrors "gym-badges-api/internal/custom-errors"
+	badgeDAO "gym-badges-api/internal/repository/badge"
+	exerciseDAO "gym-badges-api/internal/repository/exercise"
+	mockDAO "gym-badges-api/mocks/dao"
+	"gym-badges-api/models"
+	toolsLogging "gym-badges-api/tools/logging"
+	toolsTesting "gym-badges-api/tools/testing"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	log "github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+)
+
+func TestServiceExerciseSuite(t *testing.T) {
+	toolsTesting.ConfigureTestSuite(t, "SERVICE: Exercise Test Suite")
+}
+
+var _ = Describe("SERVICE: Exercise Test Suite", func() {
+
+	var (
+		mockCtrl        *gomock.Controller
+		mockExerciseDAO *mockDAO.MockIExerciseDAO
+		service         IExerciseService
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockExerciseDAO = mockDAO.NewMockIExerciseDAO(mockCtrl)
+		service = NewExerciseService(mockExerciseDAO)
+	})
+
+	AfterEach(func() {
+		defer mockCtrl.Finish()
+	})
+
+	Context("Search Exercises", func() {
+
+		var (
+			ctxLogger *log.Entry
+			userID    string
+			exercises []*exerciseDAO.Exercise
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+
+			userID = "admin"
+			configs.Basic.ExercisesPageSize = 20
+
+			exercises = []*exerciseDAO.Exercise{
+				{
+					ID:              2,
+					Name:            "Bench press",
+					MuscleGroups:    []string{"chest", "triceps"},
+					Equipment:       "barbell",
+					BadgeCategoryID: -1,
+					BadgeCategory:   &badgeDAO.Badge{ID: -1, Name: "chest"},
+				},
+				{
+					ID:              40,
+					Name:            "Bench press with pause",
+					MuscleGroups:    []string{"chest"},
+					Equipment:       "barbell",
+					BadgeCategoryID: -1,
+					CreatorID:       &userID,
+				},
+			}
+		})
+
+		It("CASE: Successful search exercises", func() {
+
+			filter := exerciseDAO.ExerciseFilter{
+				Name:            "bench",
+				Equipment:       "barbell",
+				BadgeCategoryID: -1,
+			}
+
+			mockExerciseDAO.EXPECT().SearchExercises(userID, filter, int32(20), int32(20), ctxLogger).
+				Times(1).
+				Return(exercises, nil)
+
+			response, err := service.SearchExercises(userID, " bench ", "", "barbell", -1, 2, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(len(response.Exercises)).To(Equal(2))
+			Expect(response.Exercises[0].Name).To(Equal("Bench press"))
+			Expect(response.Exercises[0].Category).To(Equal(int32(-1)))
+			Expect(response.Exercises[0].CategoryName).To(Equal("chest"))
+			Expect(response.Exercises[0].Custom).To(BeFalse())
+			Expect(response.Exercises[1].Custom).To(BeTrue())
+		})
+
+		It("CASE: Successful search without results", func() {
+
+			mockExerciseDAO.EXPECT().SearchExercises(userID, gomock.Any(), int32(0), int32(20), ctxLogger).
+				Times(1).
+				Return([]*exerciseDAO.Exercise{}, nil)
+
+			response, err := service.SearchExercises(userID, "", "", "", 0, 1, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(len(response.Exercises)).To(Equal(0))
+		})
+
+	})
+
+	Context("Create Exercise", func() {
+
+		var (
+			ctxLogger *log.Entry
+			userID    string
+			request   *models.CreateExerciseRequest
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+
+			userID = "admin"
+
+			request = &models.CreateExerciseRequest{
+				Name:         " Bench press with pause ",
+				MuscleGroups: []string{"chest"},
+				Equipment:    "barbell",
+				Category:     -1,
+			}
+		})
+
+		It("CASE: Successful create exercise", func() {
+
+			mockExerciseDAO.EXPECT().GetExerciseByName(userID, "Bench press with pause", ctxLogger).
+				Times(1).
+				Return(nil, customErrors.BuildNotFoundError("not found"))
+
+			mockExerciseDAO.EXPECT().CreateExercise(gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(exercise *exerciseDAO.Exercise, _ *log.Entry) error {
+					Expect(*exercise.CreatorID).To(Equal(userID))
+					Expect(exercise.BadgeCategoryID).To(Equal(int16(-1)))
+					exercise.ID = 40
+					return nil
+				})
+
+			response, err := service.CreateExercise(userID, request, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response.ID).To(Equal(int64(40)))
+			Expect(response.Name).To(Equal("Bench press with pause"))
+			Expect(response.Custom).To(BeTrue())
+		})
+
+		It("CASE: Create exercise failed cause it already exists", func() {
+
+			mockExerciseDAO.EXPECT().GetExerciseByName(userID, "Bench press with pause", ctxLogger).
+				Times(1).
+				Return(&exerciseDAO.Exercise{ID: 40, Name: "Bench press with pause"}, nil)
+
+			response, err := service.CreateExercise(userID, request, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.ConflictError{}))
+			Expect(response).To(BeNil())
+		})
+
+		It("CASE: Create exercise failed cause of an invalid request", func() {
+
+			request.Category = -6
+
+			response, err := service.CreateExercise(userID, request, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.BadRequestError{}))
+			Expect(response).To(BeNil())
+
+			request.Category = -1
+			request.MuscleGroups = nil
+
+			response, err = service.CreateExercise(userID, request, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.BadRequestError{}))
+			Expect(response).To(BeNil())
+		})
+
+	})
+
+	Context("Delete Exercise", func() {
+
+		var (
+			ctxLogger *log.Entry
+			userID    string
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+			userID = "admin"
+		})
+
+		It("CASE: Delete exercise failed cause it is not a custom exercise", func() {
+
+			mockExerciseDAO.EXPECT().DeleteExercise(userID, int64(2), ctxLogger).
+				Times(1).
+				Return(customErrors.BuildNotFoundError("not found"))
+
+			err := service.DeleteExercise(userID, 2, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.NotFoundError{}))
+		})
+
+	})
+
+})
@@ -0,0 +1,148 @@
+package exports_service
+
+import (
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	configs "gym-badges-api/config/gym-badges-server"
+	"gym-badges-api/internal/constants"
+	customErrors "gym-badges-api/internal/custom-errors"
+	userDAO "gym-badges-api/internal/repository/user"
+	"gym-badges-api/models"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	DataWeight     = "weight"
+	DataFat        = "fat"
+	DataAttendance = "attendance"
+
+	calendarTokenBytes = 32
+)
+
+func NewExportService(userDAO userDAO.IUserDAO) IExportService {
+	return &exportService{
+		UserDAO: userDAO,
+	}
+}
+
+type exportService struct {
+	UserDAO userDAO.IUserDAO
+}
+
+// *******************************************************************
+// CSV
+// *******************************************************************
+
+func (s exportService) ExportCSV(userID string, data string, from time.Time, to time.Time,
+	ctxLog *log.Entry) (string, error) {
+
+	ctxLog.Debugf("EXPORT_SERVICE: Processing ExportCSV request for user: %s data: %s", userID, data)
+
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return constants.EmptyString, customErrors.BuildBadRequestError("The range cannot end before it starts.")
+	}
+
+	user, err := s.UserDAO.GetUserWithHistoryBetween(userID, from, to, ctxLog)
+	if err != nil {
+		return constants.EmptyString, err
+	}
+
+	// The headers are the ones of the CSV import, so an export can be imported again
+	var records [][]string
+
+	switch data {
+	case DataWeight:
+		records = append(records, []string{"date", "weight"})
+		for _, weight := range user.WeightHistory {
+			records = append(records, []string{weight.Date.Format(constants.ISODateLayout), formatValue(weight.Weight)})
+		}
+	case DataFat:
+		records = append(records, []string{"date", "body_fat"})
+		for _, fat := range user.FatHistory {
+			records = append(records, []string{fat.Date.Format(constants.ISODateLayout), formatValue(fat.Fat)})
+		}
+	case DataAttendance:
+		records = append(records, []string{"date", "attendance"})
+		for _, attendance := range user.GymAttendance {
+			records = append(records, []string{attendance.Date.Format(constants.ISODateLayout), "1"})
+		}
+	default:
+		return constants.EmptyString, customErrors.BuildBadRequestError("Unknown export data %s.", data)
+	}
+
+	var builder strings.Builder
+
+	writer := csv.NewWriter(&builder)
+	if err := writer.WriteAll(records); err != nil {
+		return constants.EmptyString, err
+	}
+
+	return builder.String(), nil
+}
+
+func formatValue(value float32) string {
+	return strconv.FormatFloat(float64(value), 'f', -1, 32)
+}
+
+// *******************************************************************
+// CALENDAR
+// *******************************************************************
+
+func (s exportService) GetCalendarSubscription(userID string, ctxLog *log.Entry) (*models.CalendarSubscription, error) {
+
+	ctxLog.Debugf("EXPORT_SERVICE: Processing GetCalendarSubscription request for user: %s", userID)
+
+	user, err := s.UserDAO.GetUser(userID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.CalendarToken != nil {
+		return mapSubscription(*user.CalendarToken), nil
+	}
+
+	return s.ResetCalendarSubscription(userID, ctxLog)
+}
+
+func (s exportService) ResetCalendarSubscription(userID string, ctxLog *log.Entry) (*models.CalendarSubscription, error) {
+
+	ctxLog.Debugf("EXPORT_SERVICE: Processing ResetCalendarSubscription request for user: %s", userID)
+
+	secret := make([]byte, calendarTokenBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	token := hex.EncodeToString(secret)
+
+	if err := s.UserDAO.SetCalendarToken(userID, token, ctxLog); err != nil {
+		return nil, err
+	}
+
+	return mapSubscription(token), nil
+}
+
+func (s exportService) GetAttendanceCalendar(token string, ctxLog *log.Entry) (string, error) {
+
+	ctxLog.Debugf("EXPORT_SERVICE: Processing GetAttendanceCalendar request")
+
+	// Unknown and replaced tokens are not found
+	user, err := s.UserDAO.GetUserByCalendarToken(token, ctxLog)
+	if err != nil {
+		return constants.EmptyString, err
+	}
+
+	return attendanceCalendar(user), nil
+}
+
+func mapSubscription(token string) *models.CalendarSubscription {
+	return &models.CalendarSubscription{
+		URL: fmt.Sprintf("%s/calendar/%s/attendance.ics", strings.TrimSuffix(configs.Basic.PublicURL, "/"), token),
+	}
+}
@@ -0,0 +1,17 @@
+package exports_service
+
+import (
+	"gym-badges-api/models"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type IExportService interface {
+	// Zero dates do not bound the range
+	ExportCSV(userID string, data string, from time.Time, to time.Time, ctxLog *log.Entry) (string, error)
+
+	GetCalendarSubscription(userID string, ctxLog *log.Entry) (*models.CalendarSubscription, error)
+	ResetCalendarSubscription(userID string, ctxLog *log.Entry) (*models.CalendarSubscription, error)
+	GetAttendanceCalendar(token string, ctxLog *log.Entry) (string, error)
+}
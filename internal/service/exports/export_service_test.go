@@ -0,0 +1,241 @@
+package exports_service
+
+import (
+	"errors"
+	configs "gym-badges-api/config/gym-badges-server"
+	customErrors "gym-badges-api/internal/custom-errors"
+	userDAO "gym-badges-api/internal/repository/user"
+	mockDAO "gym-badges-api/mocks/dao"
+	toolsLogging "gym-badges-api/tools/logging"
+	toolsTesting "gym-badges-api/tools/testing"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	log "github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+)
+
+func TestServiceExportSuite(t *testing.T) {
+	toolsTesting.ConfigureTestSuite(t, "SERVICE: Export Test Suite")
+}
+
+var _ = Describe("SERVICE: Export Test Suite", func() {
+
+	var (
+		mockCtrl    *gomock.Controller
+		mockUserDAO *mockDAO.MockIUserDAO
+		service     IExportService
+		ctxLogger   *log.Entry
+		userID      string
+		user        userDAO.User
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockUserDAO = mockDAO.NewMockIUserDAO(mockCtrl)
+		service = NewExportService(mockUserDAO)
+
+		ctxLogger = toolsLogging.BuildLogger()
+		userID = "admin"
+
+		configs.Basic.PublicURL = "https://gym-badges.test/"
+
+		user = userDAO.User{
+			ID:   userID,
+			Name: "Admin, the first",
+			GymAttendance: []userDAO.GymAttendance{
+				{UserID: userID, Date: parseTime("2024-03-04T00:00:00"), CreatedAt: parseTime("2024-03-04T18:30:00")},
+				{UserID: userID, Date: parseTime("2024-03-06T00:00:00")},
+			},
+			WeightHistory: []userDAO.WeightHistory{
+				{UserID: userID, Date: parseTime("2024-03-01T00:00:00"), Weight: 80.5},
+				{UserID: userID, Date: parseTime("2024-03-08T00:00:00"), Weight: 80},
+			},
+			FatHistory: []userDAO.FatHistory{
+				{UserID: userID, Date: parseTime("2024-03-01T00:00:00"), Fat: 18.2},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		defer mockCtrl.Finish()
+	})
+
+	Context("Export CSV", func() {
+
+		It("CASE: Export the weight history in the import format", func() {
+
+			from := parseTime("2024-03-01T00:00:00")
+			to := parseTime("2024-03-31T00:00:00")
+
+			mockUserDAO.EXPECT().GetUserWithHistoryBetween(userID, from, to, ctxLogger).Times(1).Return(&user, nil)
+
+			response, err := service.ExportCSV(userID, DataWeight, from, to, ctxLogger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response).To(Equal("date,weight\n2024-03-01,80.5\n2024-03-08,80\n"))
+		})
+
+		It("CASE: Export the body fat history", func() {
+
+			mockUserDAO.EXPECT().GetUserWithHistoryBetween(userID, time.Time{}, time.Time{}, ctxLogger).Times(1).Return(&user, nil)
+
+			response, err := service.ExportCSV(userID, DataFat, time.Time{}, time.Time{}, ctxLogger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response).To(Equal("date,body_fat\n2024-03-01,18.2\n"))
+		})
+
+		It("CASE: Export the attendance", func() {
+
+			mockUserDAO.EXPECT().GetUserWithHistoryBetween(userID, time.Time{}, time.Time{}, ctxLogger).Times(1).Return(&user, nil)
+
+			response, err := service.ExportCSV(userID, DataAttendance, time.Time{}, time.Time{}, ctxLogger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response).To(Equal("date,attendance\n2024-03-04,1\n2024-03-06,1\n"))
+		})
+
+		It("CASE: Export an empty history only writes the header", func() {
+
+			mockUserDAO.EXPECT().GetUserWithHistoryBetween(userID, time.Time{}, time.Time{}, ctxLogger).Times(1).
+				Return(&userDAO.User{ID: userID}, nil)
+
+			response, err := service.ExportCSV(userID, DataWeight, time.Time{}, time.Time{}, ctxLogger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response).To(Equal("date,weight\n"))
+		})
+
+		It("CASE: Range ending before it starts", func() {
+
+			mockUserDAO.EXPECT().GetUserWithHistoryBetween(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+			_, err := service.ExportCSV(userID, DataWeight, parseTime("2024-03-31T00:00:00"),
+				parseTime("2024-03-01T00:00:00"), ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+		})
+
+		It("CASE: Unknown data", func() {
+
+			mockUserDAO.EXPECT().GetUserWithHistoryBetween(userID, time.Time{}, time.Time{}, ctxLogger).Times(1).Return(&user, nil)
+
+			_, err := service.ExportCSV(userID, "height", time.Time{}, time.Time{}, ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+		})
+
+		It("CASE: User not found", func() {
+
+			mockUserDAO.EXPECT().GetUserWithHistoryBetween(userID, time.Time{}, time.Time{}, ctxLogger).Times(1).
+				Return(nil, customErrors.BuildNotFoundError("not found"))
+
+			_, err := service.ExportCSV(userID, DataWeight, time.Time{}, time.Time{}, ctxLogger)
+			Expect(errors.As(err, &customErrors.NotFound)).To(BeTrue())
+		})
+
+	})
+
+	Context("Calendar subscription", func() {
+
+		It("CASE: The existing token is reused", func() {
+
+			token := "secret"
+			user.CalendarToken = &token
+
+			mockUserDAO.EXPECT().GetUser(userID, ctxLogger).Times(1).Return(&user, nil)
+			mockUserDAO.EXPECT().SetCalendarToken(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+			response, err := service.GetCalendarSubscription(userID, ctxLogger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.URL).To(Equal("https://gym-badges.test/calendar/secret/attendance.ics"))
+		})
+
+		It("CASE: A token is created on the first request", func() {
+
+			var saved string
+
+			mockUserDAO.EXPECT().GetUser(userID, ctxLogger).Times(1).Return(&user, nil)
+			mockUserDAO.EXPECT().SetCalendarToken(userID, gomock.Any(), ctxLogger).Times(1).
+				DoAndReturn(func(userID string, token string, ctxLog *log.Entry) error {
+					saved = token
+					return nil
+				})
+
+			response, err := service.GetCalendarSubscription(userID, ctxLogger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(saved).To(HaveLen(2 * calendarTokenBytes))
+			Expect(response.URL).To(Equal("https://gym-badges.test/calendar/" + saved + "/attendance.ics"))
+		})
+
+		It("CASE: Reset replaces the token", func() {
+
+			token := "secret"
+			user.CalendarToken = &token
+
+			mockUserDAO.EXPECT().SetCalendarToken(userID, gomock.Not("secret"), ctxLogger).Times(1).Return(nil)
+
+			response, err := service.ResetCalendarSubscription(userID, ctxLogger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.URL).ToNot(ContainSubstring("/secret/"))
+		})
+
+		It("CASE: User not found", func() {
+
+			mockUserDAO.EXPECT().GetUser(userID, ctxLogger).Times(1).Return(nil, customErrors.BuildNotFoundError("not found"))
+
+			_, err := service.GetCalendarSubscription(userID, ctxLogger)
+			Expect(errors.As(err, &customErrors.NotFound)).To(BeTrue())
+		})
+
+	})
+
+	Context("Attendance calendar", func() {
+
+		It("CASE: An all day event per attendance", func() {
+
+			mockUserDAO.EXPECT().GetUserByCalendarToken("secret", ctxLogger).Times(1).Return(&user, nil)
+
+			response, err := service.GetAttendanceCalendar("secret", ctxLogger)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(response).To(HavePrefix("BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+			Expect(response).To(HaveSuffix("END:VCALENDAR\r\n"))
+			Expect(response).To(ContainSubstring("X-WR-CALNAME:Gym Badges - Admin\\, the first\r\n"))
+			Expect(strings.Count(response, "BEGIN:VEVENT")).To(Equal(2))
+			Expect(response).To(ContainSubstring("UID:admin-20240304@gym-badges\r\n" +
+				"DTSTAMP:20240304T183000Z\r\n" +
+				"DTSTART;VALUE=DATE:20240304\r\n" +
+				"DTEND;VALUE=DATE:20240305\r\n"))
+			Expect(response).To(ContainSubstring("DTSTAMP:20240306T000000Z\r\n"))
+		})
+
+		It("CASE: Unknown token", func() {
+
+			mockUserDAO.EXPECT().GetUserByCalendarToken("replaced", ctxLogger).Times(1).
+				Return(nil, customErrors.BuildNotFoundError("not found"))
+
+			_, err := service.GetAttendanceCalendar("replaced", ctxLogger)
+			Expect(errors.As(err, &customErrors.NotFound)).To(BeTrue())
+		})
+
+		It("CASE: Long lines are folded without breaking characters", func() {
+
+			line := "X-WR-CALNAME:" + strings.Repeat("é", 40)
+
+			folded := strings.Split(foldLine(line), "\r\n")
+			Expect(folded).To(HaveLen(2))
+			Expect(len(folded[0])).To(BeNumerically("<=", icalMaxLineLength))
+			Expect(folded[1]).To(HavePrefix(" "))
+			Expect(folded[0] + strings.TrimPrefix(folded[1], " ")).To(Equal(line))
+		})
+
+	})
+
+})
+
+func parseTime(dateStr string) time.Time {
+	parsedTime, err := time.Parse("2006-01-02T15:04:05", dateStr)
+	if err != nil {
+		Fail("Failed to parse date: "+dateStr, 1)
+	}
+	return parsedTime
+}
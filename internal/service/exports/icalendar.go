@@ -0,0 +1,80 @@
+package exports_service
+
+import (
+	"fmt"
+	userDAO "gym-badges-api/internal/repository/user"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	icalDateLayout     = "20060102"
+	icalDateTimeLayout = "20060102T150405Z"
+
+	// Lines end with CRLF and longer lines are folded, as required by RFC 5545
+	icalLineEnd       = "\r\n"
+	icalMaxLineLength = 75
+)
+
+var icalTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+// attendanceCalendar builds an iCalendar with an all day event per gym attendance. The events keep the
+// same UID between requests, so calendar apps update them instead of duplicating them.
+func attendanceCalendar(user *userDAO.User) string {
+
+	var builder strings.Builder
+
+	line := func(format string, args ...any) {
+		builder.WriteString(foldLine(fmt.Sprintf(format, args...)))
+		builder.WriteString(icalLineEnd)
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//Gym Badges//Gym attendance//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:%s", icalTextEscaper.Replace("Gym Badges - "+user.Name))
+
+	for _, attendance := range user.GymAttendance {
+
+		day := attendance.Date
+		stamp := attendance.CreatedAt
+		if stamp.IsZero() {
+			stamp = day
+		}
+
+		line("BEGIN:VEVENT")
+		line("UID:%s-%s@gym-badges", icalTextEscaper.Replace(user.ID), day.Format(icalDateLayout))
+		line("DTSTAMP:%s", stamp.UTC().Format(icalDateTimeLayout))
+		line("DTSTART;VALUE=DATE:%s", day.Format(icalDateLayout))
+		line("DTEND;VALUE=DATE:%s", day.AddDate(0, 0, 1).Format(icalDateLayout))
+		line("SUMMARY:Gym session")
+		line("TRANSP:TRANSPARENT")
+		line("END:VEVENT")
+	}
+
+	line("END:VCALENDAR")
+
+	return builder.String()
+}
+
+// foldLine splits the line in chunks of at most 75 octets without breaking UTF-8 characters, the
+// continuation lines start with a space
+func foldLine(line string) string {
+
+	var builder strings.Builder
+
+	length := 0
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if length+size > icalMaxLineLength {
+			builder.WriteString(icalLineEnd + " ")
+			length = 1
+		}
+		builder.WriteRune(r)
+		length += size
+	}
+
+	return builder.String()
+}
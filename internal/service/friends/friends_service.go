@@ -4,20 +4,23 @@ import (
 	configs "gym-badges-api/config/gym-badges-server"
 	badgeDAO "gym-badges-api/internal/repository/badge"
 	userDAO "gym-badges-api/internal/repository/user"
+	eventsService "gym-badges-api/internal/service/events"
 	"gym-badges-api/models"
 	"gym-badges-api/tools/utils"
 
 	log "github.com/sirupsen/logrus"
 )
 
-func NewFriendsService(userDAO userDAO.IUserDAO) IFriendsService {
+func NewFriendsService(userDAO userDAO.IUserDAO, eventsService eventsService.IEventsService) IFriendsService {
 	return &friendsService{
-		UserDAO: userDAO,
+		UserDAO:       userDAO,
+		eventsService: eventsService,
 	}
 }
 
 type friendsService struct {
-	UserDAO userDAO.IUserDAO
+	UserDAO       userDAO.IUserDAO
+	eventsService eventsService.IEventsService
 }
 
 func (s friendsService) GetFriendsByUserID(userID string, page int32, ctxLog *log.Entry) (*models.FriendsResponse, error) {
@@ -98,6 +101,8 @@ func (s friendsService) AddFriend(userID string, friendID string, ctxLog *log.En
 			if err != nil {
 				return nil, err
 			}
+			s.eventsService.Publish(eventsService.Event{Type: eventsService.FriendAdded, UserID: userID}, ctxLog)
+			s.eventsService.Publish(eventsService.Event{Type: eventsService.FriendAdded, UserID: friendID}, ctxLog)
 			// Delete friend request
 			err = s.UserDAO.DeleteFriendRequest(userID, friendID, ctxLog)
 			if err != nil {
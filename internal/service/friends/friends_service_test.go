@@ -6,7 +6,9 @@ import (
 	customErrors "gym-badges-api/internal/custom-errors"
 	badgeDAO "gym-badges-api/internal/repository/badge"
 	userDAO "gym-badges-api/internal/repository/user"
+	eventsService "gym-badges-api/internal/service/events"
 	mockDAO "gym-badges-api/mocks/dao"
+	mockService "gym-badges-api/mocks/service"
 	toolsLogging "gym-badges-api/tools/logging"
 	toolsTesting "gym-badges-api/tools/testing"
 	"gym-badges-api/tools/utils"
@@ -25,15 +27,17 @@ func TestServiceFriendsSuite(t *testing.T) {
 var _ = Describe("SERVICE: Friends Test Suite", func() {
 
 	var (
-		mockCtrl    *gomock.Controller
-		mockUserDAO *mockDAO.MockIUserDAO
-		service     IFriendsService
+		mockCtrl          *gomock.Controller
+		mockUserDAO       *mockDAO.MockIUserDAO
+		mockEventsService *mockService.MockIEventsService
+		service           IFriendsService
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockUserDAO = mockDAO.NewMockIUserDAO(mockCtrl)
-		service = NewFriendsService(mockUserDAO)
+		mockEventsService = mockService.NewMockIEventsService(mockCtrl)
+		service = NewFriendsService(mockUserDAO, mockEventsService)
 	})
 
 	AfterEach(func() {
@@ -140,6 +144,68 @@ var _ = Describe("SERVICE: Friends Test Suite", func() {
 
 	})
 
+	Context("Add friend", func() {
+
+		var (
+			ctxLogger *log.Entry
+			userID    string
+			friendID  string
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+
+			userID = "admin"
+			friendID = "friend"
+
+			mockUserDAO.EXPECT().CheckFriendship(userID, friendID, ctxLogger).
+				Times(1).
+				Return(false, nil)
+		})
+
+		It("CASE: Accepting a friend request publishes the new friendship for both users", func() {
+
+			mockUserDAO.EXPECT().CheckFriendRequest(userID, friendID, ctxLogger).
+				Times(1).
+				Return(true, nil)
+
+			mockUserDAO.EXPECT().AddFriend(userID, friendID, ctxLogger).
+				Times(1).
+				Return(&userDAO.User{ID: friendID}, nil)
+
+			mockUserDAO.EXPECT().DeleteFriendRequest(userID, friendID, ctxLogger).
+				Times(1).
+				Return(nil)
+
+			mockEventsService.EXPECT().Publish(eventsService.Event{Type: eventsService.FriendAdded, UserID: userID}, ctxLogger).
+				Times(1)
+			mockEventsService.EXPECT().Publish(eventsService.Event{Type: eventsService.FriendAdded, UserID: friendID}, ctxLogger).
+				Times(1)
+
+			response, err := service.AddFriend(userID, friendID, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response.User).To(Equal(friendID))
+		})
+
+		It("CASE: Sending a friend request publishes nothing", func() {
+
+			mockUserDAO.EXPECT().CheckFriendRequest(userID, friendID, ctxLogger).
+				Times(1).
+				Return(false, nil)
+
+			mockUserDAO.EXPECT().AddFriendRequest(userID, friendID, ctxLogger).
+				Times(1).
+				Return(&userDAO.User{ID: friendID}, nil)
+
+			mockEventsService.EXPECT().Publish(gomock.Any(), gomock.Any()).
+				Times(0)
+
+			_, err := service.AddFriend(userID, friendID, ctxLogger)
+			Expect(err).To(BeNil())
+		})
+
+	})
+
 })
 
 func buildFriends(num int) []*userDAO.User {
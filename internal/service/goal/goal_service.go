@@ -0,0 +1,413 @@
+package goal_service
+
+import (
+	configs "gym-badges-api/config/gym-badges-server"
+	"gym-badges-api/internal/constants"
+	customErrors "gym-badges-api/internal/custom-errors"
+	goalDAO "gym-badges-api/internal/repository/goal"
+	userDAO "gym-badges-api/internal/repository/user"
+	workoutDAO "gym-badges-api/internal/repository/workout"
+	eventsService "gym-badges-api/internal/service/events"
+	"gym-badges-api/models"
+	"math"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const maxBodyFat = 100
+
+func NewGoalService(goalDAO goalDAO.IGoalDAO, userDAO userDAO.IUserDAO, workoutDAO workoutDAO.IWorkoutDAO,
+	eventsService eventsService.IEventsService) IGoalService {
+	return &goalService{
+		GoalDAO:       goalDAO,
+		UserDAO:       userDAO,
+		WorkoutDAO:    workoutDAO,
+		eventsService: eventsService,
+	}
+}
+
+type goalService struct {
+	GoalDAO       goalDAO.IGoalDAO
+	UserDAO       userDAO.IUserDAO
+	WorkoutDAO    workoutDAO.IWorkoutDAO
+	eventsService eventsService.IEventsService
+}
+
+// *******************************************************************
+// GOALS
+// *******************************************************************
+
+func (s goalService) GetGoals(userID string, status string, ctxLog *log.Entry) (*models.GoalsResponse, error) {
+
+	ctxLog.Debugf("GOAL_SERVICE: Processing GetGoals request for user: %s", userID)
+
+	// Read only, the goals are checked on the writes of the values they track
+	goals, err := s.GoalDAO.GetGoals(userID, status, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.UserDAO.GetUser(userID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	response := models.GoalsResponse{
+		Goals: make([]*models.Goal, len(goals)),
+	}
+
+	for i, goal := range goals {
+
+		current, err := s.currentValue(user, goal, ctxLog)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Goals[i] = mapGoal(goal, current)
+	}
+
+	return &response, nil
+}
+
+func (s goalService) GetGoal(userID string, goalID int64, ctxLog *log.Entry) (*models.Goal, error) {
+
+	ctxLog.Debugf("GOAL_SERVICE: Processing GetGoal request for user: %s goal: %d", userID, goalID)
+
+	goal, err := s.GoalDAO.GetGoal(userID, goalID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.UserDAO.GetUser(userID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.currentValue(user, goal, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapGoal(goal, current), nil
+}
+
+func (s goalService) CreateGoal(userID string, request *models.GoalRequest, ctxLog *log.Entry) (*models.Goal, error) {
+
+	ctxLog.Debugf("GOAL_SERVICE: Processing CreateGoal request for user: %s", userID)
+
+	goal := goalDAO.Goal{
+		UserID:   userID,
+		Type:     request.Type,
+		Exercise: strings.TrimSpace(request.Exercise),
+		Target:   request.Target,
+		Status:   goalDAO.StatusActive,
+	}
+
+	if request.Deadline != nil {
+		deadline := time.Time(*request.Deadline)
+		goal.Deadline = &deadline
+	}
+
+	if goal.Type != goalDAO.TypeLift {
+		goal.Exercise = constants.EmptyString
+	}
+
+	if err := validateGoal(&goal); err != nil {
+		return nil, err
+	}
+
+	active, err := s.GoalDAO.GetGoals(userID, goalDAO.StatusActive, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, other := range active {
+		if other.Type == goal.Type && strings.EqualFold(other.Exercise, goal.Exercise) {
+			return nil, customErrors.BuildConflictError("There is already an active %s goal.", describeGoal(&goal))
+		}
+	}
+
+	user, err := s.UserDAO.GetUser(userID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.currentValue(user, &goal, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case current != nil:
+		goal.StartValue = *current
+	case goal.Type == goalDAO.TypeLift:
+		// Nothing lifted yet
+		goal.StartValue = 0
+	default:
+		return nil, customErrors.BuildBadRequestError("Log your %s before setting a goal on it.", describeGoal(&goal))
+	}
+
+	if goal.StartValue == goal.Target || (goal.Type == goalDAO.TypeLift && goal.StartValue > goal.Target) {
+		return nil, customErrors.BuildBadRequestError("The target of the %s goal is already reached.", describeGoal(&goal))
+	}
+
+	if err := s.GoalDAO.CreateGoal(&goal, ctxLog); err != nil {
+		return nil, err
+	}
+
+	return mapGoal(&goal, current), nil
+}
+
+func (s goalService) EditGoal(userID string, goalID int64, request *models.EditGoalRequest,
+	ctxLog *log.Entry) (*models.Goal, error) {
+
+	ctxLog.Debugf("GOAL_SERVICE: Processing EditGoal request for user: %s goal: %d", userID, goalID)
+
+	goal, err := s.GoalDAO.GetGoal(userID, goalID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	if goal.Status == goalDAO.StatusAchieved {
+		return nil, customErrors.BuildBadRequestError("Achieved goals cannot be changed.")
+	}
+
+	goal.Target = request.Target
+	goal.Deadline = nil
+	if request.Deadline != nil {
+		deadline := time.Time(*request.Deadline)
+		goal.Deadline = &deadline
+	}
+
+	switch request.Status {
+	case goalDAO.StatusActive, goalDAO.StatusAbandoned:
+		goal.Status = request.Status
+	case constants.EmptyString:
+	default:
+		return nil, customErrors.BuildBadRequestError("Status can only be changed to %s or %s.",
+			goalDAO.StatusActive, goalDAO.StatusAbandoned)
+	}
+
+	// The deadline of a goal that is not pursued anymore does not matter
+	if goal.Status == goalDAO.StatusActive {
+		if err := validateGoal(goal); err != nil {
+			return nil, err
+		}
+	}
+
+	if goal.Target == goal.StartValue {
+		return nil, customErrors.BuildBadRequestError("Target cannot be the start value.")
+	}
+
+	if err := s.GoalDAO.EditGoal(goal, ctxLog); err != nil {
+		return nil, err
+	}
+
+	user, err := s.UserDAO.GetUser(userID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.currentValue(user, goal, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	// The new target could be reached already
+	if _, err := s.achieve(goal, current, ctxLog); err != nil {
+		return nil, err
+	}
+
+	return mapGoal(goal, current), nil
+}
+
+func (s goalService) DeleteGoal(userID string, goalID int64, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("GOAL_SERVICE: Processing DeleteGoal request for user: %s goal: %d", userID, goalID)
+
+	// The experience of achieved goals is kept
+	return s.GoalDAO.DeleteGoal(userID, goalID, ctxLog)
+}
+
+// *******************************************************************
+// ACHIEVEMENTS
+// *******************************************************************
+
+func (s goalService) CheckGoals(userID string, ctxLog *log.Entry) ([]int64, error) {
+
+	ctxLog.Debugf("GOAL_SERVICE: Checking active goals of user: %s", userID)
+
+	goals, err := s.GoalDAO.GetGoals(userID, goalDAO.StatusActive, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	achieved := make([]int64, 0)
+
+	if len(goals) == 0 {
+		return achieved, nil
+	}
+
+	user, err := s.UserDAO.GetUser(userID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, goal := range goals {
+
+		current, err := s.currentValue(user, goal, ctxLog)
+		if err != nil {
+			return nil, err
+		}
+
+		done, err := s.achieve(goal, current, ctxLog)
+		if err != nil {
+			return nil, err
+		}
+
+		if done {
+			achieved = append(achieved, goal.ID)
+		}
+	}
+
+	return achieved, nil
+}
+
+// achieve marks an active goal as achieved and grants its experience when the current value reaches the target
+func (s goalService) achieve(goal *goalDAO.Goal, current *float32, ctxLog *log.Entry) (bool, error) {
+
+	if goal.Status != goalDAO.StatusActive || current == nil || !targetReached(goal, *current) {
+		return false, nil
+	}
+
+	now := time.Now()
+
+	achievement := *goal
+	achievement.Status = goalDAO.StatusAchieved
+	achievement.AchievedAt = &now
+	achievement.Exp = configs.Basic.GoalExperience
+
+	// A concurrent check could have achieved it first, the exp is only granted once
+	achieved, err := s.GoalDAO.AchieveGoal(&achievement, ctxLog)
+	if err != nil || !achieved {
+		return false, err
+	}
+
+	*goal = achievement
+
+	s.eventsService.Publish(eventsService.Event{Type: eventsService.ExperienceChanged, UserID: goal.UserID}, ctxLog)
+
+	ctxLog.Infof("GOAL_SERVICE: User %s achieved the %s goal %d", goal.UserID, describeGoal(goal), goal.ID)
+
+	return true, nil
+}
+
+// currentValue returns nil when there is nothing logged for the goal
+func (s goalService) currentValue(user *userDAO.User, goal *goalDAO.Goal, ctxLog *log.Entry) (*float32, error) {
+
+	switch goal.Type {
+	case goalDAO.TypeWeight:
+		return user.Weight, nil
+	case goalDAO.TypeBodyFat:
+		return user.BodyFat, nil
+	}
+
+	records, err := s.WorkoutDAO.GetRecords(user.ID, goal.Exercise, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	var best *float32
+	for _, record := range records {
+		if record.Type == workoutDAO.RecordOneRM && (best == nil || record.Weight > *best) {
+			best = &record.Weight
+		}
+	}
+
+	return best, nil
+}
+
+// *******************************************************************
+// VALIDATION AND MAPPING
+// *******************************************************************
+
+func validateGoal(goal *goalDAO.Goal) error {
+
+	switch goal.Type {
+	case goalDAO.TypeWeight:
+	case goalDAO.TypeBodyFat:
+		if goal.Target >= maxBodyFat {
+			return customErrors.BuildBadRequestError("Body fat target must be under %d%%.", maxBodyFat)
+		}
+	case goalDAO.TypeLift:
+		if goal.Exercise == constants.EmptyString {
+			return customErrors.BuildBadRequestError("Lift goals need an exercise.")
+		}
+	default:
+		return customErrors.BuildBadRequestError("Unknown goal type %s.", goal.Type)
+	}
+
+	if goal.Target <= 0 {
+		return customErrors.BuildBadRequestError("Target must be positive.")
+	}
+
+	if goal.Deadline != nil && goal.Deadline.Before(time.Now().Truncate(24*time.Hour)) {
+		return customErrors.BuildBadRequestError("Deadline cannot be in the past.")
+	}
+
+	return nil
+}
+
+// targetReached checks the target in the direction of the goal, weight and fat goals can be to gain or to lose
+func targetReached(goal *goalDAO.Goal, current float32) bool {
+	if goal.Target >= goal.StartValue {
+		return current >= goal.Target
+	}
+	return current <= goal.Target
+}
+
+func describeGoal(goal *goalDAO.Goal) string {
+	if goal.Type == goalDAO.TypeLift {
+		return goal.Exercise
+	}
+	return strings.ReplaceAll(goal.Type, "_", " ")
+}
+
+func mapGoal(goal *goalDAO.Goal, current *float32) *models.Goal {
+
+	response := models.Goal{
+		ID:           goal.ID,
+		Type:         goal.Type,
+		Exercise:     goal.Exercise,
+		StartValue:   goal.StartValue,
+		CurrentValue: current,
+		Target:       goal.Target,
+		Status:       goal.Status,
+		CreatedAt:    goal.CreatedAt.Format(constants.ISODateLayout),
+		Exp:          goal.Exp,
+	}
+
+	switch {
+	case goal.Status == goalDAO.StatusAchieved:
+		response.Progress = 100
+	case current != nil:
+		progress := (*current - goal.StartValue) / (goal.Target - goal.StartValue) * 100
+		progress = float32(math.Round(float64(progress)*100) / 100)
+		response.Progress = min(max(progress, 0), 100)
+	}
+
+	if goal.Deadline != nil {
+		deadline := goal.Deadline.Format(constants.ISODateLayout)
+		response.Deadline = &deadline
+		response.Overdue = goal.Status == goalDAO.StatusActive && goal.Deadline.Before(time.Now().Truncate(24*time.Hour))
+	}
+
+	if goal.AchievedAt != nil {
+		achievedAt := goal.AchievedAt.Format(constants.ISODateLayout)
+		response.AchievedAt = &achievedAt
+	}
+
+	return &response
+}
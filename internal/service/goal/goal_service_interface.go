@@ -0,0 +1,18 @@
+package goal_service
+
+import (
+	"gym-badges-api/models"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type IGoalService interface {
+	GetGoals(userID string, status string, ctxLog *log.Entry) (*models.GoalsResponse, error)
+	GetGoal(userID string, goalID int64, ctxLog *log.Entry) (*models.Goal, error)
+	CreateGoal(userID string, request *models.GoalRequest, ctxLog *log.Entry) (*models.Goal, error)
+	EditGoal(userID string, goalID int64, request *models.EditGoalRequest, ctxLog *log.Entry) (*models.Goal, error)
+	DeleteGoal(userID string, goalID int64, ctxLog *log.Entry) error
+
+	// Marks the active goals whose target has been reached as achieved and returns their ids
+	CheckGoals(userID string, ctxLog *log.Entry) ([]int64, error)
+}
@@ -0,0 +1,457 @@
+package goal_service
+
+import (
+	"errors"
+	configs "gym-badges-api/config/gym-badges-server"
+	customErrors "gym-badges-api/internal/custom-errors"
+	goalDAO "gym-badges-api/internal/repository/goal"
+	userDAO "gym-badges-api/internal/repository/user"
+	workoutDAO "gym-badges-api/internal/repository/workout"
+	mockDAO "gym-badges-api/mocks/dao"
+	mockService "gym-badges-api/mocks/service"
+	"gym-badges-api/models"
+	toolsLogging "gym-badges-api/tools/logging"
+	toolsTesting "gym-badges-api/tools/testing"
+	"gym-badges-api/tools/utils"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	log "github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+)
+
+func TestServiceGoalSuite(t *testing.T) {
+	toolsTesting.ConfigureTestSuite(t, "SERVICE: Goal Test Suite")
+}
+
+var _ = Describe("SERVICE: Goal Test Suite", func() {
+
+	var (
+		mockCtrl          *gomock.Controller
+		mockGoalDAO       *mockDAO.MockIGoalDAO
+		mockUserDAO       *mockDAO.MockIUserDAO
+		mockWorkoutDAO    *mockDAO.MockIWorkoutDAO
+		mockEventsService *mockService.MockIEventsService
+		service           IGoalService
+		ctxLogger         *log.Entry
+		userID            string
+		user              userDAO.User
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockGoalDAO = mockDAO.NewMockIGoalDAO(mockCtrl)
+		mockUserDAO = mockDAO.NewMockIUserDAO(mockCtrl)
+		mockWorkoutDAO = mockDAO.NewMockIWorkoutDAO(mockCtrl)
+		mockEventsService = mockService.NewMockIEventsService(mockCtrl)
+		service = NewGoalService(mockGoalDAO, mockUserDAO, mockWorkoutDAO, mockEventsService)
+
+		mockEventsService.EXPECT().Publish(gomock.Any(), gomock.Any()).AnyTimes()
+
+		ctxLogger = toolsLogging.BuildLogger()
+		userID = "admin"
+		configs.Basic.GoalExperience = 500
+
+		user = userDAO.User{
+			ID:      "admin",
+			Weight:  utils.NewFloat32(84),
+			BodyFat: utils.NewFloat32(18),
+		}
+	})
+
+	AfterEach(func() {
+		defer mockCtrl.Finish()
+	})
+
+	Context("Get Goals", func() {
+
+		var (
+			goals []*goalDAO.Goal
+		)
+
+		BeforeEach(func() {
+			goals = []*goalDAO.Goal{
+				{ID: 1, UserID: userID, Type: goalDAO.TypeWeight, StartValue: 90, Target: 80, Status: goalDAO.StatusActive},
+				{ID: 2, UserID: userID, Type: goalDAO.TypeLift, Exercise: "Squat", StartValue: 100, Target: 140,
+					Status: goalDAO.StatusAchieved, Exp: 500},
+			}
+		})
+
+		It("CASE: Successful get goals with their progress", func() {
+
+			mockUserDAO.EXPECT().GetUser(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockGoalDAO.EXPECT().GetGoals(userID, "", ctxLogger).
+				Times(1).
+				Return(goals, nil)
+
+			mockWorkoutDAO.EXPECT().GetRecords(userID, "Squat", ctxLogger).
+				Times(1).
+				Return([]*workoutDAO.PersonalRecord{
+					{Exercise: "Squat", Type: workoutDAO.RecordOneRM, Weight: 140},
+					{Exercise: "Squat", Type: workoutDAO.RecordFiveRM, Weight: 150},
+				}, nil)
+
+			// Read only, a reached target is only achieved by the write that reaches it
+			mockGoalDAO.EXPECT().AchieveGoal(gomock.Any(), gomock.Any()).
+				Times(0)
+
+			response, err := service.GetGoals(userID, "", ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(len(response.Goals)).To(Equal(2))
+			Expect(*response.Goals[0].CurrentValue).To(Equal(float32(84)))
+			Expect(response.Goals[0].Progress).To(Equal(float32(60)))
+			Expect(*response.Goals[1].CurrentValue).To(Equal(float32(140)))
+			Expect(response.Goals[1].Progress).To(Equal(float32(100)))
+			Expect(response.Goals[1].Exp).To(Equal(int64(500)))
+		})
+
+		It("CASE: Get goals failed cause user not exist", func() {
+
+			mockGoalDAO.EXPECT().GetGoals(userID, "", ctxLogger).
+				Times(1).
+				Return([]*goalDAO.Goal{}, nil)
+
+			mockUserDAO.EXPECT().GetUser(userID, ctxLogger).
+				Times(1).
+				Return(nil, customErrors.BuildNotFoundError("not found"))
+
+			response, err := service.GetGoals(userID, "", ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.NotFoundError{}))
+			Expect(response).To(BeNil())
+		})
+
+	})
+
+	Context("Create Goal", func() {
+
+		var (
+			request *models.GoalRequest
+		)
+
+		BeforeEach(func() {
+			deadline := strfmt.Date(time.Now().AddDate(0, 3, 0))
+			request = &models.GoalRequest{
+				Type:     goalDAO.TypeWeight,
+				Target:   78,
+				Deadline: &deadline,
+			}
+		})
+
+		It("CASE: Successful create weight goal starting from the current weight", func() {
+
+			mockGoalDAO.EXPECT().GetGoals(userID, goalDAO.StatusActive, ctxLogger).
+				Times(1).
+				Return([]*goalDAO.Goal{}, nil)
+
+			mockUserDAO.EXPECT().GetUser(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockGoalDAO.EXPECT().CreateGoal(gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(goal *goalDAO.Goal, _ *log.Entry) error {
+					Expect(goal.StartValue).To(Equal(float32(84)))
+					Expect(goal.Status).To(Equal(goalDAO.StatusActive))
+					goal.ID = 3
+					return nil
+				})
+
+			response, err := service.CreateGoal(userID, request, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response.ID).To(Equal(int64(3)))
+			Expect(response.Progress).To(Equal(float32(0)))
+			Expect(response.Deadline).ToNot(BeNil())
+			Expect(response.Overdue).To(BeFalse())
+		})
+
+		It("CASE: Successful create lift goal without records", func() {
+
+			request.Type = goalDAO.TypeLift
+			request.Exercise = " Deadlift "
+			request.Target = 200
+
+			mockGoalDAO.EXPECT().GetGoals(userID, goalDAO.StatusActive, ctxLogger).
+				Times(1).
+				Return([]*goalDAO.Goal{}, nil)
+
+			mockUserDAO.EXPECT().GetUser(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockWorkoutDAO.EXPECT().GetRecords(userID, "Deadlift", ctxLogger).
+				Times(1).
+				Return([]*workoutDAO.PersonalRecord{}, nil)
+
+			mockGoalDAO.EXPECT().CreateGoal(gomock.Any(), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			response, err := service.CreateGoal(userID, request, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response.Exercise).To(Equal("Deadlift"))
+			Expect(response.StartValue).To(Equal(float32(0)))
+			Expect(response.CurrentValue).To(BeNil())
+		})
+
+		It("CASE: Create goal failed cause of an invalid request", func() {
+
+			request.Type = goalDAO.TypeLift
+
+			response, err := service.CreateGoal(userID, request, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.BadRequestError{}))
+			Expect(response).To(BeNil())
+
+			request.Type = goalDAO.TypeBodyFat
+			request.Target = 120
+
+			response, err = service.CreateGoal(userID, request, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.BadRequestError{}))
+			Expect(response).To(BeNil())
+
+			past := strfmt.Date(time.Now().AddDate(0, 0, -3))
+			request.Target = 15
+			request.Deadline = &past
+
+			response, err = service.CreateGoal(userID, request, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.BadRequestError{}))
+			Expect(response).To(BeNil())
+		})
+
+		It("CASE: Create goal failed cause there is already an active one", func() {
+
+			mockGoalDAO.EXPECT().GetGoals(userID, goalDAO.StatusActive, ctxLogger).
+				Times(1).
+				Return([]*goalDAO.Goal{{ID: 1, Type: goalDAO.TypeWeight, Status: goalDAO.StatusActive}}, nil)
+
+			response, err := service.CreateGoal(userID, request, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.ConflictError{}))
+			Expect(response).To(BeNil())
+		})
+
+		It("CASE: Create goal failed cause there is no weight logged", func() {
+
+			user.Weight = nil
+
+			mockGoalDAO.EXPECT().GetGoals(userID, goalDAO.StatusActive, ctxLogger).
+				Times(1).
+				Return([]*goalDAO.Goal{}, nil)
+
+			mockUserDAO.EXPECT().GetUser(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			response, err := service.CreateGoal(userID, request, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.BadRequestError{}))
+			Expect(response).To(BeNil())
+		})
+
+		It("CASE: Create goal failed cause the target is already reached", func() {
+
+			request.Target = 84
+
+			mockGoalDAO.EXPECT().GetGoals(userID, goalDAO.StatusActive, ctxLogger).
+				Times(1).
+				Return([]*goalDAO.Goal{}, nil)
+
+			mockUserDAO.EXPECT().GetUser(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			response, err := service.CreateGoal(userID, request, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.BadRequestError{}))
+			Expect(response).To(BeNil())
+		})
+
+	})
+
+	Context("Edit Goal", func() {
+
+		var (
+			goal    goalDAO.Goal
+			request *models.EditGoalRequest
+		)
+
+		BeforeEach(func() {
+			goal = goalDAO.Goal{ID: 1, UserID: userID, Type: goalDAO.TypeBodyFat, StartValue: 22, Target: 15,
+				Status: goalDAO.StatusActive}
+			request = &models.EditGoalRequest{
+				Target: 16,
+			}
+		})
+
+		It("CASE: Successful abandon goal", func() {
+
+			request.Status = goalDAO.StatusAbandoned
+
+			mockGoalDAO.EXPECT().GetGoal(userID, int64(1), ctxLogger).
+				Times(1).
+				Return(&goal, nil)
+
+			mockGoalDAO.EXPECT().EditGoal(gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(edited *goalDAO.Goal, _ *log.Entry) error {
+					Expect(edited.Target).To(Equal(float32(16)))
+					Expect(edited.Status).To(Equal(goalDAO.StatusAbandoned))
+					return nil
+				})
+
+			mockUserDAO.EXPECT().GetUser(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			response, err := service.EditGoal(userID, 1, request, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response.Status).To(Equal(goalDAO.StatusAbandoned))
+			Expect(response.Progress).To(Equal(float32(66.67)))
+		})
+
+		It("CASE: Edit goal failed cause it is already achieved", func() {
+
+			goal.Status = goalDAO.StatusAchieved
+
+			mockGoalDAO.EXPECT().GetGoal(userID, int64(1), ctxLogger).
+				Times(1).
+				Return(&goal, nil)
+
+			response, err := service.EditGoal(userID, 1, request, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.BadRequestError{}))
+			Expect(response).To(BeNil())
+		})
+
+		It("CASE: Edit goal failed cause goal not exist", func() {
+
+			mockGoalDAO.EXPECT().GetGoal(userID, int64(1), ctxLogger).
+				Times(1).
+				Return(nil, customErrors.BuildNotFoundError("not found"))
+
+			response, err := service.EditGoal(userID, 1, request, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.NotFoundError{}))
+			Expect(response).To(BeNil())
+		})
+
+	})
+
+	Context("Check Goals", func() {
+
+		It("CASE: Successful achieve the goals whose target is reached", func() {
+
+			goals := []*goalDAO.Goal{
+				{ID: 1, UserID: userID, Type: goalDAO.TypeWeight, StartValue: 90, Target: 85, Status: goalDAO.StatusActive},
+				{ID: 2, UserID: userID, Type: goalDAO.TypeBodyFat, StartValue: 15, Target: 20, Status: goalDAO.StatusActive},
+				{ID: 3, UserID: userID, Type: goalDAO.TypeLift, Exercise: "Bench press", StartValue: 80, Target: 100,
+					Status: goalDAO.StatusActive},
+			}
+
+			mockGoalDAO.EXPECT().GetGoals(userID, goalDAO.StatusActive, ctxLogger).
+				Times(1).
+				Return(goals, nil)
+
+			mockUserDAO.EXPECT().GetUser(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockWorkoutDAO.EXPECT().GetRecords(userID, "Bench press", ctxLogger).
+				Times(1).
+				Return([]*workoutDAO.PersonalRecord{
+					{Exercise: "Bench press", Type: workoutDAO.RecordOneRM, Weight: 95},
+					{Exercise: "Bench press", Type: workoutDAO.RecordEstimatedEpley, Weight: 105},
+				}, nil)
+
+			mockGoalDAO.EXPECT().AchieveGoal(gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(goal *goalDAO.Goal, _ *log.Entry) (bool, error) {
+					Expect(goal.ID).To(Equal(int64(1)))
+					Expect(goal.Status).To(Equal(goalDAO.StatusAchieved))
+					Expect(goal.Exp).To(Equal(int64(500)))
+					return true, nil
+				})
+
+			achieved, err := service.CheckGoals(userID, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(achieved).To(Equal([]int64{1}))
+			Expect(goals[0].Status).To(Equal(goalDAO.StatusAchieved))
+			Expect(goals[0].AchievedAt).ToNot(BeNil())
+			Expect(goals[1].Status).To(Equal(goalDAO.StatusActive))
+			Expect(goals[2].Status).To(Equal(goalDAO.StatusActive))
+		})
+
+		It("CASE: A goal achieved by a concurrent check is not achieved again", func() {
+
+			goals := []*goalDAO.Goal{
+				{ID: 1, UserID: userID, Type: goalDAO.TypeWeight, StartValue: 90, Target: 85, Status: goalDAO.StatusActive},
+			}
+
+			mockGoalDAO.EXPECT().GetGoals(userID, goalDAO.StatusActive, ctxLogger).
+				Times(1).
+				Return(goals, nil)
+
+			mockUserDAO.EXPECT().GetUser(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			// The goal was not active anymore when it was updated
+			mockGoalDAO.EXPECT().AchieveGoal(gomock.Any(), ctxLogger).
+				Times(1).
+				Return(false, nil)
+
+			achieved, err := service.CheckGoals(userID, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(achieved).To(BeEmpty())
+			Expect(goals[0].Status).To(Equal(goalDAO.StatusActive))
+		})
+
+		It("CASE: Check goals without active goals", func() {
+
+			mockGoalDAO.EXPECT().GetGoals(userID, goalDAO.StatusActive, ctxLogger).
+				Times(1).
+				Return([]*goalDAO.Goal{}, nil)
+
+			achieved, err := service.CheckGoals(userID, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(len(achieved)).To(Equal(0))
+		})
+
+		It("CASE: Check goals failed cause of an unexpected error", func() {
+
+			mockGoalDAO.EXPECT().GetGoals(userID, goalDAO.StatusActive, ctxLogger).
+				Times(1).
+				Return(nil, errors.New("panic"))
+
+			achieved, err := service.CheckGoals(userID, ctxLogger)
+			Expect(err).ToNot(BeNil())
+			Expect(achieved).To(BeNil())
+		})
+
+	})
+
+	Context("Delete Goal", func() {
+
+		It("CASE: Successful delete goal", func() {
+
+			mockGoalDAO.EXPECT().DeleteGoal(userID, int64(1), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			err := service.DeleteGoal(userID, 1, ctxLogger)
+			Expect(err).To(BeNil())
+		})
+
+		It("CASE: Delete goal failed cause goal not exist", func() {
+
+			mockGoalDAO.EXPECT().DeleteGoal(userID, int64(1), ctxLogger).
+				Times(1).
+				Return(customErrors.BuildNotFoundError("not found"))
+
+			err := service.DeleteGoal(userID, 1, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.NotFoundError{}))
+		})
+
+	})
+
+})
@@ -0,0 +1,189 @@
+package gym_service
+
+import (
+	"gym-badges-api/internal/constants"
+	customErrors "gym-badges-api/internal/custom-errors"
+	gymDAO "gym-badges-api/internal/repository/gym"
+	userDAO "gym-badges-api/internal/repository/user"
+	statsService "gym-badges-api/internal/service/stats"
+	"gym-badges-api/models"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	log "github.com/sirupsen/logrus"
+)
+
+func NewGymService(gymDAO gymDAO.IGymDAO, userDAO userDAO.IUserDAO, statsService statsService.IStatsService) IGymService {
+	return &gymService{
+		GymDAO:       gymDAO,
+		UserDAO:      userDAO,
+		statsService: statsService,
+	}
+}
+
+type gymService struct {
+	GymDAO       gymDAO.IGymDAO
+	UserDAO      userDAO.IUserDAO
+	statsService statsService.IStatsService
+}
+
+// *******************************************************************
+// GYMS
+// *******************************************************************
+
+func (s gymService) GetGyms(ctxLog *log.Entry) (*models.GymsResponse, error) {
+
+	ctxLog.Debugf("GYM_SERVICE: Processing GetGyms request")
+
+	gyms, err := s.GymDAO.GetGyms(ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	response := models.GymsResponse{
+		Gyms: make([]*models.Gym, len(gyms)),
+	}
+
+	for i, gym := range gyms {
+		response.Gyms[i] = &models.Gym{
+			ID:        gym.ID,
+			Name:      gym.Name,
+			Latitude:  gym.Latitude,
+			Longitude: gym.Longitude,
+			Radius:    gym.Radius,
+		}
+	}
+
+	return &response, nil
+}
+
+func (s gymService) GetGymQRCode(gymID int64, gymKey string, ctxLog *log.Entry) (*models.GymQrCode, error) {
+
+	ctxLog.Debugf("GYM_SERVICE: Processing GetGymQRCode request for gym: %d", gymID)
+
+	gym, err := s.GymDAO.GetGym(gymID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	if !validGymKey(gym, gymKey) {
+		return nil, customErrors.BuildUnauthorizedError("Wrong key for gym %d.", gymID)
+	}
+
+	code, expiresAt, err := qrCode(gym, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GymQrCode{
+		Code:      code,
+		ExpiresAt: strfmt.DateTime(expiresAt),
+	}, nil
+}
+
+// *******************************************************************
+// CHECK-INS
+// *******************************************************************
+
+func (s gymService) CheckIn(userID string, gymID int64, request *models.CheckInRequest,
+	ctxLog *log.Entry) (*models.VerifiedAttendance, error) {
+
+	ctxLog.Debugf("GYM_SERVICE: Processing CheckIn request for user: %s gym: %d", userID, gymID)
+
+	gym, err := s.GymDAO.GetGym(gymID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	method, nonce, err := verifyCheckIn(gym, request, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// The server decides the day, the client cannot choose it
+	today := now.Truncate(24 * time.Hour)
+
+	verified, err := s.UserDAO.CheckVerifiedAttendance(userID, today, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+	if verified {
+		return nil, customErrors.BuildConflictError("Today is already verified.")
+	}
+
+	// The QR code is spent once it is known to verify the day, a shared or replayed code is rejected
+	if method == userDAO.CheckInMethodQR {
+		use := gymDAO.UsedQRCode{
+			Nonce:  nonce,
+			GymID:  gym.ID,
+			UserID: userID,
+			UsedAt: now,
+		}
+		if err := s.GymDAO.UseQRCode(&use, ctxLog); err != nil {
+			return nil, err
+		}
+	}
+
+	attended, err := s.UserDAO.CheckGymAttendance(userID, today, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	// Days added manually before checking in are kept and become verified
+	if !attended {
+		if err := s.statsService.AddGymAttendance(userID, today, ctxLog); err != nil {
+			return nil, err
+		}
+	}
+
+	attendance := userDAO.VerifiedAttendance{
+		UserID:    userID,
+		Date:      today,
+		GymID:     gym.ID,
+		Method:    method,
+		CheckedAt: now,
+	}
+
+	if err := s.UserDAO.AddVerifiedAttendance(&attendance, ctxLog); err != nil {
+		return nil, err
+	}
+
+	ctxLog.Infof("GYM_SERVICE: User %s checked in at gym %d with %s", userID, gym.ID, method)
+
+	return &models.VerifiedAttendance{
+		Date:      today.Format(constants.ISODateLayout),
+		GymID:     gym.ID,
+		Method:    method,
+		CheckedAt: strfmt.DateTime(now),
+	}, nil
+}
+
+// verifyCheckIn returns the method that proves the check-in, and the nonce of the QR code when it is the
+// proof. The QR code is checked first when both proofs are sent.
+func verifyCheckIn(gym *gymDAO.Gym, request *models.CheckInRequest, now time.Time) (string, string, error) {
+
+	switch {
+	case request.QrCode != constants.EmptyString:
+		nonce, valid := validQRCode(gym, request.QrCode, now)
+		if !valid {
+			return constants.EmptyString, constants.EmptyString,
+				customErrors.BuildForbiddenError("The QR code is not valid for gym %d or it has expired.", gym.ID)
+		}
+		return userDAO.CheckInMethodQR, nonce, nil
+
+	case request.Latitude != nil && request.Longitude != nil:
+		latitude, longitude := *request.Latitude, *request.Longitude
+		if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+			return constants.EmptyString, constants.EmptyString, customErrors.BuildBadRequestError("Coordinates out of range.")
+		}
+		if distance(latitude, longitude, gym.Latitude, gym.Longitude) > gym.Radius {
+			return constants.EmptyString, constants.EmptyString, customErrors.BuildForbiddenError("The location is outside gym %d.", gym.ID)
+		}
+		return userDAO.CheckInMethodGPS, constants.EmptyString, nil
+	}
+
+	return constants.EmptyString, constants.EmptyString,
+		customErrors.BuildBadRequestError("A check-in needs the coordinates or the QR code of the gym.")
+}
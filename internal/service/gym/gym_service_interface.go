@@ -0,0 +1,14 @@
+package gym_service
+
+import (
+	"gym-badges-api/models"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type IGymService interface {
+	GetGyms(ctxLog *log.Entry) (*models.GymsResponse, error)
+	// The key of the gym authenticates its screen
+	GetGymQRCode(gymID int64, gymKey string, ctxLog *log.Entry) (*models.GymQrCode, error)
+	CheckIn(userID string, gymID int64, request *models.CheckInRequest, ctxLog *log.Entry) (*models.VerifiedAttendance, error)
+}
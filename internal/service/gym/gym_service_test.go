@@ -0,0 +1,383 @@
+package gym_service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	configs "gym-badges-api/config/gym-badges-server"
+	"gym-badges-api/internal/constants"
+	customErrors "gym-badges-api/internal/custom-errors"
+	gymDAO "gym-badges-api/internal/repository/gym"
+	userDAO "gym-badges-api/internal/repository/user"
+	mockDAO "gym-badges-api/mocks/dao"
+	mockService "gym-badges-api/mocks/service"
+	"gym-badges-api/models"
+	toolsLogging "gym-badges-api/tools/logging"
+	toolsTesting "gym-badges-api/tools/testing"
+	"gym-badges-api/tools/utils"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	log "github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+)
+
+func TestServiceGymSuite(t *testing.T) {
+	toolsTesting.ConfigureTestSuite(t, "SERVICE: Gym Test Suite")
+}
+
+var _ = Describe("SERVICE: Gym Test Suite", func() {
+
+	var (
+		mockCtrl         *gomock.Controller
+		mockGymDAO       *mockDAO.MockIGymDAO
+		mockUserDAO      *mockDAO.MockIUserDAO
+		mockStatsService *mockService.MockIStatsService
+		service          IGymService
+		ctxLogger        *log.Entry
+		userID           string
+		gym              gymDAO.Gym
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockGymDAO = mockDAO.NewMockIGymDAO(mockCtrl)
+		mockUserDAO = mockDAO.NewMockIUserDAO(mockCtrl)
+		mockStatsService = mockService.NewMockIStatsService(mockCtrl)
+		service = NewGymService(mockGymDAO, mockUserDAO, mockStatsService)
+
+		ctxLogger = toolsLogging.BuildLogger()
+		userID = "admin"
+		configs.Basic.QRCodePeriod = 30
+
+		gym = gymDAO.Gym{
+			ID:        1,
+			Name:      "Central Gym",
+			Latitude:  40.4168,
+			Longitude: -3.7038,
+			Radius:    100,
+			QRSecret:  "secret",
+			// SHA-256 of "device-key"
+			DeviceKeyHash: "5d19e448729151e104c2f1069e08a199f6a0bad7192e2588e21d924f734c04c6",
+		}
+	})
+
+	AfterEach(func() {
+		defer mockCtrl.Finish()
+	})
+
+	Context("Get Gyms", func() {
+
+		It("CASE: Successful get gyms without their secrets", func() {
+
+			mockGymDAO.EXPECT().GetGyms(ctxLogger).Times(1).Return([]*gymDAO.Gym{&gym}, nil)
+
+			response, err := service.GetGyms(ctxLogger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Gyms).To(HaveLen(1))
+			Expect(*response.Gyms[0]).To(Equal(models.Gym{
+				ID:        1,
+				Name:      "Central Gym",
+				Latitude:  40.4168,
+				Longitude: -3.7038,
+				Radius:    100,
+			}))
+		})
+
+		It("CASE: Get gyms failed because of an unexpected error", func() {
+
+			mockGymDAO.EXPECT().GetGyms(ctxLogger).Times(1).Return(nil, errors.New("test error"))
+
+			response, err := service.GetGyms(ctxLogger)
+			Expect(err).To(HaveOccurred())
+			Expect(response).To(BeNil())
+		})
+	})
+
+	Context("Get Gym QR Code", func() {
+
+		It("CASE: Successful get a valid QR code", func() {
+
+			mockGymDAO.EXPECT().GetGym(gym.ID, ctxLogger).Times(1).Return(&gym, nil)
+
+			response, err := service.GetGymQRCode(gym.ID, "device-key", ctxLogger)
+			Expect(err).ToNot(HaveOccurred())
+			_, valid := validQRCode(&gym, response.Code, time.Now())
+			Expect(valid).To(BeTrue())
+			Expect(time.Time(response.ExpiresAt)).To(BeTemporally(">", time.Now()))
+		})
+
+		It("CASE: Successful get a different QR code each time", func() {
+
+			mockGymDAO.EXPECT().GetGym(gym.ID, ctxLogger).Times(2).Return(&gym, nil)
+
+			first, err := service.GetGymQRCode(gym.ID, "device-key", ctxLogger)
+			Expect(err).ToNot(HaveOccurred())
+			second, err := service.GetGymQRCode(gym.ID, "device-key", ctxLogger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(first.Code).ToNot(Equal(second.Code))
+		})
+
+		It("CASE: Get QR code failed because the QR secret is not the gym key", func() {
+
+			mockGymDAO.EXPECT().GetGym(gym.ID, ctxLogger).Times(1).Return(&gym, nil)
+
+			response, err := service.GetGymQRCode(gym.ID, "secret", ctxLogger)
+			Expect(errors.As(err, &customErrors.Unauthorized)).To(BeTrue())
+			Expect(response).To(BeNil())
+		})
+
+		It("CASE: Get QR code failed because the gym has no key", func() {
+
+			gym.DeviceKeyHash = constants.EmptyString
+
+			mockGymDAO.EXPECT().GetGym(gym.ID, ctxLogger).Times(1).Return(&gym, nil)
+
+			response, err := service.GetGymQRCode(gym.ID, constants.EmptyString, ctxLogger)
+			Expect(errors.As(err, &customErrors.Unauthorized)).To(BeTrue())
+			Expect(response).To(BeNil())
+		})
+
+		It("CASE: Get QR code failed because of a wrong gym key", func() {
+
+			mockGymDAO.EXPECT().GetGym(gym.ID, ctxLogger).Times(1).Return(&gym, nil)
+
+			response, err := service.GetGymQRCode(gym.ID, "wrong", ctxLogger)
+			Expect(errors.As(err, &customErrors.Unauthorized)).To(BeTrue())
+			Expect(response).To(BeNil())
+		})
+
+		It("CASE: Get QR code failed because the gym does not exist", func() {
+
+			mockGymDAO.EXPECT().GetGym(gym.ID, ctxLogger).Times(1).
+				Return(nil, customErrors.BuildNotFoundError("test error"))
+
+			response, err := service.GetGymQRCode(gym.ID, "device-key", ctxLogger)
+			Expect(errors.As(err, &customErrors.NotFound)).To(BeTrue())
+			Expect(response).To(BeNil())
+		})
+	})
+
+	Context("Check In", func() {
+
+		var (
+			today time.Time
+		)
+
+		BeforeEach(func() {
+			today = time.Now().Truncate(24 * time.Hour)
+		})
+
+		It("CASE: Successful check in inside the geofence", func() {
+
+			request := models.CheckInRequest{
+				Latitude:  utils.NewFloat64(40.4170),
+				Longitude: utils.NewFloat64(-3.7040),
+			}
+
+			mockGymDAO.EXPECT().GetGym(gym.ID, ctxLogger).Times(1).Return(&gym, nil)
+			mockUserDAO.EXPECT().CheckVerifiedAttendance(userID, today, ctxLogger).Times(1).Return(false, nil)
+			mockUserDAO.EXPECT().CheckGymAttendance(userID, today, ctxLogger).Times(1).Return(false, nil)
+			mockStatsService.EXPECT().AddGymAttendance(userID, today, ctxLogger).Times(1).Return(nil)
+			mockUserDAO.EXPECT().AddVerifiedAttendance(gomock.Any(), ctxLogger).Times(1).
+				DoAndReturn(func(attendance *userDAO.VerifiedAttendance, _ *log.Entry) error {
+					Expect(attendance.UserID).To(Equal(userID))
+					Expect(attendance.Date).To(Equal(today))
+					Expect(attendance.GymID).To(Equal(gym.ID))
+					Expect(attendance.Method).To(Equal(userDAO.CheckInMethodGPS))
+					return nil
+				})
+
+			response, err := service.CheckIn(userID, gym.ID, &request, ctxLogger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Date).To(Equal(today.Format(constants.ISODateLayout)))
+			Expect(response.Method).To(Equal(userDAO.CheckInMethodGPS))
+		})
+
+		It("CASE: Successful check in with the QR code of the previous period", func() {
+
+			request := models.CheckInRequest{
+				QrCode: signQRCode(&gym, time.Now().Unix()/30-1, "nonce"),
+			}
+
+			mockGymDAO.EXPECT().GetGym(gym.ID, ctxLogger).Times(1).Return(&gym, nil)
+			mockUserDAO.EXPECT().CheckVerifiedAttendance(userID, today, ctxLogger).Times(1).Return(false, nil)
+			mockGymDAO.EXPECT().UseQRCode(gomock.Any(), ctxLogger).Times(1).
+				DoAndReturn(func(use *gymDAO.UsedQRCode, _ *log.Entry) error {
+					Expect(use.Nonce).To(Equal("nonce"))
+					Expect(use.GymID).To(Equal(gym.ID))
+					Expect(use.UserID).To(Equal(userID))
+					return nil
+				})
+			mockUserDAO.EXPECT().CheckGymAttendance(userID, today, ctxLogger).Times(1).Return(false, nil)
+			mockStatsService.EXPECT().AddGymAttendance(userID, today, ctxLogger).Times(1).Return(nil)
+			mockUserDAO.EXPECT().AddVerifiedAttendance(gomock.Any(), ctxLogger).Times(1).Return(nil)
+
+			response, err := service.CheckIn(userID, gym.ID, &request, ctxLogger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Method).To(Equal(userDAO.CheckInMethodQR))
+		})
+
+		It("CASE: Successful check in of a day already attended", func() {
+
+			request := models.CheckInRequest{
+				QrCode: signQRCode(&gym, time.Now().Unix()/30, "nonce"),
+			}
+
+			mockGymDAO.EXPECT().GetGym(gym.ID, ctxLogger).Times(1).Return(&gym, nil)
+			mockUserDAO.EXPECT().CheckVerifiedAttendance(userID, today, ctxLogger).Times(1).Return(false, nil)
+			mockGymDAO.EXPECT().UseQRCode(gomock.Any(), ctxLogger).Times(1).Return(nil)
+			mockUserDAO.EXPECT().CheckGymAttendance(userID, today, ctxLogger).Times(1).Return(true, nil)
+			mockUserDAO.EXPECT().AddVerifiedAttendance(gomock.Any(), ctxLogger).Times(1).Return(nil)
+
+			response, err := service.CheckIn(userID, gym.ID, &request, ctxLogger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.GymID).To(Equal(gym.ID))
+		})
+
+		It("CASE: Check in failed because the location is outside the geofence", func() {
+
+			request := models.CheckInRequest{
+				Latitude:  utils.NewFloat64(40.4268),
+				Longitude: utils.NewFloat64(-3.7038),
+			}
+
+			mockGymDAO.EXPECT().GetGym(gym.ID, ctxLogger).Times(1).Return(&gym, nil)
+
+			response, err := service.CheckIn(userID, gym.ID, &request, ctxLogger)
+			Expect(errors.As(err, &customErrors.Forbidden)).To(BeTrue())
+			Expect(response).To(BeNil())
+		})
+
+		It("CASE: Check in failed because of coordinates out of range", func() {
+
+			request := models.CheckInRequest{
+				Latitude:  utils.NewFloat64(95),
+				Longitude: utils.NewFloat64(-3.7038),
+			}
+
+			mockGymDAO.EXPECT().GetGym(gym.ID, ctxLogger).Times(1).Return(&gym, nil)
+
+			response, err := service.CheckIn(userID, gym.ID, &request, ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+			Expect(response).To(BeNil())
+		})
+
+		It("CASE: Check in failed because the QR code has expired", func() {
+
+			request := models.CheckInRequest{
+				QrCode: signQRCode(&gym, time.Now().Unix()/30-2, "nonce"),
+			}
+
+			mockGymDAO.EXPECT().GetGym(gym.ID, ctxLogger).Times(1).Return(&gym, nil)
+
+			response, err := service.CheckIn(userID, gym.ID, &request, ctxLogger)
+			Expect(errors.As(err, &customErrors.Forbidden)).To(BeTrue())
+			Expect(response).To(BeNil())
+		})
+
+		It("CASE: Check in failed because the QR code was already used", func() {
+
+			request := models.CheckInRequest{
+				QrCode: signQRCode(&gym, time.Now().Unix()/30, "nonce"),
+			}
+
+			mockGymDAO.EXPECT().GetGym(gym.ID, ctxLogger).Times(1).Return(&gym, nil)
+			mockUserDAO.EXPECT().CheckVerifiedAttendance(userID, today, ctxLogger).Times(1).Return(false, nil)
+			mockGymDAO.EXPECT().UseQRCode(gomock.Any(), ctxLogger).Times(1).
+				Return(customErrors.BuildConflictError("test error"))
+			mockStatsService.EXPECT().AddGymAttendance(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			mockUserDAO.EXPECT().AddVerifiedAttendance(gomock.Any(), gomock.Any()).Times(0)
+
+			response, err := service.CheckIn(userID, gym.ID, &request, ctxLogger)
+			Expect(errors.As(err, &customErrors.Conflict)).To(BeTrue())
+			Expect(response).To(BeNil())
+		})
+
+		It("CASE: Check in failed because the QR code was signed without a nonce", func() {
+
+			payload := fmt.Sprintf("%d.%d", gym.ID, time.Now().Unix()/30)
+			mac := hmac.New(sha256.New, []byte(gym.QRSecret))
+			mac.Write([]byte(payload))
+			request := models.CheckInRequest{
+				QrCode: payload + "." + hex.EncodeToString(mac.Sum(nil)[:qrSignatureBytes]),
+			}
+
+			mockGymDAO.EXPECT().GetGym(gym.ID, ctxLogger).Times(1).Return(&gym, nil)
+
+			response, err := service.CheckIn(userID, gym.ID, &request, ctxLogger)
+			Expect(errors.As(err, &customErrors.Forbidden)).To(BeTrue())
+			Expect(response).To(BeNil())
+		})
+
+		It("CASE: Check in failed because the QR code belongs to another gym", func() {
+
+			other := gymDAO.Gym{ID: 2, QRSecret: "other"}
+			request := models.CheckInRequest{
+				QrCode: signQRCode(&other, time.Now().Unix()/30, "nonce"),
+			}
+
+			mockGymDAO.EXPECT().GetGym(gym.ID, ctxLogger).Times(1).Return(&gym, nil)
+
+			response, err := service.CheckIn(userID, gym.ID, &request, ctxLogger)
+			Expect(errors.As(err, &customErrors.Forbidden)).To(BeTrue())
+			Expect(response).To(BeNil())
+		})
+
+		It("CASE: Check in failed because there is no proof", func() {
+
+			mockGymDAO.EXPECT().GetGym(gym.ID, ctxLogger).Times(1).Return(&gym, nil)
+
+			response, err := service.CheckIn(userID, gym.ID, &models.CheckInRequest{}, ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+			Expect(response).To(BeNil())
+		})
+
+		It("CASE: Check in failed because today is already verified", func() {
+
+			request := models.CheckInRequest{
+				Latitude:  utils.NewFloat64(40.4168),
+				Longitude: utils.NewFloat64(-3.7038),
+			}
+
+			mockGymDAO.EXPECT().GetGym(gym.ID, ctxLogger).Times(1).Return(&gym, nil)
+			mockUserDAO.EXPECT().CheckVerifiedAttendance(userID, today, ctxLogger).Times(1).Return(true, nil)
+
+			response, err := service.CheckIn(userID, gym.ID, &request, ctxLogger)
+			Expect(errors.As(err, &customErrors.Conflict)).To(BeTrue())
+			Expect(response).To(BeNil())
+		})
+
+		It("CASE: Check in failed because the gym does not exist", func() {
+
+			mockGymDAO.EXPECT().GetGym(gym.ID, ctxLogger).Times(1).
+				Return(nil, customErrors.BuildNotFoundError("test error"))
+
+			response, err := service.CheckIn(userID, gym.ID, &models.CheckInRequest{}, ctxLogger)
+			Expect(errors.As(err, &customErrors.NotFound)).To(BeTrue())
+			Expect(response).To(BeNil())
+		})
+
+		It("CASE: Check in failed because of an unexpected error", func() {
+
+			request := models.CheckInRequest{
+				Latitude:  utils.NewFloat64(40.4168),
+				Longitude: utils.NewFloat64(-3.7038),
+			}
+
+			mockGymDAO.EXPECT().GetGym(gym.ID, ctxLogger).Times(1).Return(&gym, nil)
+			mockUserDAO.EXPECT().CheckVerifiedAttendance(userID, today, ctxLogger).Times(1).Return(false, nil)
+			mockUserDAO.EXPECT().CheckGymAttendance(userID, today, ctxLogger).Times(1).Return(false, nil)
+			mockStatsService.EXPECT().AddGymAttendance(userID, today, ctxLogger).Times(1).
+				Return(errors.New("test error"))
+
+			response, err := service.CheckIn(userID, gym.ID, &request, ctxLogger)
+			Expect(err).To(HaveOccurred())
+			Expect(response).To(BeNil())
+		})
+	})
+})
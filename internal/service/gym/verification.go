@@ -0,0 +1,116 @@
+package gym_service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	configs "gym-badges-api/config/gym-badges-server"
+	"gym-badges-api/internal/constants"
+	gymDAO "gym-badges-api/internal/repository/gym"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	earthRadius = 6371000 // In meters
+
+	// 128 bits of the signature and of the nonce are enough for codes that live some seconds
+	qrSignatureBytes = 16
+	qrNonceBytes     = 16
+)
+
+// *******************************************************************
+// QR CODES
+// *******************************************************************
+
+// qrCode signs the gym, the current period and a random nonce, so every code is different and cannot be
+// built without the secret of the gym. Each nonce is only accepted once. Returns the code and when it expires.
+func qrCode(gym *gymDAO.Gym, now time.Time) (string, time.Time, error) {
+
+	period := qrPeriod()
+	window := now.Unix() / period
+
+	nonce := make([]byte, qrNonceBytes)
+	if _, err := rand.Read(nonce); err != nil {
+		return constants.EmptyString, time.Time{}, err
+	}
+
+	return signQRCode(gym, window, hex.EncodeToString(nonce)), time.Unix((window+1)*period, 0), nil
+}
+
+// validQRCode accepts the codes of the current and the previous period, the code may change while it
+// is being scanned. Returns the nonce of the code, that the check-in has to use.
+func validQRCode(gym *gymDAO.Gym, code string, now time.Time) (string, bool) {
+
+	parts := strings.Split(code, ".")
+	if len(parts) != 4 || parts[0] != strconv.FormatInt(gym.ID, 10) || gym.QRSecret == constants.EmptyString {
+		return constants.EmptyString, false
+	}
+
+	window, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return constants.EmptyString, false
+	}
+
+	current := now.Unix() / qrPeriod()
+	if window != current && window != current-1 {
+		return constants.EmptyString, false
+	}
+
+	if !hmac.Equal([]byte(code), []byte(signQRCode(gym, window, parts[2]))) {
+		return constants.EmptyString, false
+	}
+
+	return parts[2], true
+}
+
+func signQRCode(gym *gymDAO.Gym, window int64, nonce string) string {
+
+	payload := fmt.Sprintf("%d.%d.%s", gym.ID, window, nonce)
+
+	mac := hmac.New(sha256.New, []byte(gym.QRSecret))
+	mac.Write([]byte(payload))
+
+	return payload + "." + hex.EncodeToString(mac.Sum(nil)[:qrSignatureBytes])
+}
+
+// validGymKey compares the hash of the key with the one stored. A gym without any key or QR secret
+// accepts no screen.
+func validGymKey(gym *gymDAO.Gym, key string) bool {
+
+	if gym.DeviceKeyHash == constants.EmptyString || gym.QRSecret == constants.EmptyString {
+		return false
+	}
+
+	hash := sha256.Sum256([]byte(key))
+
+	return hmac.Equal([]byte(hex.EncodeToString(hash[:])), []byte(gym.DeviceKeyHash))
+}
+
+func qrPeriod() int64 {
+	return max(configs.Basic.QRCodePeriod, 1)
+}
+
+// *******************************************************************
+// GEOFENCE
+// *******************************************************************
+
+// distance in meters between two coordinates, with the haversine formula
+func distance(latitude1, longitude1, latitude2, longitude2 float64) float64 {
+
+	toRadians := func(degrees float64) float64 {
+		return degrees * math.Pi / 180
+	}
+
+	deltaLatitude := toRadians(latitude2 - latitude1)
+	deltaLongitude := toRadians(longitude2 - longitude1)
+
+	a := math.Sin(deltaLatitude/2)*math.Sin(deltaLatitude/2) +
+		math.Cos(toRadians(latitude1))*math.Cos(toRadians(latitude2))*math.Sin(deltaLongitude/2)*math.Sin(deltaLongitude/2)
+
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
@@ -0,0 +1,193 @@
+package imports_service
+
+import (
+	configs "gym-badges-api/config/gym-badges-server"
+	"gym-badges-api/internal/constants"
+	customErrors "gym-badges-api/internal/custom-errors"
+	userDAO "gym-badges-api/internal/repository/user"
+	eventsService "gym-badges-api/internal/service/events"
+	goalService "gym-badges-api/internal/service/goal"
+	statsService "gym-badges-api/internal/service/stats"
+	"gym-badges-api/models"
+	"io"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	FormatCSV         = "csv"
+	FormatAppleHealth = "apple_health"
+	FormatGoogleFit   = "google_fit"
+)
+
+func NewImportService(userDAO userDAO.IUserDAO, statsService statsService.IStatsService,
+	eventsService eventsService.IEventsService, goalService goalService.IGoalService) IImportService {
+	return &importService{
+		UserDAO:       userDAO,
+		statsService:  statsService,
+		eventsService: eventsService,
+		goalService:   goalService,
+	}
+}
+
+type importService struct {
+	UserDAO       userDAO.IUserDAO
+	statsService  statsService.IStatsService
+	eventsService eventsService.IEventsService
+	goalService   goalService.IGoalService
+}
+
+// *******************************************************************
+// HISTORY IMPORT
+// *******************************************************************
+
+func (s importService) ImportHistory(userID string, format string, file io.Reader, dryRun bool,
+	ctxLog *log.Entry) (*models.ImportReport, error) {
+
+	ctxLog.Debugf("IMPORT_SERVICE: Processing ImportHistory request for user: %s format: %s", userID, format)
+
+	data := newImportedData(format, dryRun)
+	limited := newLimitedReader(file, configs.Basic.ImportMaxSize)
+
+	var err error
+	switch format {
+	case FormatCSV:
+		err = parseCSV(limited, data)
+	case FormatAppleHealth:
+		err = parseAppleHealth(limited, data)
+	case FormatGoogleFit:
+		err = parseGoogleFit(limited, data)
+	default:
+		err = customErrors.BuildBadRequestError("Unknown import format %s.", format)
+	}
+	// The parsers only see a read error, so the reason is given here
+	if limited.exceeded {
+		return nil, customErrors.BuildBadRequestError("The file cannot be bigger than %d MB.", configs.Basic.ImportMaxSize>>20)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.UserDAO.GetUserWithHistory(userID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	attendances, weights, fats := newHistory(user, data)
+
+	report := data.report
+
+	if dryRun {
+		return report, nil
+	}
+
+	if err := s.UserDAO.ImportHistory(userID, attendances, weights, fats, ctxLog); err != nil {
+		return nil, err
+	}
+
+	ctxLog.Infof("IMPORT_SERVICE: Imported %d attendances, %d weights and %d body fats to user %s",
+		len(attendances), len(weights), len(fats), userID)
+
+	// The streak is always recomputed, so retrying a partially failed import fixes it
+	streak, err := s.statsService.RecomputeStreak(userID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+	report.Streak = &streak
+
+	if len(attendances) > 0 {
+		s.eventsService.Publish(eventsService.Event{Type: eventsService.AttendanceAdded, UserID: userID}, ctxLog)
+	}
+
+	// The history is already saved, so this failure is only logged
+	if _, err := s.goalService.CheckGoals(userID, ctxLog); err != nil {
+		ctxLog.Warnf("IMPORT_SERVICE: Checking goals for user %s failed: %s", userID, err)
+	}
+
+	return report, nil
+}
+
+// newHistory returns the imported days that are not stored yet, the stored ones are kept as they are
+func newHistory(user *userDAO.User, data *importedData) ([]userDAO.GymAttendance, []userDAO.WeightHistory,
+	[]userDAO.FatHistory) {
+
+	report := data.report
+
+	stored := make(map[time.Time]bool)
+	for _, attendance := range user.GymAttendance {
+		stored[truncateDay(attendance.Date)] = true
+	}
+
+	attendances := make([]userDAO.GymAttendance, 0)
+	for _, day := range sortedDays(data.attendances) {
+		if stored[day] {
+			report.Attendances.Duplicated++
+			continue
+		}
+		attendances = append(attendances, userDAO.GymAttendance{UserID: user.ID, Date: day})
+	}
+
+	stored = make(map[time.Time]bool)
+	for _, weight := range user.WeightHistory {
+		stored[truncateDay(weight.Date)] = true
+	}
+
+	weights := make([]userDAO.WeightHistory, 0)
+	for _, day := range sortedDays(data.weights) {
+		if stored[day] {
+			report.Weights.Duplicated++
+			continue
+		}
+		weights = append(weights, userDAO.WeightHistory{UserID: user.ID, Date: day, Weight: data.weights[day].Value})
+	}
+
+	stored = make(map[time.Time]bool)
+	for _, fat := range user.FatHistory {
+		stored[truncateDay(fat.Date)] = true
+	}
+
+	fats := make([]userDAO.FatHistory, 0)
+	for _, day := range sortedDays(data.fats) {
+		if stored[day] {
+			report.BodyFats.Duplicated++
+			continue
+		}
+		fats = append(fats, userDAO.FatHistory{UserID: user.ID, Date: day, Fat: data.fats[day].Value})
+	}
+
+	report.Attendances.Found = int32(len(data.attendances))
+	report.Attendances.Added = int32(len(attendances))
+	report.Weights.Found = int32(len(data.weights))
+	report.Weights.Added = int32(len(weights))
+	report.BodyFats.Found = int32(len(data.fats))
+	report.BodyFats.Added = int32(len(fats))
+
+	days := append(append(sortedDays(data.attendances), sortedDays(data.weights)...), sortedDays(data.fats)...)
+	if len(days) > 0 {
+		sort.Slice(days, func(i, j int) bool {
+			return days[i].Before(days[j])
+		})
+		first := days[0].Format(constants.ISODateLayout)
+		last := days[len(days)-1].Format(constants.ISODateLayout)
+		report.FirstDate = &first
+		report.LastDate = &last
+	}
+
+	return attendances, weights, fats
+}
+
+func sortedDays[V any](values map[time.Time]V) []time.Time {
+
+	days := make([]time.Time, 0, len(values))
+	for day := range values {
+		days = append(days, day)
+	}
+
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Before(days[j])
+	})
+
+	return days
+}
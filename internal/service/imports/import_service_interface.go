@@ -0,0 +1,13 @@
+package imports_service
+
+import (
+	"gym-badges-api/models"
+	"io"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type IImportService interface {
+	// Imports the history in the file, or only reports what would be imported in a dry run
+	ImportHistory(userID string, format string, file io.Reader, dryRun bool, ctxLog *log.Entry) (*models.ImportReport, error)
+}
@@ -0,0 +1,268 @@
+package imports_service
+
+import (
+	configs "gym-badges-api/config/gym-badges-server"
+	customErrors "gym-badges-api/internal/custom-errors"
+	userDAO "gym-badges-api/internal/repository/user"
+	eventsService "gym-badges-api/internal/service/events"
+	mockDAO "gym-badges-api/mocks/dao"
+	mockService "gym-badges-api/mocks/service"
+	toolsLogging "gym-badges-api/tools/logging"
+	toolsTesting "gym-badges-api/tools/testing"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	log "github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+)
+
+func TestServiceImportSuite(t *testing.T) {
+	toolsTesting.ConfigureTestSuite(t, "SERVICE: Import Test Suite")
+}
+
+var _ = Describe("SERVICE: Import Test Suite", func() {
+
+	var (
+		mockCtrl          *gomock.Controller
+		mockUserDAO       *mockDAO.MockIUserDAO
+		mockStatsService  *mockService.MockIStatsService
+		mockEventsService *mockService.MockIEventsService
+		mockGoalService   *mockService.MockIGoalService
+		service           IImportService
+		ctxLogger         *log.Entry
+		userID            string
+		user              userDAO.User
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockUserDAO = mockDAO.NewMockIUserDAO(mockCtrl)
+		mockStatsService = mockService.NewMockIStatsService(mockCtrl)
+		mockEventsService = mockService.NewMockIEventsService(mockCtrl)
+		mockGoalService = mockService.NewMockIGoalService(mockCtrl)
+		service = NewImportService(mockUserDAO, mockStatsService, mockEventsService, mockGoalService)
+
+		configs.Basic.ImportMaxSize = 1 << 20
+
+		ctxLogger = toolsLogging.BuildLogger()
+		userID = "admin"
+
+		user = userDAO.User{
+			ID: userID,
+			GymAttendance: []userDAO.GymAttendance{
+				{UserID: userID, Date: parseTime("2024-03-04T00:00:00")},
+			},
+			WeightHistory: []userDAO.WeightHistory{
+				{UserID: userID, Date: parseTime("2024-03-01T00:00:00"), Weight: 80},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		defer mockCtrl.Finish()
+	})
+
+	Context("Import CSV", func() {
+
+		var (
+			file string
+		)
+
+		BeforeEach(func() {
+			file = "Date,Weight,Body Fat,Attendance\n" +
+				"2024-03-01,81.5,,\n" +
+				"2024-03-02,80.9,18.5,yes\n" +
+				"2024-03-02 20:00,80.7,,\n" +
+				"2024-03-04,,,1\n" +
+				"2024-03-05,900,,x\n" +
+				"03/06/2024,80.1,,\n" +
+				"2099-01-01,,,1\n"
+		})
+
+		It("CASE: Successful dry run reporting duplicates and invalid entries", func() {
+
+			mockUserDAO.EXPECT().GetUserWithHistory(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().ImportHistory(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			report, err := service.ImportHistory(userID, FormatCSV, strings.NewReader(file), true, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(report.DryRun).To(BeTrue())
+			Expect(report.Streak).To(BeNil())
+			Expect(*report.FirstDate).To(Equal("2024-03-01"))
+			Expect(*report.LastDate).To(Equal("2024-03-05"))
+
+			Expect(report.Weights.Found).To(Equal(int32(2)))
+			Expect(report.Weights.Duplicated).To(Equal(int32(1)))
+			Expect(report.Weights.Added).To(Equal(int32(1)))
+			Expect(report.Weights.Invalid).To(Equal(int32(2)))
+
+			Expect(report.BodyFats.Found).To(Equal(int32(1)))
+			Expect(report.BodyFats.Added).To(Equal(int32(1)))
+
+			Expect(report.Attendances.Found).To(Equal(int32(3)))
+			Expect(report.Attendances.Duplicated).To(Equal(int32(1)))
+			Expect(report.Attendances.Added).To(Equal(int32(2)))
+			Expect(report.Attendances.Invalid).To(Equal(int32(1)))
+
+			Expect(report.Errors).To(Equal([]string{
+				"line 6: weight 900.00 kg is out of range",
+				"line 7: invalid date \"03/06/2024\"",
+				"line 8: attendance date 2099-01-01 is in the future",
+			}))
+		})
+
+		It("CASE: Successful import recomputing streak, badges and goals", func() {
+
+			mockUserDAO.EXPECT().GetUserWithHistory(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().ImportHistory(userID, gomock.Any(), gomock.Any(), gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(_ string, attendances []userDAO.GymAttendance, weights []userDAO.WeightHistory,
+					fats []userDAO.FatHistory, _ *log.Entry) error {
+					Expect(len(attendances)).To(Equal(2))
+					Expect(attendances[0].Date).To(Equal(parseTime("2024-03-02T00:00:00")))
+					Expect(attendances[1].Date).To(Equal(parseTime("2024-03-05T00:00:00")))
+					// The last weight of the day is kept
+					Expect(weights).To(Equal([]userDAO.WeightHistory{
+						{UserID: userID, Date: parseTime("2024-03-02T00:00:00"), Weight: 80.7},
+					}))
+					Expect(len(fats)).To(Equal(1))
+					return nil
+				})
+
+			mockStatsService.EXPECT().RecomputeStreak(userID, ctxLogger).
+				Times(1).
+				Return(int32(3), nil)
+
+			mockEventsService.EXPECT().Publish(eventsService.Event{Type: eventsService.AttendanceAdded, UserID: userID}, ctxLogger).
+				Times(1)
+
+			mockGoalService.EXPECT().CheckGoals(userID, ctxLogger).
+				Times(1).
+				Return([]int64{}, nil)
+
+			report, err := service.ImportHistory(userID, FormatCSV, strings.NewReader(file), false, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(report.DryRun).To(BeFalse())
+			Expect(*report.Streak).To(Equal(int32(3)))
+		})
+
+		It("CASE: Import failed cause the file has no date column", func() {
+
+			report, err := service.ImportHistory(userID, FormatCSV, strings.NewReader("weight\n80\n"), true, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.BadRequestError{}))
+			Expect(report).To(BeNil())
+		})
+
+		It("CASE: Import failed cause the file is too big", func() {
+
+			configs.Basic.ImportMaxSize = int64(len(file) - 1)
+
+			report, err := service.ImportHistory(userID, FormatCSV, strings.NewReader(file), true, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.BadRequestError{}))
+			Expect(err.Error()).To(ContainSubstring("cannot be bigger"))
+			Expect(report).To(BeNil())
+		})
+
+		It("CASE: Import failed cause user not exist", func() {
+
+			mockUserDAO.EXPECT().GetUserWithHistory(userID, ctxLogger).
+				Times(1).
+				Return(nil, customErrors.BuildNotFoundError("not found"))
+
+			report, err := service.ImportHistory(userID, FormatCSV, strings.NewReader(file), false, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.NotFoundError{}))
+			Expect(report).To(BeNil())
+		})
+
+	})
+
+	Context("Import Apple Health", func() {
+
+		It("CASE: Successful dry run converting units and skipping other workouts", func() {
+
+			file := `<?xml version="1.0" encoding="UTF-8"?>
+<HealthData locale="en_US">
+ <Record type="HKQuantityTypeIdentifierBodyMass" unit="lb" value="176.37" startDate="2024-03-02 07:30:00 +0100"/>
+ <Record type="HKQuantityTypeIdentifierBodyFatPercentage" unit="%" value="0.185" startDate="2024-03-02 07:30:00 +0100"/>
+ <Record type="HKQuantityTypeIdentifierStepCount" unit="count" value="5000" startDate="2024-03-02 07:30:00 +0100"/>
+ <Record type="HKQuantityTypeIdentifierBodyMass" unit="st" value="12" startDate="2024-03-03 07:30:00 +0100"/>
+ <Workout workoutActivityType="HKWorkoutActivityTypeTraditionalStrengthTraining" startDate="2024-03-02 18:00:00 +0100"/>
+ <Workout workoutActivityType="HKWorkoutActivityTypeRunning" startDate="2024-03-03 18:00:00 +0100"/>
+</HealthData>`
+
+			mockUserDAO.EXPECT().GetUserWithHistory(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			report, err := service.ImportHistory(userID, FormatAppleHealth, strings.NewReader(file), true, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(report.Weights.Added).To(Equal(int32(1)))
+			Expect(report.Weights.Invalid).To(Equal(int32(1)))
+			Expect(report.BodyFats.Added).To(Equal(int32(1)))
+			Expect(report.Attendances.Added).To(Equal(int32(1)))
+			Expect(report.Errors).To(Equal([]string{"line 6: unknown weight unit \"st\""}))
+		})
+
+		It("CASE: Import failed cause the file is not an Apple Health export", func() {
+
+			report, err := service.ImportHistory(userID, FormatAppleHealth, strings.NewReader("<Other/>"), true, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.BadRequestError{}))
+			Expect(report).To(BeNil())
+		})
+
+	})
+
+	Context("Import Google Fit", func() {
+
+		It("CASE: Successful dry run of data points and sessions", func() {
+
+			file := `[
+				{"Data Points": [
+					{"dataTypeName": "com.google.weight", "startTimeNanos": 1709373600000000000, "fitValue": [{"value": {"fpVal": 79.8}}]},
+					{"dataTypeName": "com.google.activity.segment", "startTimeNanos": 1709373600000000000, "fitValue": [{"value": {"intVal": 80}}]},
+					{"dataTypeName": "com.google.activity.segment", "startTimeNanos": 1709460000000000000, "fitValue": [{"value": {"intVal": 8}}]}
+				]},
+				{"fitnessActivity": "weightlifting", "startTime": "2024-03-05T18:00:00.000Z"},
+				{"fitnessActivity": "running", "startTime": "2024-03-06T18:00:00.000Z"}
+			]`
+
+			mockUserDAO.EXPECT().GetUserWithHistory(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			report, err := service.ImportHistory(userID, FormatGoogleFit, strings.NewReader(file), true, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(report.Weights.Added).To(Equal(int32(1)))
+			Expect(report.Attendances.Added).To(Equal(int32(2)))
+			Expect(*report.FirstDate).To(Equal("2024-03-02"))
+			Expect(*report.LastDate).To(Equal("2024-03-05"))
+		})
+
+		It("CASE: Import failed cause the file is not JSON", func() {
+
+			report, err := service.ImportHistory(userID, FormatGoogleFit, strings.NewReader("date,weight"), true, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.BadRequestError{}))
+			Expect(report).To(BeNil())
+		})
+
+	})
+
+})
+
+func parseTime(dateStr string) time.Time {
+	parsedTime, err := time.Parse("2006-01-02T15:04:05", dateStr)
+	if err != nil {
+		Fail("Failed to parse date: "+dateStr, 1)
+	}
+	return parsedTime
+}
@@ -0,0 +1,497 @@
+package imports_service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"gym-badges-api/internal/constants"
+	customErrors "gym-badges-api/internal/custom-errors"
+	"gym-badges-api/models"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Only the first rejected entries are explained in the report
+	maxReportedErrors = 20
+
+	maxWeight  = 500
+	maxBodyFat = 100
+
+	poundsToKg = 0.45359237
+)
+
+// reading is a measurement with its full timestamp, so the last one of each day is kept
+type reading struct {
+	Time  time.Time
+	Value float32
+}
+
+// importedData holds the valid values found in a file, one per day
+type importedData struct {
+	attendances map[time.Time]bool
+	weights     map[time.Time]reading
+	fats        map[time.Time]reading
+	report      *models.ImportReport
+	now         time.Time
+}
+
+var errFileTooBig = errors.New("the file is too big")
+
+// limitedReader fails once more than maxSize bytes are read, unlike io.LimitReader that ends silently
+// and would import a truncated file
+type limitedReader struct {
+	reader   io.Reader
+	left     int64
+	exceeded bool
+}
+
+func newLimitedReader(reader io.Reader, maxSize int64) *limitedReader {
+	return &limitedReader{reader: io.LimitReader(reader, maxSize+1), left: maxSize}
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if r.left -= int64(n); r.left < 0 {
+		r.exceeded = true
+		return n + int(r.left), errFileTooBig
+	}
+	return n, err
+}
+
+func newImportedData(format string, dryRun bool) *importedData {
+	return &importedData{
+		attendances: make(map[time.Time]bool),
+		weights:     make(map[time.Time]reading),
+		fats:        make(map[time.Time]reading),
+		report: &models.ImportReport{
+			Format:      format,
+			DryRun:      dryRun,
+			Attendances: &models.ImportSummary{},
+			Weights:     &models.ImportSummary{},
+			BodyFats:    &models.ImportSummary{},
+			Errors:      make([]string, 0),
+		},
+		now: time.Now(),
+	}
+}
+
+func (d *importedData) reject(summary *models.ImportSummary, source string, reason string, args ...any) {
+	summary.Invalid++
+	if len(d.report.Errors) < maxReportedErrors {
+		d.report.Errors = append(d.report.Errors, source+": "+fmt.Sprintf(reason, args...))
+	}
+}
+
+func (d *importedData) addAttendance(at time.Time, source string) {
+	if at.After(d.now) {
+		d.reject(d.report.Attendances, source, "attendance date %s is in the future", at.Format(constants.ISODateLayout))
+		return
+	}
+	d.attendances[truncateDay(at)] = true
+}
+
+func (d *importedData) addWeight(at time.Time, weight float32, source string) {
+	switch {
+	case at.After(d.now):
+		d.reject(d.report.Weights, source, "weight date %s is in the future", at.Format(constants.ISODateLayout))
+	case weight <= 0 || weight > maxWeight:
+		d.reject(d.report.Weights, source, "weight %.2f kg is out of range", weight)
+	default:
+		addReading(d.weights, at, weight)
+	}
+}
+
+func (d *importedData) addBodyFat(at time.Time, fat float32, source string) {
+	switch {
+	case at.After(d.now):
+		d.reject(d.report.BodyFats, source, "body fat date %s is in the future", at.Format(constants.ISODateLayout))
+	case fat <= 0 || fat >= maxBodyFat:
+		d.reject(d.report.BodyFats, source, "body fat %.2f%% is out of range", fat)
+	default:
+		addReading(d.fats, at, fat)
+	}
+}
+
+func addReading(readings map[time.Time]reading, at time.Time, value float32) {
+	day := truncateDay(at)
+	if current, found := readings[day]; !found || !at.Before(current.Time) {
+		readings[day] = reading{Time: at, Value: value}
+	}
+}
+
+// truncateDay keeps the calendar day of the date in its own time zone
+func truncateDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// *******************************************************************
+// CSV
+// *******************************************************************
+
+var (
+	csvDateLayouts = []string{
+		constants.ISODateLayout,
+		time.RFC3339,
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04:05",
+		"2006-01-02 15:04",
+	}
+
+	csvWeightColumns     = []string{"weight", "weight_kg"}
+	csvBodyFatColumns    = []string{"body_fat", "fat", "body_fat_percentage"}
+	csvAttendanceColumns = []string{"attendance", "workout", "gym"}
+)
+
+// parseCSV reads a file with a header row. Each row has a date and any of weight in kg, body fat
+// percentage and attendance, which is marked with 1, true, yes or x.
+func parseCSV(file io.Reader, data *importedData) error {
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return customErrors.BuildBadRequestError("The CSV file has no header row.")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		// Spreadsheet apps may start the file with a byte order mark
+		name = strings.TrimPrefix(name, "\uFEFF")
+		name = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_")
+		columns[name] = i
+	}
+
+	findColumn := func(names []string) int {
+		for _, name := range names {
+			if i, found := columns[name]; found {
+				return i
+			}
+		}
+		return -1
+	}
+
+	dateColumn := findColumn([]string{"date"})
+	weightColumn := findColumn(csvWeightColumns)
+	fatColumn := findColumn(csvBodyFatColumns)
+	attendanceColumn := findColumn(csvAttendanceColumns)
+
+	if dateColumn < 0 {
+		return customErrors.BuildBadRequestError("The CSV file needs a date column.")
+	}
+	if weightColumn < 0 && fatColumn < 0 && attendanceColumn < 0 {
+		return customErrors.BuildBadRequestError("The CSV file needs a weight, body_fat or attendance column.")
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return customErrors.BuildBadRequestError("The CSV file cannot be read: %s", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		source := fmt.Sprintf("line %d", line)
+
+		field := func(column int) string {
+			if column < 0 || column >= len(record) {
+				return constants.EmptyString
+			}
+			return strings.TrimSpace(record[column])
+		}
+
+		weight, fat, attendance := field(weightColumn), field(fatColumn), field(attendanceColumn)
+
+		date, err := parseDate(field(dateColumn), csvDateLayouts)
+		if err != nil {
+			if weight != constants.EmptyString {
+				data.reject(data.report.Weights, source, "invalid date %q", field(dateColumn))
+			}
+			if fat != constants.EmptyString {
+				data.reject(data.report.BodyFats, source, "invalid date %q", field(dateColumn))
+			}
+			if attendance != constants.EmptyString {
+				data.reject(data.report.Attendances, source, "invalid date %q", field(dateColumn))
+			}
+			continue
+		}
+
+		if weight != constants.EmptyString {
+			if value, err := strconv.ParseFloat(weight, 32); err == nil {
+				data.addWeight(date, float32(value), source)
+			} else {
+				data.reject(data.report.Weights, source, "invalid weight %q", weight)
+			}
+		}
+
+		if fat != constants.EmptyString {
+			if value, err := strconv.ParseFloat(fat, 32); err == nil {
+				data.addBodyFat(date, float32(value), source)
+			} else {
+				data.reject(data.report.BodyFats, source, "invalid body fat %q", fat)
+			}
+		}
+
+		switch strings.ToLower(attendance) {
+		case "1", "true", "yes", "y", "x":
+			data.addAttendance(date, source)
+		case constants.EmptyString, "0", "false", "no", "n":
+		default:
+			data.reject(data.report.Attendances, source, "invalid attendance %q", attendance)
+		}
+	}
+}
+
+func parseDate(value string, layouts []string) (time.Time, error) {
+	for _, layout := range layouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+// *******************************************************************
+// APPLE HEALTH
+// *******************************************************************
+
+const appleHealthDateLayout = "2006-01-02 15:04:05 -0700"
+
+var appleHealthGymWorkouts = map[string]bool{
+	"HKWorkoutActivityTypeTraditionalStrengthTraining":   true,
+	"HKWorkoutActivityTypeFunctionalStrengthTraining":    true,
+	"HKWorkoutActivityTypeHighIntensityIntervalTraining": true,
+	"HKWorkoutActivityTypeCrossTraining":                 true,
+	"HKWorkoutActivityTypeCoreTraining":                  true,
+}
+
+// parseAppleHealth reads the export.xml file of an Apple Health export. It is streamed, as these
+// files easily take hundreds of megabytes.
+func parseAppleHealth(file io.Reader, data *importedData) error {
+
+	decoder := xml.NewDecoder(file)
+
+	foundHealthData := false
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return customErrors.BuildBadRequestError("The Apple Health export cannot be read: %s", err)
+		}
+
+		element, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		line, _ := decoder.InputPos()
+		source := fmt.Sprintf("line %d", line)
+
+		attributes := make(map[string]string, len(element.Attr))
+		for _, attribute := range element.Attr {
+			attributes[attribute.Name.Local] = attribute.Value
+		}
+
+		switch element.Name.Local {
+		case "HealthData":
+			foundHealthData = true
+
+		case "Record":
+			switch attributes["type"] {
+			case "HKQuantityTypeIdentifierBodyMass":
+				date, value, err := appleHealthValue(attributes)
+				if err != nil {
+					data.reject(data.report.Weights, source, "%s", err)
+					continue
+				}
+				switch attributes["unit"] {
+				case "kg":
+					data.addWeight(date, value, source)
+				case "lb":
+					data.addWeight(date, value*poundsToKg, source)
+				default:
+					data.reject(data.report.Weights, source, "unknown weight unit %q", attributes["unit"])
+				}
+
+			case "HKQuantityTypeIdentifierBodyFatPercentage":
+				date, value, err := appleHealthValue(attributes)
+				if err != nil {
+					data.reject(data.report.BodyFats, source, "%s", err)
+					continue
+				}
+				// Stored as a fraction
+				data.addBodyFat(date, value*100, source)
+			}
+
+		case "Workout":
+			if !appleHealthGymWorkouts[attributes["workoutActivityType"]] {
+				continue
+			}
+			date, err := time.Parse(appleHealthDateLayout, attributes["startDate"])
+			if err != nil {
+				data.reject(data.report.Attendances, source, "invalid date %q", attributes["startDate"])
+				continue
+			}
+			data.addAttendance(date, source)
+		}
+	}
+
+	if !foundHealthData {
+		return customErrors.BuildBadRequestError("The file is not an Apple Health export.")
+	}
+
+	return nil
+}
+
+func appleHealthValue(attributes map[string]string) (time.Time, float32, error) {
+
+	date, err := time.Parse(appleHealthDateLayout, attributes["startDate"])
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("invalid date %q", attributes["startDate"])
+	}
+
+	value, err := strconv.ParseFloat(attributes["value"], 32)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("invalid value %q", attributes["value"])
+	}
+
+	return date, float32(value), nil
+}
+
+// *******************************************************************
+// GOOGLE FIT
+// *******************************************************************
+
+const (
+	googleFitWeight          = "com.google.weight"
+	googleFitBodyFat         = "com.google.body.fat.percentage"
+	googleFitActivitySegment = "com.google.activity.segment"
+)
+
+var (
+	// Activity types of the activity segments
+	googleFitGymActivities = map[int64]bool{
+		80:  true, // Strength training
+		97:  true, // Weightlifting
+		113: true, // Crossfit
+		114: true, // HIIT
+	}
+
+	// Fitness activities of the sessions
+	googleFitGymSessions = map[string]bool{
+		"strength_training":                true,
+		"weightlifting":                    true,
+		"crossfit":                         true,
+		"circuit_training":                 true,
+		"interval_training.high_intensity": true,
+	}
+)
+
+// googleFitFile is either a data points file of the "All Data" folder or a session file of the
+// "All Sessions" folder of a Takeout
+type googleFitFile struct {
+	DataPoints []struct {
+		DataTypeName   string `json:"dataTypeName"`
+		StartTimeNanos int64  `json:"startTimeNanos"`
+		FitValue       []struct {
+			Value struct {
+				FpVal  *float64 `json:"fpVal"`
+				IntVal *int64   `json:"intVal"`
+			} `json:"value"`
+		} `json:"fitValue"`
+	} `json:"Data Points"`
+
+	FitnessActivity string `json:"fitnessActivity"`
+	StartTime       string `json:"startTime"`
+}
+
+// parseGoogleFit reads one Google Fit Takeout file, or an array of them
+func parseGoogleFit(file io.Reader, data *importedData) error {
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+
+	var files []googleFitFile
+
+	if content = bytes.TrimSpace(content); bytes.HasPrefix(content, []byte("[")) {
+		err = json.Unmarshal(content, &files)
+	} else {
+		files = make([]googleFitFile, 1)
+		err = json.Unmarshal(content, &files[0])
+	}
+	if err != nil {
+		return customErrors.BuildBadRequestError("The Google Fit export cannot be read: %s", err)
+	}
+
+	for i, fitFile := range files {
+
+		if fitFile.FitnessActivity != constants.EmptyString {
+			if !googleFitGymSessions[fitFile.FitnessActivity] {
+				continue
+			}
+			source := fmt.Sprintf("session %d", i+1)
+			date, err := time.Parse(time.RFC3339, fitFile.StartTime)
+			if err != nil {
+				data.reject(data.report.Attendances, source, "invalid date %q", fitFile.StartTime)
+				continue
+			}
+			data.addAttendance(date, source)
+			continue
+		}
+
+		for j, point := range fitFile.DataPoints {
+
+			source := fmt.Sprintf("data point %d", j+1)
+			if len(files) > 1 {
+				source = fmt.Sprintf("file %d data point %d", i+1, j+1)
+			}
+
+			// Takeout has no time zone, so days are taken in UTC
+			date := time.Unix(0, point.StartTimeNanos).UTC()
+
+			var fpVal *float64
+			var intVal *int64
+			if len(point.FitValue) > 0 {
+				fpVal, intVal = point.FitValue[0].Value.FpVal, point.FitValue[0].Value.IntVal
+			}
+
+			switch point.DataTypeName {
+			case googleFitWeight:
+				if fpVal == nil {
+					data.reject(data.report.Weights, source, "missing weight value")
+					continue
+				}
+				data.addWeight(date, float32(*fpVal), source)
+
+			case googleFitBodyFat:
+				if fpVal == nil {
+					data.reject(data.report.BodyFats, source, "missing body fat value")
+					continue
+				}
+				data.addBodyFat(date, float32(*fpVal), source)
+
+			case googleFitActivitySegment:
+				if intVal != nil && googleFitGymActivities[*intVal] {
+					data.addAttendance(date, source)
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -3,37 +3,46 @@ package rankings_service
 import (
 	configs "gym-badges-api/config/gym-badges-server"
 	userDAO "gym-badges-api/internal/repository/user"
+	eventsService "gym-badges-api/internal/service/events"
 	"gym-badges-api/models"
 	"gym-badges-api/tools/utils"
 
 	log "github.com/sirupsen/logrus"
 )
 
-func NewRankingsService(userDAO userDAO.IUserDAO) IRankingsService {
+func NewRankingsService(userDAO userDAO.IUserDAO, eventsService eventsService.IEventsService) IRankingsService {
 	return &rankingsService{
-		UserDAO: userDAO,
+		UserDAO:       userDAO,
+		eventsService: eventsService,
 	}
 }
 
 type rankingsService struct {
-	UserDAO userDAO.IUserDAO
+	UserDAO       userDAO.IUserDAO
+	eventsService eventsService.IEventsService
 }
 
-func (r *rankingsService) GetGlobalRanking(userID string, page int32, ctxLog *log.Entry) (*models.GetRankingResponse, error) {
+func (r *rankingsService) GetGlobalRanking(userID string, page int32, verified bool,
+	ctxLog *log.Entry) (*models.GetRankingResponse, error) {
 
-	ctxLog.Debugf("RANKINGS_SERVICE: Processing GetGlobalRanking for user: %s", userID)
+	ctxLog.Debugf("RANKINGS_SERVICE: Processing GetGlobalRanking for user: %s verified: %t", userID, verified)
 
 	offset := int64(page-1) * int64(configs.Basic.RankingsPageSize)
 	size := configs.Basic.RankingsPageSize
 	firstRank := offset + 1
 	lastRank := firstRank + int64(size) - 1
 
-	users, err := r.UserDAO.GetUsersOrderedByExp(offset, size, ctxLog)
+	getUsers, getUserWithRank := r.UserDAO.GetUsersOrderedByExp, r.UserDAO.GetUserWithGlobalRank
+	if verified {
+		getUsers, getUserWithRank = r.UserDAO.GetUsersOrderedByVerifiedAttendances, r.UserDAO.GetUserWithVerifiedGlobalRank
+	}
+
+	users, err := getUsers(offset, size, ctxLog)
 	if err != nil {
 		return nil, err
 	}
 
-	user, selfRank, err := r.UserDAO.GetUserWithGlobalRank(userID, ctxLog)
+	user, selfRank, err := getUserWithRank(userID, ctxLog)
 	if err != nil {
 		return nil, err
 	}
@@ -45,33 +54,40 @@ func (r *rankingsService) GetGlobalRanking(userID string, page int32, ctxLog *lo
 	// User not in ranking
 	if selfRank < firstRank || selfRank > lastRank {
 		response.Yourself = &models.RakingUser{
-			UserID: userID,
-			Name:   user.Name,
-			Image:  user.Image,
-			Level:  int64(utils.CalcLevel(user.Experience)),
-			Rank:   selfRank,
-			Streak: user.Streak,
+			UserID:              userID,
+			Name:                user.Name,
+			Image:               user.Image,
+			Level:               int64(utils.CalcLevel(user.Experience)),
+			Rank:                selfRank,
+			Streak:              user.Streak,
+			VerifiedAttendances: user.VerifiedAttendances,
 		}
 	}
 
 	return &response, nil
 }
 
-func (r *rankingsService) GetFriendsRanking(userID string, page int32, ctxLog *log.Entry) (*models.GetRankingResponse, error) {
+func (r *rankingsService) GetFriendsRanking(userID string, page int32, verified bool,
+	ctxLog *log.Entry) (*models.GetRankingResponse, error) {
 
-	ctxLog.Debugf("RANKINGS_SERVICE: Processing GetFriendsRanking for user: %s", userID)
+	ctxLog.Debugf("RANKINGS_SERVICE: Processing GetFriendsRanking for user: %s verified: %t", userID, verified)
 
 	offset := int64(page-1) * int64(configs.Basic.RankingsPageSize)
 	size := configs.Basic.RankingsPageSize
 	firstRank := offset + 1
 	lastRank := firstRank + int64(size) - 1
 
-	users, err := r.UserDAO.GetFriendsOrderedByExp(userID, offset, size, ctxLog)
+	getFriends, getUserWithRank := r.UserDAO.GetFriendsOrderedByExp, r.UserDAO.GetUserWithFriendsRank
+	if verified {
+		getFriends, getUserWithRank = r.UserDAO.GetFriendsOrderedByVerifiedAttendances, r.UserDAO.GetUserWithVerifiedFriendsRank
+	}
+
+	users, err := getFriends(userID, offset, size, ctxLog)
 	if err != nil {
 		return nil, err
 	}
 
-	user, selfRank, err := r.UserDAO.GetUserWithFriendsRank(userID, ctxLog)
+	user, selfRank, err := getUserWithRank(userID, ctxLog)
 	if err != nil {
 		return nil, err
 	}
@@ -83,12 +99,13 @@ func (r *rankingsService) GetFriendsRanking(userID string, page int32, ctxLog *l
 	// User not in ranking
 	if selfRank < firstRank || selfRank > lastRank {
 		response.Yourself = &models.RakingUser{
-			UserID: userID,
-			Name:   user.Name,
-			Image:  user.Image,
-			Level:  int64(utils.CalcLevel(user.Experience)),
-			Rank:   selfRank,
-			Streak: user.Streak,
+			UserID:              userID,
+			Name:                user.Name,
+			Image:               user.Image,
+			Level:               int64(utils.CalcLevel(user.Experience)),
+			Rank:                selfRank,
+			Streak:              user.Streak,
+			VerifiedAttendances: user.VerifiedAttendances,
 		}
 	}
 
@@ -101,14 +118,32 @@ func mapRanking(users []*userDAO.User, firstRank int64) []*models.RakingUser {
 
 	for i, u := range users {
 		ranking[i] = &models.RakingUser{
-			UserID: u.ID,
-			Name:   u.Name,
-			Image:  u.Image,
-			Level:  int64(utils.CalcLevel(u.Experience)),
-			Rank:   firstRank + int64(i),
-			Streak: u.Streak,
+			UserID:              u.ID,
+			Name:                u.Name,
+			Image:               u.Image,
+			Level:               int64(utils.CalcLevel(u.Experience)),
+			Rank:                firstRank + int64(i),
+			Streak:              u.Streak,
+			VerifiedAttendances: u.VerifiedAttendances,
 		}
 	}
 
 	return ranking
 }
+
+func (r *rankingsService) PublishFriendsRankChanges(event eventsService.Event, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("RANKINGS_SERVICE: Processing PublishFriendsRankChanges for user: %s", event.UserID)
+
+	friendIDs, err := r.UserDAO.GetFriendIDs(event.UserID, ctxLog)
+	if err != nil {
+		return err
+	}
+
+	// The user is in the friends ranking of each friend
+	for _, friendID := range friendIDs {
+		r.eventsService.Publish(eventsService.Event{Type: eventsService.RankChanged, UserID: friendID}, ctxLog)
+	}
+
+	return nil
+}
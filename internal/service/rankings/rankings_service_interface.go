@@ -1,12 +1,16 @@
 package rankings_service
 
 import (
+	eventsService "gym-badges-api/internal/service/events"
 	"gym-badges-api/models"
 
 	log "github.com/sirupsen/logrus"
 )
 
 type IRankingsService interface {
-	GetGlobalRanking(userID string, page int32, ctxLog *log.Entry) (*models.GetRankingResponse, error)
-	GetFriendsRanking(userID string, page int32, ctxLog *log.Entry) (*models.GetRankingResponse, error)
+	// Verified rankings order by verified attendances first
+	GetGlobalRanking(userID string, page int32, verified bool, ctxLog *log.Entry) (*models.GetRankingResponse, error)
+	GetFriendsRanking(userID string, page int32, verified bool, ctxLog *log.Entry) (*models.GetRankingResponse, error)
+	// Publishes a rank change for each friend of the user whose experience changed
+	PublishFriendsRankChanges(event eventsService.Event, ctxLog *log.Entry) error
 }
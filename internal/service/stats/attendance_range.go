@@ -0,0 +1,135 @@
+package stats_service
+
+import (
+	"gym-badges-api/internal/constants"
+	customErrors "gym-badges-api/internal/custom-errors"
+	"gym-badges-api/models"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Two years are enough for a year-long heatmap and keep the response bounded
+const maxAttendanceRangeDays = 2 * 366
+
+// *******************************************************************
+// ATTENDANCE RANGE
+// *******************************************************************
+
+func (s statService) GetAttendanceRange(userID string, from time.Time, to time.Time,
+	ctxLog *log.Entry) (*models.AttendanceRangeResponse, error) {
+
+	ctxLog.Debugf("STATS_SERVICE: Processing GetAttendanceRange request for user: %s", userID)
+
+	from, to = truncateDay(from), truncateDay(to)
+
+	if to.Before(from) {
+		return nil, customErrors.BuildBadRequestError("The range cannot end before it starts.")
+	}
+	if daysBetween(from, to) >= maxAttendanceRangeDays {
+		return nil, customErrors.BuildBadRequestError("The range cannot be longer than %d days.", maxAttendanceRangeDays)
+	}
+
+	// Read only, the streak is evaluated on the writes and by the periodic job
+	user, err := s.UserDAO.GetUserWithStreakProtection(userID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	period := userGoalPeriod(user)
+
+	perDay := make(map[time.Time]int32)
+	perWeek := make(map[time.Time]int32) // Attended days per goal period, as the weekly goal counts them
+	for _, attendance := range user.GymAttendance {
+		day := truncateDay(attendance.Date)
+		if perDay[day] == 0 {
+			perWeek[period.start(day)]++
+		}
+		perDay[day]++
+	}
+
+	protected := make(map[time.Time]bool)
+	for _, vacation := range user.VacationWeeks {
+		protected[period.start(vacation.Week)] = true
+	}
+	for _, freeze := range user.StreakFreezes {
+		if freeze.UsedWeek != nil {
+			protected[period.start(*freeze.UsedWeek)] = true
+		}
+	}
+
+	goals := userWeeklyGoals(user, period)
+
+	response := models.AttendanceRangeResponse{
+		From:               from.Format(constants.ISODateLayout),
+		To:                 to.Format(constants.ISODateLayout),
+		WeeklyGoal:         goals.at(period.start(time.Now())),
+		Days:               make([]*models.AttendanceDay, 0, int(daysBetween(from, to))+1),
+		Weeks:              make([]*models.AttendanceWeek, 0),
+		SessionsPerWeekday: make([]int32, daysPerWeek),
+		CurrentStreak:      user.Streak,
+	}
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		count := perDay[day]
+		response.Days = append(response.Days, &models.AttendanceDay{
+			Date:  day.Format(constants.ISODateLayout),
+			Count: count,
+		})
+		response.TotalSessions += count
+		response.SessionsPerWeekday[weekdayIndex(day)] += count
+	}
+
+	for week := period.start(from); !week.After(to); week = period.next(week) {
+		weeklyGoal := goals.at(week)
+		response.Weeks = append(response.Weeks, &models.AttendanceWeek{
+			Week:       week.Format(constants.ISODateLayout),
+			Sessions:   perWeek[week],
+			WeeklyGoal: weeklyGoal,
+			GoalMet:    weeklyGoal > 0 && perWeek[week] >= weeklyGoal,
+			Protected:  protected[week],
+		})
+	}
+
+	weeks := (daysBetween(from, to) + 1) / daysPerWeek
+	response.AverageSessionsPerWeek = roundValue(float32(float64(response.TotalSessions) / weeks))
+
+	if len(user.GymAttendance) > 0 {
+		first := period.start(user.GymAttendance[0].Date)
+		response.LongestStreak = max(longestStreak(perWeek, goals, protected, period, first, time.Now()), user.Streak)
+	}
+
+	return &response, nil
+}
+
+// longestStreak counts the longest run of goal periods that met their weekly goal between the first period and
+// the current one, with the same rules as the current streak: protected periods neither add nor break a run,
+// and the current period does not break it until it ends.
+func longestStreak(perWeek map[time.Time]int32, goals weeklyGoals, protected map[time.Time]bool,
+	period goalPeriod, first time.Time, now time.Time) int32 {
+
+	thisWeek := period.start(now)
+
+	var longest, run int32
+	for week := first; !week.After(thisWeek); week = period.next(week) {
+		weeklyGoal := goals.at(week)
+		switch {
+		case weeklyGoal <= 0:
+			run = 0
+		case perWeek[week] >= weeklyGoal:
+			run++
+			longest = max(longest, run)
+		case protected[week], week.Equal(thisWeek):
+			continue
+		default:
+			run = 0
+		}
+	}
+
+	return longest
+}
+
+// weekdayIndex returns the position of the day in the week, starting on Monday
+func weekdayIndex(date time.Time) int {
+	return (int(date.Weekday()) + daysPerWeek - 1) % daysPerWeek
+}
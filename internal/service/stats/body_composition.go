@@ -0,0 +1,165 @@
+package stats_service
+
+import (
+	"gym-badges-api/internal/constants"
+	customErrors "gym-badges-api/internal/custom-errors"
+	userDAO "gym-badges-api/internal/repository/user"
+	"gym-badges-api/models"
+	"math"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// Preference "Hide weight, fat, height and sex"
+	hideBodyStatsPreferenceID = 2
+
+	bodyStatsHiddenErrorMsg = "User %s hides their body stats."
+)
+
+type measurement struct {
+	Date  time.Time
+	Value float32
+}
+
+// *******************************************************************
+// BODY COMPOSITION
+// *******************************************************************
+
+func (s statService) GetBodyComposition(userID string, authUserID string, months int32,
+	ctxLog *log.Entry) (*models.BodyCompositionResponse, error) {
+
+	ctxLog.Debugf("STATS_SERVICE: Processing GetBodyComposition request for user: %s", userID)
+
+	user, err := s.UserDAO.GetUserWithBodyHistory(userID, months, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	if authUserID != userID && bodyStatsHidden(user) {
+		return nil, customErrors.BuildForbiddenError(bodyStatsHiddenErrorMsg, userID)
+	}
+
+	weights := make([]measurement, len(user.WeightHistory))
+	for i, weight := range user.WeightHistory {
+		weights[i] = measurement{Date: truncateDay(weight.Date), Value: weight.Weight}
+	}
+
+	fats := make([]measurement, len(user.FatHistory))
+	for i, fat := range user.FatHistory {
+		fats[i] = measurement{Date: truncateDay(fat.Date), Value: fat.Fat}
+	}
+
+	response := models.BodyCompositionResponse{
+		Height: user.Height,
+		Sex:    user.Sex,
+		Days:   make([]*models.BodyCompositionDay, 0),
+	}
+
+	// Without weight nothing can be computed
+	if len(weights) == 0 {
+		return &response, nil
+	}
+
+	// Height is stored in cm
+	var height float32
+	if user.Height != nil {
+		height = *user.Height / 100
+	}
+
+	start, end := weights[0].Date, weights[len(weights)-1].Date
+	if len(fats) > 0 && fats[len(fats)-1].Date.After(end) {
+		end = fats[len(fats)-1].Date
+	}
+
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+
+		weight, weightMeasured := valueAt(weights, day)
+		fat, fatMeasured := valueAt(fats, day)
+
+		response.Days = append(response.Days, bodyComposition(day, *weight, fat, height,
+			!weightMeasured && !fatMeasured))
+	}
+
+	return &response, nil
+}
+
+func bodyComposition(day time.Time, weight float32, fat *float32, height float32,
+	interpolated bool) *models.BodyCompositionDay {
+
+	composition := models.BodyCompositionDay{
+		Date:         day.Format(constants.ISODateLayout),
+		Interpolated: interpolated,
+		Weight:       roundValue(weight),
+	}
+
+	if height > 0 {
+		composition.Bmi = roundPointer(weight / (height * height))
+	}
+
+	if fat != nil {
+		fatMass := weight * *fat / 100
+		leanBodyMass := weight - fatMass
+
+		composition.BodyFat = roundPointer(*fat)
+		composition.FatMass = roundPointer(fatMass)
+		composition.LeanBodyMass = roundPointer(leanBodyMass)
+
+		if height > 0 {
+			composition.Ffmi = roundPointer(leanBodyMass / (height * height))
+		}
+	}
+
+	return &composition
+}
+
+// valueAt returns the value measured the given day, or the linear interpolation between the surrounding
+// measurements. After the last measurement its value is kept, before the first one there is no value.
+func valueAt(series []measurement, day time.Time) (*float32, bool) {
+
+	i := sort.Search(len(series), func(i int) bool {
+		return !series[i].Date.Before(day)
+	})
+
+	switch {
+	case i == len(series):
+		if i == 0 {
+			return nil, false
+		}
+		return &series[i-1].Value, false
+	case series[i].Date.Equal(day):
+		return &series[i].Value, true
+	case i == 0:
+		return nil, false
+	}
+
+	previous, next := series[i-1], series[i]
+	ratio := float32(day.Sub(previous.Date)) / float32(next.Date.Sub(previous.Date))
+	value := previous.Value + (next.Value-previous.Value)*ratio
+
+	return &value, false
+}
+
+func bodyStatsHidden(user *userDAO.User) bool {
+	for _, preference := range user.Preferences {
+		if preference.ID == hideBodyStatsPreferenceID {
+			return preference.On
+		}
+	}
+	return false
+}
+
+func truncateDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func roundValue(value float32) float32 {
+	return float32(math.Round(float64(value)*100) / 100)
+}
+
+func roundPointer(value float32) *float32 {
+	rounded := roundValue(value)
+	return &rounded
+}
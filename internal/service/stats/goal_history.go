@@ -0,0 +1,114 @@
+package stats_service
+
+import (
+	configs "gym-badges-api/config/gym-badges-server"
+	"gym-badges-api/internal/constants"
+	customErrors "gym-badges-api/internal/custom-errors"
+	userDAO "gym-badges-api/internal/repository/user"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// How a weekly goal change is applied when the current period has started
+const (
+	GoalChangeNextPeriod = "next_period" // The current period keeps its goal
+	GoalChangeLocked     = "locked"      // The goal cannot change once the current period has sessions
+)
+
+// weeklyGoals is the goal in force for each period, from the goal history of the user
+type weeklyGoals struct {
+	changes []userDAO.WeeklyGoalChange // Sorted by effective date
+	current int32                      // Goal of the users that never changed it
+	days    int
+}
+
+func userWeeklyGoals(user *userDAO.User, period goalPeriod) weeklyGoals {
+	return weeklyGoals{changes: user.GoalHistory, current: user.WeeklyGoal, days: period.days}
+}
+
+// at returns the goal of the period starting on the day. Periods before the first change keep the first
+// goal, and a goal cannot be higher than the days of the period.
+func (g weeklyGoals) at(start time.Time) int32 {
+
+	goal := g.current
+	if len(g.changes) > 0 {
+		goal = g.changes[0].WeeklyGoal
+	}
+
+	for _, change := range g.changes {
+		if change.EffectiveFrom.After(start) {
+			break
+		}
+		goal = change.WeeklyGoal
+	}
+
+	return min(goal, int32(g.days))
+}
+
+// latest returns the goal of the last change, which may not be in force yet
+func (g weeklyGoals) latest() int32 {
+	if len(g.changes) == 0 {
+		return g.current
+	}
+	return g.changes[len(g.changes)-1].WeeklyGoal
+}
+
+// *******************************************************************
+// WEEKLY GOAL
+// *******************************************************************
+
+func (s statService) ChangeWeeklyGoal(userID string, weeklyGoal int32, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("STATS_SERVICE: Processing ChangeWeeklyGoal request for user: %s", userID)
+
+	user, err := s.UserDAO.GetUserWithStreakProtection(userID, ctxLog)
+	if err != nil {
+		return err
+	}
+
+	period := userGoalPeriod(user)
+	if weeklyGoal < 1 || int(weeklyGoal) > period.days {
+		return customErrors.BuildBadRequestError("The weekly goal must be between 1 and %d sessions.", period.days)
+	}
+
+	goals := userWeeklyGoals(user, period)
+	if goals.latest() == weeklyGoal {
+		return nil
+	}
+
+	current := period.start(time.Now())
+	effective := current
+
+	switch configs.Basic.GoalChangeMode {
+	case GoalChangeLocked:
+		for _, attendance := range user.GymAttendance {
+			if period.start(attendance.Date).Equal(current) {
+				return customErrors.BuildConflictError("The weekly goal cannot change until the period of %s ends.",
+					current.Format(constants.ISODateLayout))
+			}
+		}
+	default:
+		effective = period.next(current)
+	}
+
+	changes := make([]userDAO.WeeklyGoalChange, 0, 2)
+
+	// The goal before the first change is recorded too, so that it keeps applying to the older periods
+	if len(user.GoalHistory) == 0 {
+		if first := period.start(user.CreatedAt); first.Before(effective) {
+			changes = append(changes, userDAO.WeeklyGoalChange{EffectiveFrom: first, WeeklyGoal: user.WeeklyGoal})
+		}
+	}
+	changes = append(changes, userDAO.WeeklyGoalChange{EffectiveFrom: effective, WeeklyGoal: weeklyGoal})
+
+	ctxLog.Infof("STATS_SERVICE: Weekly goal of user %s set to %d from %s", userID, weeklyGoal, effective.Format(constants.ISODateLayout))
+
+	if err := s.UserDAO.SaveWeeklyGoalChanges(userID, changes, ctxLog); err != nil {
+		return err
+	}
+
+	s.recomputeStreak(userID, ctxLog)
+
+	return nil
+}
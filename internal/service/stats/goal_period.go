@@ -0,0 +1,70 @@
+package stats_service
+
+import (
+	userDAO "gym-badges-api/internal/repository/user"
+	"math"
+	"time"
+)
+
+const daysPerWeek = 7
+
+// Weeks of any start day are anchored at the days following this Monday
+var firstMonday = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// goalPeriod splits the days in consecutive periods of the same length. The weekly goal, the streak and the
+// vacations are counted per period, which are weeks unless the user chose another length.
+type goalPeriod struct {
+	anchor time.Time // First day of one of the periods
+	days   int
+}
+
+// weeksStartingOn returns the weeks starting on the ISO weekday, Monday when it is not valid
+func weeksStartingOn(isoWeekday int16) goalPeriod {
+	if isoWeekday < 1 || isoWeekday > daysPerWeek {
+		isoWeekday = userDAO.DefaultWeekStart
+	}
+	return goalPeriod{anchor: firstMonday.AddDate(0, 0, int(isoWeekday)-1), days: daysPerWeek}
+}
+
+func userWeeks(user *userDAO.User) goalPeriod {
+	return weeksStartingOn(user.WeekStart)
+}
+
+func userGoalPeriod(user *userDAO.User) goalPeriod {
+
+	period := userWeeks(user)
+
+	if user.GoalPeriodDays > 0 && user.GoalPeriodDays != daysPerWeek {
+		period.days = int(user.GoalPeriodDays)
+		if user.GoalPeriodAnchor != nil {
+			period.anchor = truncateDay(*user.GoalPeriodAnchor)
+		}
+	}
+
+	return period
+}
+
+// start returns the first day of the period of the date
+func (p goalPeriod) start(date time.Time) time.Time {
+	day := truncateDay(date)
+
+	offset := int(math.Round(daysBetween(p.anchor, day))) % p.days
+	if offset < 0 {
+		offset += p.days
+	}
+
+	return day.AddDate(0, 0, -offset)
+}
+
+func (p goalPeriod) next(start time.Time) time.Time {
+	return start.AddDate(0, 0, p.days)
+}
+
+func (p goalPeriod) previous(start time.Time) time.Time {
+	return start.AddDate(0, 0, -p.days)
+}
+
+// dayIndex returns the position of the date in its period
+func (p goalPeriod) dayIndex(date time.Time) int {
+	return int(math.Round(daysBetween(p.start(date), truncateDay(date))))
+}
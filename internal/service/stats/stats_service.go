@@ -3,6 +3,8 @@ package stats_service
 import (
 	"gym-badges-api/internal/constants"
 	userDAO "gym-badges-api/internal/repository/user"
+	eventsService "gym-badges-api/internal/service/events"
+	goalService "gym-badges-api/internal/service/goal"
 	sessionService "gym-badges-api/internal/service/session"
 	"gym-badges-api/models"
 	"time"
@@ -10,16 +12,21 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-func NewStatsService(userDAO userDAO.IUserDAO, sessionService sessionService.ISessionService) IStatsService {
+func NewStatsService(userDAO userDAO.IUserDAO, sessionService sessionService.ISessionService,
+	goalService goalService.IGoalService, eventsService eventsService.IEventsService) IStatsService {
 	return &statService{
 		UserDAO:        userDAO,
 		sessionService: sessionService,
+		goalService:    goalService,
+		eventsService:  eventsService,
 	}
 }
 
 type statService struct {
 	UserDAO        userDAO.IUserDAO
 	sessionService sessionService.ISessionService
+	goalService    goalService.IGoalService
+	eventsService  eventsService.IEventsService
 }
 
 // *******************************************************************
@@ -58,6 +65,8 @@ func (s statService) AddWeight(userID string, weight float32, ctxLog *log.Entry)
 		return err
 	}
 
+	s.checkGoals(userID, ctxLog)
+
 	return nil
 }
 
@@ -97,9 +106,19 @@ func (s statService) AddBodyFat(userID string, bodyFat float32, ctxLog *log.Entr
 		return err
 	}
 
+	s.checkGoals(userID, ctxLog)
+
 	return nil
 }
 
+// checkGoals marks the goals reached with the new measurement as achieved. The measurement is already
+// saved, so a failure here is only logged.
+func (s statService) checkGoals(userID string, ctxLog *log.Entry) {
+	if _, err := s.goalService.CheckGoals(userID, ctxLog); err != nil {
+		ctxLog.Warnf("STATS_SERVICE: Checking goals for user %s failed: %s", userID, err)
+	}
+}
+
 // *******************************************************************
 // GYM ATTENDANCES (STREAK)
 // *******************************************************************
@@ -109,6 +128,7 @@ func (s statService) GetStreakCalendarByYearAndMonth(userID string, year int32,
 
 	ctxLog.Debugf("STATS_SERVICE: Processing GetStreakCalendar request for user: %s", userID)
 
+	// Read only, the streak is evaluated on the writes and by the periodic job
 	user, err := s.UserDAO.GetUserWithAttendance(userID, year, month, ctxLog)
 	if err != nil {
 		return nil, err
@@ -127,51 +147,38 @@ func (s statService) GetStreakCalendarByYearAndMonth(userID string, year int32,
 	return &response, nil
 }
 
-func monday() time.Time {
-	today := time.Now()
-	todayIndex := int(today.Weekday())
-	if todayIndex == 0 { // Sunday is 0 in Weekday(), make it equivalent to 7 for easier math
-		todayIndex = 7
-	}
-	monday := today.AddDate(0, 0, -todayIndex+1).Truncate(24 * time.Hour).Add(-1) // Offset to Monday
-
-	return monday
-}
-
 func (s statService) AddGymAttendance(userID string, date time.Time, ctxLog *log.Entry) error {
 
 	ctxLog.Debugf("STATS_SERVICE: Processing AddGymAttendance request for user: %s", userID)
 
-	// ===== Update current week =====
-	monday := monday()
-
-	// Calculate distantce to monday to know if date is in the current week
-	if date.After(monday) {
-		dateIndex := int(date.Sub(monday).Hours() / 24)
-		if err := s.UserDAO.AddDayToCurrentWeek(userID, dateIndex, ctxLog); err != nil {
-			return err
-		}
+	if err := s.UserDAO.AddGymAttendance(userID, date, ctxLog); err != nil {
+		return err
 	}
 
-	// ===== Update gym attendances =====
-	return s.UserDAO.AddGymAttendance(userID, date, ctxLog)
+	s.recomputeStreak(userID, ctxLog)
+
+	s.eventsService.Publish(eventsService.Event{Type: eventsService.AttendanceAdded, UserID: userID}, ctxLog)
+
+	return nil
 }
 
 func (s statService) DeleteGymAttendance(userID string, date time.Time, ctxLog *log.Entry) error {
 
 	ctxLog.Debugf("STATS_SERVICE: Processing DeleteGymAttendance request for user: %s", userID)
 
-	// ===== Update current week =====
-	monday := monday()
-
-	// Calculate distantce to monday to know if date is in the current week
-	if date.After(monday) {
-		dateIndex := int(date.Sub(monday).Hours() / 24)
-		if err := s.UserDAO.DeleteDayFromCurrentWeek(userID, dateIndex, ctxLog); err != nil {
-			return err
-		}
+	if err := s.UserDAO.DeleteGymAttendance(userID, date, ctxLog); err != nil {
+		return err
 	}
 
-	// ===== Update gym attendances =====
-	return s.UserDAO.DeleteGymAttendance(userID, date, ctxLog)
+	s.recomputeStreak(userID, ctxLog)
+
+	return nil
+}
+
+// recomputeStreak evaluates the past weeks, which protects missed ones with freezes. The attendance is
+// already saved, so a failure here is only logged.
+func (s statService) recomputeStreak(userID string, ctxLog *log.Entry) {
+	if _, err := s.RecomputeStreak(userID, ctxLog); err != nil {
+		ctxLog.Warnf("STATS_SERVICE: Recomputing streak for user %s failed: %s", userID, err)
+	}
 }
@@ -14,7 +14,25 @@ type IStatsService interface {
 	GetFatHistory(userID string, months int32, ctxLog *log.Entry) (*models.MeasurementHistoryResponse, error)
 	AddBodyFat(userID string, bodyFat float32, ctxLog *log.Entry) error
 
+	GetBodyComposition(userID string, authUserID string, months int32, ctxLog *log.Entry) (*models.BodyCompositionResponse, error)
+	GetMeasurementTrend(userID string, authUserID string, measurement string, months int32, window int32, target *float32, ctxLog *log.Entry) (*models.MeasurementTrendResponse, error)
+
 	GetStreakCalendarByYearAndMonth(userID string, year int32, month int32, ctxLog *log.Entry) (*models.StreakCalendarResponse, error)
 	AddGymAttendance(userID string, date time.Time, ctxLog *log.Entry) error
 	DeleteGymAttendance(userID string, date time.Time, ctxLog *log.Entry) error
+	// Evaluates the streak and the current week again from the whole attendance history
+	RecomputeStreak(userID string, ctxLog *log.Entry) (int32, error)
+	// Evaluates the streaks of every user, as the periods that end without attendances are only seen here
+	RecomputeStreaks(ctxLog *log.Entry) error
+	GetAttendanceRange(userID string, from time.Time, to time.Time, ctxLog *log.Entry) (*models.AttendanceRangeResponse, error)
+	// Records the new goal from the current or the next period on, depending on the goal change mode
+	ChangeWeeklyGoal(userID string, weeklyGoal int32, ctxLog *log.Entry) error
+
+	// Adds the day as attended if needed and sets the times of its session
+	AddGymSession(userID string, date time.Time, start time.Time, end time.Time, ctxLog *log.Entry) error
+	GetTrainingTime(userID string, months int32, ctxLog *log.Entry) (*models.TrainingTimeResponse, error)
+
+	GetStreakProtection(userID string, ctxLog *log.Entry) (*models.StreakProtectionResponse, error)
+	AddVacationWeek(userID string, date time.Time, ctxLog *log.Entry) error
+	DeleteVacationWeek(userID string, date time.Time, ctxLog *log.Entry) error
 }
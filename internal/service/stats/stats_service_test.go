@@ -1,13 +1,19 @@
 package stats_service
 
 import (
+	"errors"
 	"fmt"
+	configs "gym-badges-api/config/gym-badges-server"
 	customErrors "gym-badges-api/internal/custom-errors"
+	badgeDAO "gym-badges-api/internal/repository/badge"
 	userDAO "gym-badges-api/internal/repository/user"
+	eventsService "gym-badges-api/internal/service/events"
 	mockDAO "gym-badges-api/mocks/dao"
 	mockService "gym-badges-api/mocks/service"
+	"gym-badges-api/models"
 	toolsLogging "gym-badges-api/tools/logging"
 	toolsTesting "gym-badges-api/tools/testing"
+	"gym-badges-api/tools/utils"
 	"testing"
 	"time"
 
@@ -27,6 +33,8 @@ var _ = Describe("SERVICE: Stats Test Suite", func() {
 		mockCtrl           *gomock.Controller
 		mockUserDAO        *mockDAO.MockIUserDAO
 		mockSessionService *mockService.MockISessionService
+		mockGoalService    *mockService.MockIGoalService
+		mockEventsService  *mockService.MockIEventsService
 		service            IStatsService
 	)
 
@@ -34,7 +42,9 @@ var _ = Describe("SERVICE: Stats Test Suite", func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockUserDAO = mockDAO.NewMockIUserDAO(mockCtrl)
 		mockSessionService = mockService.NewMockISessionService(mockCtrl)
-		service = NewStatsService(mockUserDAO, mockSessionService)
+		mockGoalService = mockService.NewMockIGoalService(mockCtrl)
+		mockEventsService = mockService.NewMockIEventsService(mockCtrl)
+		service = NewStatsService(mockUserDAO, mockSessionService, mockGoalService, mockEventsService)
 	})
 
 	AfterEach(func() {
@@ -125,12 +135,1057 @@ var _ = Describe("SERVICE: Stats Test Suite", func() {
 
 	})
 
+	Context("Add Weight", func() {
+
+		var (
+			ctxLogger *log.Entry
+			userID    string
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+			userID = "admin"
+		})
+
+		It("CASE: Successful add weight checking the goals", func() {
+
+			mockUserDAO.EXPECT().AddWeight(userID, float32(80.5), gomock.Any(), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			mockGoalService.EXPECT().CheckGoals(userID, ctxLogger).
+				Times(1).
+				Return([]int64{4}, nil)
+
+			err := service.AddWeight(userID, 80.5, ctxLogger)
+			Expect(err).To(BeNil())
+		})
+
+		It("CASE: Successful add weight even if checking the goals fails", func() {
+
+			mockUserDAO.EXPECT().AddWeight(userID, float32(80.5), gomock.Any(), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			mockGoalService.EXPECT().CheckGoals(userID, ctxLogger).
+				Times(1).
+				Return(nil, errors.New("panic"))
+
+			err := service.AddWeight(userID, 80.5, ctxLogger)
+			Expect(err).To(BeNil())
+		})
+
+		It("CASE: Add weight failed cause user not exist", func() {
+
+			mockUserDAO.EXPECT().AddWeight(userID, float32(80.5), gomock.Any(), ctxLogger).
+				Times(1).
+				Return(customErrors.BuildNotFoundError("not found"))
+
+			mockGoalService.EXPECT().CheckGoals(gomock.Any(), gomock.Any()).
+				Times(0)
+
+			err := service.AddWeight(userID, 80.5, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.NotFoundError{}))
+		})
+
+	})
+
 	Context("Get Fat History", func() {
 
 		var (
 			ctxLogger *log.Entry
 			userID    string
-			months    int32
+			months    int32
+			user      userDAO.User
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+
+			userID = "admin"
+			months = 3
+
+			user = userDAO.User{
+				ID:    "admin",
+				Email: "admin@admin.com",
+				Name:  "John",
+				FatHistory: []userDAO.FatHistory{
+					{
+						UserID: "admin",
+						Date:   parseTime("2024-11-01T10:30:00"),
+						Fat:    79.0,
+					},
+					{
+						UserID: "admin",
+						Date:   parseTime("2024-11-07T10:30:00"),
+						Fat:    80.5,
+					},
+					{
+						UserID: "admin",
+						Date:   parseTime("2024-11-14T10:30:00"),
+						Fat:    83.0,
+					},
+				},
+			}
+		})
+
+		It("CASE: Successful get fat history", func() {
+
+			mockUserDAO.EXPECT().GetUserWithFatHistory(userID, months, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			response, err := service.GetFatHistory(userID, months, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(len(response.Days)).To(Equal(3))
+			Expect(response.Days[0].Date).To(Equal("2024-11-01"))
+			Expect(response.Days[0].Value).To(Equal(float32(79)))
+			Expect(response.Days[1].Date).To(Equal("2024-11-07"))
+			Expect(response.Days[1].Value).To(Equal(float32(80.5)))
+			Expect(response.Days[2].Date).To(Equal("2024-11-14"))
+			Expect(response.Days[2].Value).To(Equal(float32(83)))
+		})
+
+		It("CASE: Successful retrieval without fat history info", func() {
+
+			user.FatHistory = nil
+
+			mockUserDAO.EXPECT().GetUserWithFatHistory(userID, months, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			response, err := service.GetFatHistory(userID, months, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(len(response.Days)).To(Equal(0))
+		})
+
+		It("CASE: Get fat history failed cause user not exist", func() {
+
+			user.WeightHistory = nil
+
+			mockUserDAO.EXPECT().GetUserWithFatHistory(userID, months, ctxLogger).
+				Times(1).
+				Return(nil, customErrors.BuildNotFoundError("not found"))
+
+			response, err := service.GetFatHistory(userID, months, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.NotFoundError{}))
+			Expect(response).To(BeNil())
+		})
+
+	})
+
+	Context("Get Body Composition", func() {
+
+		var (
+			ctxLogger *log.Entry
+			userID    string
+			months    int32
+			user      userDAO.User
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+
+			userID = "admin"
+			months = 3
+
+			user = userDAO.User{
+				ID:     "admin",
+				Email:  "admin@admin.com",
+				Name:   "John",
+				Height: utils.NewFloat32(180),
+				Sex:    "masculine",
+				WeightHistory: []userDAO.WeightHistory{
+					{UserID: "admin", Date: parseTime("2024-11-01T00:00:00"), Weight: 80},
+					{UserID: "admin", Date: parseTime("2024-11-05T00:00:00"), Weight: 84},
+				},
+				FatHistory: []userDAO.FatHistory{
+					{UserID: "admin", Date: parseTime("2024-11-03T00:00:00"), Fat: 20},
+					{UserID: "admin", Date: parseTime("2024-11-06T00:00:00"), Fat: 18},
+				},
+				Preferences: []userDAO.Preference{
+					{UserID: "admin", ID: 1, On: false},
+					{UserID: "admin", ID: 2, On: true},
+				},
+			}
+		})
+
+		It("CASE: Successful get body composition interpolating missing days", func() {
+
+			mockUserDAO.EXPECT().GetUserWithBodyHistory(userID, months, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			// The owner can see his hidden stats
+			response, err := service.GetBodyComposition(userID, userID, months, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(*response.Height).To(Equal(float32(180)))
+			Expect(len(response.Days)).To(Equal(6))
+
+			Expect(response.Days[0].Date).To(Equal("2024-11-01"))
+			Expect(response.Days[0].Interpolated).To(BeFalse())
+			Expect(*response.Days[0].Bmi).To(Equal(float32(24.69)))
+			Expect(response.Days[0].BodyFat).To(BeNil())
+			Expect(response.Days[0].LeanBodyMass).To(BeNil())
+
+			Expect(response.Days[1].Interpolated).To(BeTrue())
+			Expect(response.Days[1].Weight).To(Equal(float32(81)))
+
+			Expect(response.Days[2].Weight).To(Equal(float32(82)))
+			Expect(*response.Days[2].FatMass).To(Equal(float32(16.4)))
+			Expect(*response.Days[2].LeanBodyMass).To(Equal(float32(65.6)))
+			Expect(*response.Days[2].Ffmi).To(Equal(float32(20.25)))
+
+			Expect(response.Days[3].Interpolated).To(BeTrue())
+			Expect(*response.Days[3].BodyFat).To(Equal(float32(19.33)))
+
+			// Last weight is kept after its date
+			Expect(response.Days[5].Date).To(Equal("2024-11-06"))
+			Expect(response.Days[5].Interpolated).To(BeFalse())
+			Expect(response.Days[5].Weight).To(Equal(float32(84)))
+			Expect(*response.Days[5].BodyFat).To(Equal(float32(18)))
+		})
+
+		It("CASE: Successful retrieval without weight history info", func() {
+
+			user.WeightHistory = nil
+
+			mockUserDAO.EXPECT().GetUserWithBodyHistory(userID, months, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			response, err := service.GetBodyComposition(userID, userID, months, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(len(response.Days)).To(Equal(0))
+		})
+
+		It("CASE: Get body composition failed cause the user hides it", func() {
+
+			mockUserDAO.EXPECT().GetUserWithBodyHistory(userID, months, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			response, err := service.GetBodyComposition(userID, "other", months, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.ForbiddenError{}))
+			Expect(response).To(BeNil())
+		})
+
+		It("CASE: Get body composition failed cause user not exist", func() {
+
+			mockUserDAO.EXPECT().GetUserWithBodyHistory(userID, months, ctxLogger).
+				Times(1).
+				Return(nil, customErrors.BuildNotFoundError("not found"))
+
+			response, err := service.GetBodyComposition(userID, "other", months, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.NotFoundError{}))
+			Expect(response).To(BeNil())
+		})
+
+	})
+
+	Context("Get Measurement Trend", func() {
+
+		var (
+			ctxLogger *log.Entry
+			userID    string
+			months    int32
+			user      userDAO.User
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+
+			userID = "admin"
+			months = 3
+
+			user = userDAO.User{
+				ID:    "admin",
+				Email: "admin@admin.com",
+				Name:  "John",
+				WeightHistory: []userDAO.WeightHistory{
+					{UserID: "admin", Date: parseTime("2024-11-01T00:00:00"), Weight: 84},
+					{UserID: "admin", Date: parseTime("2024-11-08T00:00:00"), Weight: 83},
+					{UserID: "admin", Date: parseTime("2024-11-15T00:00:00"), Weight: 82},
+					{UserID: "admin", Date: parseTime("2024-11-22T00:00:00"), Weight: 81},
+					{UserID: "admin", Date: parseTime("2024-11-29T00:00:00"), Weight: 80},
+				},
+				FatHistory: []userDAO.FatHistory{
+					{UserID: "admin", Date: parseTime("2024-11-01T00:00:00"), Fat: 18},
+				},
+			}
+		})
+
+		It("CASE: Successful get weight trend with goal projection", func() {
+
+			mockUserDAO.EXPECT().GetUserWithWeightHistory(userID, months, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			response, err := service.GetMeasurementTrend(userID, userID, "weight", months, 0, utils.NewFloat32(78), ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response.Measurement).To(Equal("weight"))
+			Expect(len(response.Days)).To(Equal(5))
+			Expect(response.Days[0].Trend).To(Equal(float32(84)))
+			Expect(response.Days[1].Trend).To(Equal(float32(83.48)))
+			Expect(*response.Trend).To(Equal(float32(80.87)))
+			Expect(*response.WeeklyRate).To(Equal(float32(-0.95)))
+
+			Expect(response.Fit.WindowDays).To(Equal(int32(28)))
+			Expect(response.Fit.Points).To(Equal(int32(5)))
+			Expect(response.Fit.SlopePerWeek).To(Equal(float32(-1)))
+			Expect(response.Fit.Value).To(Equal(float32(80)))
+			Expect(response.Fit.RSquared).To(Equal(float32(1)))
+
+			Expect(response.Projection.Reachable).To(BeTrue())
+			Expect(*response.Projection.Days).To(Equal(int32(21)))
+			Expect(*response.Projection.Date).To(Equal("2024-12-20"))
+		})
+
+		It("CASE: Goal is not reachable when moving away from it", func() {
+
+			mockUserDAO.EXPECT().GetUserWithWeightHistory(userID, months, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			response, err := service.GetMeasurementTrend(userID, userID, "weight", months, 14, utils.NewFloat32(90), ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response.Fit.Points).To(Equal(int32(3)))
+			Expect(response.Projection.Reachable).To(BeFalse())
+			Expect(response.Projection.Date).To(BeNil())
+		})
+
+		It("CASE: Successful get fat trend without enough measurements to fit", func() {
+
+			mockUserDAO.EXPECT().GetUserWithFatHistory(userID, months, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			response, err := service.GetMeasurementTrend(userID, userID, "fat", months, 0, nil, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(len(response.Days)).To(Equal(1))
+			Expect(*response.Trend).To(Equal(float32(18)))
+			Expect(response.WeeklyRate).To(BeNil())
+			Expect(response.Fit).To(BeNil())
+			Expect(response.Projection).To(BeNil())
+		})
+
+		It("CASE: Successful get weight trend of another user", func() {
+
+			mockUserDAO.EXPECT().GetUser(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().GetUserWithWeightHistory(userID, months, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			response, err := service.GetMeasurementTrend(userID, "other", "weight", months, 0, nil, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(len(response.Days)).To(Equal(5))
+		})
+
+		It("CASE: Get measurement trend failed cause the user hides the body stats", func() {
+
+			user.Preferences = []userDAO.Preference{
+				{UserID: "admin", ID: 1, On: false},
+				{UserID: "admin", ID: 2, On: true},
+			}
+
+			mockUserDAO.EXPECT().GetUser(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			response, err := service.GetMeasurementTrend(userID, "other", "weight", months, 0, nil, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.ForbiddenError{}))
+			Expect(response).To(BeNil())
+		})
+
+		It("CASE: Get measurement trend failed cause of an unknown measurement", func() {
+
+			response, err := service.GetMeasurementTrend(userID, userID, "height", months, 0, nil, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.BadRequestError{}))
+			Expect(response).To(BeNil())
+		})
+
+	})
+
+	Context("Recompute Streak", func() {
+
+		var (
+			ctxLogger *log.Entry
+			userID    string
+			user      userDAO.User
+			monday    time.Time
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+
+			userID = "admin"
+			monday = weekStart(time.Now())
+
+			attendance := func(days ...int) []userDAO.GymAttendance {
+				attendances := make([]userDAO.GymAttendance, len(days))
+				for i, day := range days {
+					attendances[i] = userDAO.GymAttendance{UserID: userID, Date: monday.AddDate(0, 0, day)}
+				}
+				return attendances
+			}
+
+			user = userDAO.User{
+				ID:         userID,
+				Streak:     10,
+				WeeklyGoal: 3,
+				// Three weeks ago fell short, the last two weeks met the goal and this week has one session
+				GymAttendance: attendance(-21, -19, -14, -13, -11, -7, -5, -5, -1, 0),
+			}
+		})
+
+		It("CASE: Successful recompute streak with the current week in progress", func() {
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().UpdateStreak(userID, int32(2), []bool{true, false, false, false, false, false, false}, int32(3), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			streak, err := service.RecomputeStreak(userID, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(streak).To(Equal(int32(2)))
+		})
+
+		It("CASE: Successful recompute streak with the current week goal met", func() {
+
+			user.WeeklyGoal = 2
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			user.GymAttendance = append(user.GymAttendance, userDAO.GymAttendance{UserID: userID, Date: monday.AddDate(0, 0, 1)})
+
+			mockUserDAO.EXPECT().UpdateStreak(userID, int32(4), []bool{true, true, false, false, false, false, false}, int32(2), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			streak, err := service.RecomputeStreak(userID, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(streak).To(Equal(int32(4)))
+		})
+
+		It("CASE: Successful recompute streak with weeks starting on Sunday", func() {
+
+			user.WeekStart = 7
+			sunday := weeksStartingOn(7).start(time.Now())
+			user.GymAttendance = []userDAO.GymAttendance{
+				{UserID: userID, Date: sunday.AddDate(0, 0, -14)},
+				{UserID: userID, Date: sunday.AddDate(0, 0, -12)},
+				{UserID: userID, Date: sunday.AddDate(0, 0, -8)},
+				{UserID: userID, Date: sunday.AddDate(0, 0, -7)},
+				{UserID: userID, Date: sunday.AddDate(0, 0, -4)},
+				{UserID: userID, Date: sunday.AddDate(0, 0, -1)},
+				{UserID: userID, Date: sunday},
+			}
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().UpdateStreak(userID, int32(2), []bool{true, false, false, false, false, false, false}, int32(3), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			streak, err := service.RecomputeStreak(userID, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(streak).To(Equal(int32(2)))
+		})
+
+		It("CASE: Successful recompute streak with goal periods of 10 days", func() {
+
+			// The current period started 5 days ago
+			anchor := truncateDay(time.Now()).AddDate(0, 0, -25)
+			user.GoalPeriodDays = 10
+			user.GoalPeriodAnchor = &anchor
+			user.GymAttendance = []userDAO.GymAttendance{
+				{UserID: userID, Date: anchor.AddDate(0, 0, 1)},
+				{UserID: userID, Date: anchor.AddDate(0, 0, 3)},
+				{UserID: userID, Date: anchor.AddDate(0, 0, 9)},
+				{UserID: userID, Date: anchor.AddDate(0, 0, 10)},
+				{UserID: userID, Date: anchor.AddDate(0, 0, 12)},
+				{UserID: userID, Date: anchor.AddDate(0, 0, 19)},
+				{UserID: userID, Date: anchor.AddDate(0, 0, 25)},
+			}
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			currentPeriod := make([]bool, 10)
+			currentPeriod[5] = true
+			mockUserDAO.EXPECT().UpdateStreak(userID, int32(2), currentPeriod, int32(3), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			streak, err := service.RecomputeStreak(userID, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(streak).To(Equal(int32(2)))
+		})
+
+		It("CASE: Successful recompute streak with the goal in force for each week", func() {
+
+			// The weeks before the first change keep its goal
+			user.WeeklyGoal = 3
+			user.GoalHistory = []userDAO.WeeklyGoalChange{
+				{UserID: userID, EffectiveFrom: monday.AddDate(0, 0, -21), WeeklyGoal: 2},
+				{UserID: userID, EffectiveFrom: monday.AddDate(0, 0, -14), WeeklyGoal: 3},
+				{UserID: userID, EffectiveFrom: monday, WeeklyGoal: 1},
+			}
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().UpdateStreak(userID, int32(4), []bool{true, false, false, false, false, false, false}, int32(1), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			streak, err := service.RecomputeStreak(userID, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(streak).To(Equal(int32(4)))
+		})
+
+		It("CASE: Recompute streak failed cause user not exist", func() {
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(1).
+				Return(nil, customErrors.BuildNotFoundError("not found"))
+
+			mockUserDAO.EXPECT().UpdateStreak(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			streak, err := service.RecomputeStreak(userID, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.NotFoundError{}))
+			Expect(streak).To(Equal(int32(0)))
+		})
+
+		It("CASE: Successful recompute streaks of every user", func() {
+
+			mockUserDAO.EXPECT().GetUserIDs(ctxLogger).
+				Times(1).
+				Return([]string{userID}, nil)
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().UpdateStreak(userID, int32(2), gomock.Any(), gomock.Any(), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			err := service.RecomputeStreaks(ctxLogger)
+			Expect(err).To(BeNil())
+		})
+
+		It("CASE: Recompute streaks goes on with the other users when one fails", func() {
+
+			mockUserDAO.EXPECT().GetUserIDs(ctxLogger).
+				Times(1).
+				Return([]string{"deleted", userID}, nil)
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection("deleted", ctxLogger).
+				Times(1).
+				Return(nil, customErrors.BuildNotFoundError("not found"))
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().UpdateStreak(userID, int32(2), gomock.Any(), gomock.Any(), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			err := service.RecomputeStreaks(ctxLogger)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("CASE: Successful recompute streak using a freeze for a missed week", func() {
+
+			// Two weeks ago was missed
+			user.WeeklyGoal = 2
+			user.GymAttendance = []userDAO.GymAttendance{
+				{UserID: userID, Date: monday.AddDate(0, 0, -21)},
+				{UserID: userID, Date: monday.AddDate(0, 0, -20)},
+				{UserID: userID, Date: monday.AddDate(0, 0, -7)},
+				{UserID: userID, Date: monday.AddDate(0, 0, -6)},
+			}
+			user.StreakFreezes = []userDAO.StreakFreeze{
+				{ID: 1, UserID: userID, Reason: userDAO.FreezeReasonExperience, EarnedAt: monday.AddDate(0, 0, -60)},
+			}
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().SaveStreakFreezes(userID, gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(userID string, freezes []userDAO.StreakFreeze, ctxLog *log.Entry) error {
+					Expect(freezes).To(HaveLen(1))
+					Expect(freezes[0].ID).To(Equal(int64(1)))
+					Expect(*freezes[0].UsedWeek).To(Equal(monday.AddDate(0, 0, -14)))
+					Expect(freezes[0].UsedAt).ToNot(BeNil())
+					return nil
+				})
+
+			// Last week and three weeks ago
+			mockUserDAO.EXPECT().UpdateStreak(userID, int32(2), gomock.Any(), gomock.Any(), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			streak, err := service.RecomputeStreak(userID, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(streak).To(Equal(int32(2)))
+		})
+
+		It("CASE: Successful recompute streak giving each missed week a freeze earned before its end", func() {
+
+			user.WeeklyGoal = 2
+			user.GymAttendance = []userDAO.GymAttendance{
+				{UserID: userID, Date: monday.AddDate(0, 0, -28)},
+				{UserID: userID, Date: monday.AddDate(0, 0, -27)},
+				{UserID: userID, Date: monday.AddDate(0, 0, -7)},
+				{UserID: userID, Date: monday.AddDate(0, 0, -6)},
+			}
+			user.StreakFreezes = []userDAO.StreakFreeze{
+				{ID: 1, UserID: userID, Reason: userDAO.FreezeReasonExperience, EarnedAt: monday.AddDate(0, 0, -30)},
+				{ID: 2, UserID: userID, Reason: userDAO.FreezeReasonExperience, EarnedAt: monday.AddDate(0, 0, -12)},
+			}
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().SaveStreakFreezes(userID, gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(userID string, freezes []userDAO.StreakFreeze, ctxLog *log.Entry) error {
+					Expect(freezes).To(HaveLen(2))
+					Expect(freezes[0].ID).To(Equal(int64(2)))
+					Expect(*freezes[0].UsedWeek).To(Equal(monday.AddDate(0, 0, -14)))
+					Expect(freezes[1].ID).To(Equal(int64(1)))
+					Expect(*freezes[1].UsedWeek).To(Equal(monday.AddDate(0, 0, -21)))
+					return nil
+				})
+
+			mockUserDAO.EXPECT().UpdateStreak(userID, int32(2), gomock.Any(), gomock.Any(), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			streak, err := service.RecomputeStreak(userID, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(streak).To(Equal(int32(2)))
+		})
+
+		It("CASE: Successful recompute streak with vacation and protected weeks", func() {
+
+			user.WeeklyGoal = 2
+			user.GymAttendance = []userDAO.GymAttendance{
+				{UserID: userID, Date: monday.AddDate(0, 0, -28)},
+				{UserID: userID, Date: monday.AddDate(0, 0, -27)},
+				{UserID: userID, Date: monday.AddDate(0, 0, -7)},
+				{UserID: userID, Date: monday.AddDate(0, 0, -6)},
+			}
+			usedWeek := monday.AddDate(0, 0, -21)
+			user.StreakFreezes = []userDAO.StreakFreeze{
+				{ID: 1, UserID: userID, Reason: userDAO.FreezeReasonExperience, EarnedAt: monday.AddDate(0, 0, -30), UsedWeek: &usedWeek},
+			}
+			// Any day of the week is stored as its Monday
+			user.VacationWeeks = []userDAO.VacationWeek{{UserID: userID, Week: monday.AddDate(0, 0, -14)}}
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().SaveStreakFreezes(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			mockUserDAO.EXPECT().UpdateStreak(userID, int32(2), gomock.Any(), gomock.Any(), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			streak, err := service.RecomputeStreak(userID, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(streak).To(Equal(int32(2)))
+		})
+
+		It("CASE: Successful recompute streak keeping the freezes when the streak is already broken", func() {
+
+			user.WeeklyGoal = 2
+			user.GymAttendance = []userDAO.GymAttendance{
+				{UserID: userID, Date: monday.AddDate(0, 0, -28)},
+			}
+			user.StreakFreezes = []userDAO.StreakFreeze{
+				{ID: 1, UserID: userID, Reason: userDAO.FreezeReasonExperience, EarnedAt: monday.AddDate(0, 0, -60)},
+				{ID: 2, UserID: userID, Reason: userDAO.FreezeReasonExperience, EarnedAt: monday.AddDate(0, 0, -60)},
+			}
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().SaveStreakFreezes(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			mockUserDAO.EXPECT().UpdateStreak(userID, int32(0), gomock.Any(), gomock.Any(), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			streak, err := service.RecomputeStreak(userID, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(streak).To(Equal(int32(0)))
+		})
+
+		It("CASE: Successful recompute streak earning freezes with experience and consistency badges", func() {
+
+			configs.Basic.FreezeExperience = 2500
+			defer func() { configs.Basic.FreezeExperience = 0 }()
+
+			badgeID := int16(66)
+			user.Experience = 5200
+			rule := func(badgeID int16, metric string) *badgeDAO.BadgeRule {
+				return &badgeDAO.BadgeRule{BadgeID: badgeID, BadgeRuleClause: badgeDAO.BadgeRuleClause{Metric: metric}}
+			}
+			user.Badges = []*badgeDAO.Badge{
+				{ID: 66, Rule: rule(66, badgeDAO.MetricStreak)},
+				{ID: 67, Rule: rule(67, badgeDAO.MetricStreak)},
+				{ID: 80, Rule: rule(80, badgeDAO.MetricGlobalRank)},
+				{ID: 99, Rule: rule(99, badgeDAO.MetricSessionsBetween)},
+				{ID: 1},
+			}
+			user.StreakFreezes = []userDAO.StreakFreeze{
+				{ID: 1, UserID: userID, Reason: userDAO.FreezeReasonExperience, EarnedAt: monday.AddDate(0, 0, -60)},
+				{ID: 2, UserID: userID, Reason: userDAO.FreezeReasonBadge, BadgeID: &badgeID, EarnedAt: monday.AddDate(0, 0, -60)},
+			}
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().SaveStreakFreezes(userID, gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(userID string, freezes []userDAO.StreakFreeze, ctxLog *log.Entry) error {
+					Expect(freezes).To(HaveLen(3))
+					Expect(freezes[0].Reason).To(Equal(userDAO.FreezeReasonExperience))
+					Expect(freezes[1].Reason).To(Equal(userDAO.FreezeReasonBadge))
+					Expect(*freezes[1].BadgeID).To(Equal(int16(67)))
+					Expect(freezes[1].UsedWeek).To(BeNil())
+					// The rankings are not consistency badges
+					Expect(*freezes[2].BadgeID).To(Equal(int16(99)))
+					return nil
+				})
+
+			mockUserDAO.EXPECT().UpdateStreak(userID, int32(2), gomock.Any(), gomock.Any(), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			streak, err := service.RecomputeStreak(userID, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(streak).To(Equal(int32(2)))
+		})
+
+	})
+
+	Context("Change Weekly Goal", func() {
+
+		var (
+			ctxLogger *log.Entry
+			userID    string
+			user      userDAO.User
+			monday    time.Time
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+
+			userID = "admin"
+			monday = weekStart(time.Now())
+
+			user = userDAO.User{
+				ID:            userID,
+				WeeklyGoal:    3,
+				GymAttendance: []userDAO.GymAttendance{{UserID: userID, Date: monday}},
+				CreatedAt:     monday.AddDate(0, 0, -60),
+			}
+		})
+
+		AfterEach(func() {
+			configs.Basic.GoalChangeMode = ""
+		})
+
+		It("CASE: Successful change weekly goal from the next week", func() {
+
+			configs.Basic.GoalChangeMode = GoalChangeNextPeriod
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(2).
+				Return(&user, nil)
+
+			// The first goal is recorded for the weeks before the change
+			mockUserDAO.EXPECT().SaveWeeklyGoalChanges(userID, []userDAO.WeeklyGoalChange{
+				{EffectiveFrom: weekStart(user.CreatedAt), WeeklyGoal: 3},
+				{EffectiveFrom: monday.AddDate(0, 0, 7), WeeklyGoal: 5},
+			}, ctxLogger).
+				Times(1).
+				Return(nil)
+
+			mockUserDAO.EXPECT().UpdateStreak(userID, gomock.Any(), gomock.Any(), int32(3), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			err := service.ChangeWeeklyGoal(userID, 5, ctxLogger)
+			Expect(err).To(BeNil())
+		})
+
+		It("CASE: Successful change weekly goal of a locked week without sessions", func() {
+
+			configs.Basic.GoalChangeMode = GoalChangeLocked
+			user.GymAttendance = []userDAO.GymAttendance{{UserID: userID, Date: monday.AddDate(0, 0, -3)}}
+			user.GoalHistory = []userDAO.WeeklyGoalChange{
+				{UserID: userID, EffectiveFrom: monday.AddDate(0, 0, -28), WeeklyGoal: 3},
+			}
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(2).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().SaveWeeklyGoalChanges(userID, []userDAO.WeeklyGoalChange{
+				{EffectiveFrom: monday, WeeklyGoal: 2},
+			}, ctxLogger).
+				Times(1).
+				Return(nil)
+
+			mockUserDAO.EXPECT().UpdateStreak(userID, gomock.Any(), gomock.Any(), gomock.Any(), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			err := service.ChangeWeeklyGoal(userID, 2, ctxLogger)
+			Expect(err).To(BeNil())
+		})
+
+		It("CASE: Change weekly goal failed cause the locked week has sessions", func() {
+
+			configs.Basic.GoalChangeMode = GoalChangeLocked
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().SaveWeeklyGoalChanges(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			err := service.ChangeWeeklyGoal(userID, 2, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.ConflictError{}))
+		})
+
+		It("CASE: Change weekly goal failed cause it is higher than the days of the period", func() {
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().SaveWeeklyGoalChanges(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			err := service.ChangeWeeklyGoal(userID, 8, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.BadRequestError{}))
+		})
+
+		It("CASE: Successful change weekly goal to the same goal", func() {
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().SaveWeeklyGoalChanges(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			err := service.ChangeWeeklyGoal(userID, 3, ctxLogger)
+			Expect(err).To(BeNil())
+		})
+
+	})
+
+	Context("Streak Protection", func() {
+
+		var (
+			ctxLogger *log.Entry
+			userID    string
+			user      userDAO.User
+			monday    time.Time
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+
+			configs.Basic.MaxVacationWeeks = 2
+
+			userID = "admin"
+			monday = weekStart(time.Now())
+
+			usedWeek := monday.AddDate(0, 0, -14)
+			usedAt := monday.AddDate(0, 0, -3)
+			user = userDAO.User{
+				ID:         userID,
+				WeeklyGoal: 3,
+				StreakFreezes: []userDAO.StreakFreeze{
+					{ID: 1, UserID: userID, Reason: userDAO.FreezeReasonExperience, EarnedAt: parseTime("2024-03-01T10:00:00"),
+						UsedWeek: &usedWeek, UsedAt: &usedAt},
+					{ID: 2, UserID: userID, Reason: userDAO.FreezeReasonExperience, EarnedAt: parseTime("2024-05-01T10:00:00")},
+				},
+				VacationWeeks: []userDAO.VacationWeek{
+					{UserID: userID, Week: monday.AddDate(0, 0, 7)},
+				},
+			}
+		})
+
+		It("CASE: Successful get streak protection", func() {
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().UpdateStreak(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			response, err := service.GetStreakProtection(userID, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response.AvailableFreezes).To(Equal(int32(1)))
+			Expect(response.Freezes).To(HaveLen(2))
+			Expect(*response.Freezes[0].UsedWeek).To(Equal(monday.AddDate(0, 0, -14).Format("2006-01-02")))
+			Expect(response.Freezes[0].EarnedAt).To(Equal("2024-03-01"))
+			Expect(response.Freezes[1].UsedWeek).To(BeNil())
+			Expect(response.VacationWeeks).To(Equal([]string{monday.AddDate(0, 0, 7).Format("2006-01-02")}))
+		})
+
+		It("CASE: Successful add vacation week from any day of the week", func() {
+
+			// Read again by the recomputation once the vacation weeks change
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(2).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().AddVacationWeek(userID, monday.AddDate(0, 0, 14), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			mockUserDAO.EXPECT().UpdateStreak(userID, gomock.Any(), gomock.Any(), gomock.Any(), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			err := service.AddVacationWeek(userID, monday.AddDate(0, 0, 18), ctxLogger)
+			Expect(err).To(BeNil())
+		})
+
+		It("CASE: Add vacation week failed cause it is already declared", func() {
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().AddVacationWeek(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			err := service.AddVacationWeek(userID, monday.AddDate(0, 0, 9), ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.ConflictError{}))
+		})
+
+		It("CASE: Add vacation week failed cause it is in the past", func() {
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().AddVacationWeek(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			err := service.AddVacationWeek(userID, monday.AddDate(0, 0, -1), ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.BadRequestError{}))
+		})
+
+		It("CASE: Add vacation week failed cause there are no vacation weeks left this year", func() {
+
+			// Weeks of the same year as the new one
+			week := time.Date(monday.Year()+1, time.March, 2, 0, 0, 0, 0, time.UTC)
+			user.VacationWeeks = []userDAO.VacationWeek{
+				{UserID: userID, Week: weekStart(week)},
+				{UserID: userID, Week: weekStart(week.AddDate(0, 0, 7))},
+			}
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().AddVacationWeek(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			err := service.AddVacationWeek(userID, week.AddDate(0, 0, 28), ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.BadRequestError{}))
+		})
+
+		It("CASE: Successful delete vacation week", func() {
+
+			// Read again by the recomputation once the vacation weeks change
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(2).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().DeleteVacationWeek(userID, monday.AddDate(0, 0, 7), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			mockUserDAO.EXPECT().UpdateStreak(userID, gomock.Any(), gomock.Any(), gomock.Any(), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			err := service.DeleteVacationWeek(userID, monday.AddDate(0, 0, 7), ctxLogger)
+			Expect(err).To(BeNil())
+		})
+
+		It("CASE: Delete vacation week failed cause it is not declared", func() {
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().DeleteVacationWeek(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			err := service.DeleteVacationWeek(userID, monday, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.NotFoundError{}))
+		})
+
+		It("CASE: Delete vacation week failed cause it is in the past", func() {
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().DeleteVacationWeek(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			err := service.DeleteVacationWeek(userID, monday.AddDate(0, 0, -7), ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.BadRequestError{}))
+		})
+
+	})
+
+	Context("Get Attendance Range", func() {
+
+		var (
+			ctxLogger *log.Entry
+			userID    string
+			from      time.Time
+			to        time.Time
 			user      userDAO.User
 		)
 
@@ -138,75 +1193,321 @@ var _ = Describe("SERVICE: Stats Test Suite", func() {
 			ctxLogger = toolsLogging.BuildLogger()
 
 			userID = "admin"
-			months = 3
+			from = parseTime("2024-01-01T00:00:00")
+			to = parseTime("2024-01-31T00:00:00")
+
+			attendance := make([]userDAO.GymAttendance, 0)
+			for _, date := range []string{"2024-01-01", "2024-01-03", "2024-01-08", "2024-01-12", "2024-01-22",
+				"2024-01-29", "2024-01-30"} {
+				attendance = append(attendance, userDAO.GymAttendance{UserID: userID, Date: parseTime(date + "T10:30:00")})
+			}
 
 			user = userDAO.User{
-				ID:    "admin",
-				Email: "admin@admin.com",
-				Name:  "John",
-				FatHistory: []userDAO.FatHistory{
-					{
-						UserID: "admin",
-						Date:   parseTime("2024-11-01T10:30:00"),
-						Fat:    79.0,
-					},
-					{
-						UserID: "admin",
-						Date:   parseTime("2024-11-07T10:30:00"),
-						Fat:    80.5,
-					},
-					{
-						UserID: "admin",
-						Date:   parseTime("2024-11-14T10:30:00"),
-						Fat:    83.0,
-					},
+				ID:            userID,
+				WeeklyGoal:    2,
+				GymAttendance: attendance,
+				VacationWeeks: []userDAO.VacationWeek{
+					{UserID: userID, Week: parseTime("2024-01-15T00:00:00")},
 				},
 			}
 		})
 
-		It("CASE: Successful get fat history", func() {
+		It("CASE: Successful get attendance range with its aggregates", func() {
 
-			mockUserDAO.EXPECT().GetUserWithFatHistory(userID, months, ctxLogger).
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
 				Times(1).
 				Return(&user, nil)
 
-			response, err := service.GetFatHistory(userID, months, ctxLogger)
+			mockUserDAO.EXPECT().UpdateStreak(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			response, err := service.GetAttendanceRange(userID, from, to, ctxLogger)
 			Expect(err).To(BeNil())
-			Expect(len(response.Days)).To(Equal(3))
-			Expect(response.Days[0].Date).To(Equal("2024-11-01"))
-			Expect(response.Days[0].Value).To(Equal(float32(79)))
-			Expect(response.Days[1].Date).To(Equal("2024-11-07"))
-			Expect(response.Days[1].Value).To(Equal(float32(80.5)))
-			Expect(response.Days[2].Date).To(Equal("2024-11-14"))
-			Expect(response.Days[2].Value).To(Equal(float32(83)))
+			Expect(response.From).To(Equal("2024-01-01"))
+			Expect(response.To).To(Equal("2024-01-31"))
+			Expect(response.WeeklyGoal).To(Equal(int32(2)))
+			Expect(response.Days).To(HaveLen(31))
+			Expect(*response.Days[0]).To(Equal(models.AttendanceDay{Date: "2024-01-01", Count: 1}))
+			Expect(*response.Days[1]).To(Equal(models.AttendanceDay{Date: "2024-01-02", Count: 0}))
+			Expect(response.Weeks).To(Equal([]*models.AttendanceWeek{
+				{Week: "2024-01-01", Sessions: 2, WeeklyGoal: 2, GoalMet: true},
+				{Week: "2024-01-08", Sessions: 2, WeeklyGoal: 2, GoalMet: true},
+				{Week: "2024-01-15", Sessions: 0, WeeklyGoal: 2, Protected: true},
+				{Week: "2024-01-22", Sessions: 1, WeeklyGoal: 2},
+				{Week: "2024-01-29", Sessions: 2, WeeklyGoal: 2, GoalMet: true},
+			}))
+			Expect(response.TotalSessions).To(Equal(int32(7)))
+			Expect(response.SessionsPerWeekday).To(Equal([]int32{4, 1, 1, 0, 1, 0, 0}))
+			Expect(response.AverageSessionsPerWeek).To(Equal(float32(1.58)))
+			Expect(response.CurrentStreak).To(Equal(int32(0)))
+			// The vacation week does not break the first run, the week of the 22nd does
+			Expect(response.LongestStreak).To(Equal(int32(2)))
 		})
 
-		It("CASE: Successful retrieval without fat history info", func() {
+		It("CASE: Successful get attendance range without attendance", func() {
 
-			user.FatHistory = nil
+			user.GymAttendance = nil
 
-			mockUserDAO.EXPECT().GetUserWithFatHistory(userID, months, ctxLogger).
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
 				Times(1).
 				Return(&user, nil)
 
-			response, err := service.GetFatHistory(userID, months, ctxLogger)
+			mockUserDAO.EXPECT().UpdateStreak(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			response, err := service.GetAttendanceRange(userID, from, from, ctxLogger)
 			Expect(err).To(BeNil())
-			Expect(len(response.Days)).To(Equal(0))
+			Expect(response.Days).To(Equal([]*models.AttendanceDay{{Date: "2024-01-01", Count: 0}}))
+			Expect(response.Weeks).To(HaveLen(1))
+			Expect(response.TotalSessions).To(Equal(int32(0)))
+			Expect(response.AverageSessionsPerWeek).To(Equal(float32(0)))
+			Expect(response.LongestStreak).To(Equal(int32(0)))
 		})
 
-		It("CASE: Get fat history failed cause user not exist", func() {
+		It("CASE: Get attendance range failed because the range ends before it starts", func() {
 
-			user.WeightHistory = nil
+			response, err := service.GetAttendanceRange(userID, to, from, ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+			Expect(response).To(BeNil())
+		})
 
-			mockUserDAO.EXPECT().GetUserWithFatHistory(userID, months, ctxLogger).
+		It("CASE: Get attendance range failed because the range is too long", func() {
+
+			response, err := service.GetAttendanceRange(userID, from, from.AddDate(3, 0, 0), ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+			Expect(response).To(BeNil())
+		})
+
+		It("CASE: Get attendance range failed because the user does not exist", func() {
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
 				Times(1).
-				Return(nil, customErrors.BuildNotFoundError("not found"))
+				Return(nil, customErrors.BuildNotFoundError("test error"))
 
-			response, err := service.GetFatHistory(userID, months, ctxLogger)
-			Expect(err).To(BeAssignableToTypeOf(customErrors.NotFoundError{}))
+			response, err := service.GetAttendanceRange(userID, from, to, ctxLogger)
+			Expect(errors.As(err, &customErrors.NotFound)).To(BeTrue())
 			Expect(response).To(BeNil())
 		})
+	})
+
+	Context("Add Gym Session", func() {
+
+		var (
+			ctxLogger *log.Entry
+			userID    string
+			date      time.Time
+			start     time.Time
+			end       time.Time
+			user      userDAO.User
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+
+			userID = "admin"
+			date = parseTime("2024-12-03T00:00:00")
+			// 06:30 in the local time of the user is 05:30 UTC
+			start = time.Date(2024, 12, 3, 6, 30, 0, 0, time.FixedZone("CET", 3600))
+			end = start.Add(90 * time.Minute)
+
+			previousStart := parseTime("2024-12-02T22:00:00")
+			previousEnd := parseTime("2024-12-02T23:30:00")
+			user = userDAO.User{
+				ID:         userID,
+				WeeklyGoal: 3,
+				GymAttendance: []userDAO.GymAttendance{
+					{UserID: userID, Date: parseTime("2024-12-02T00:00:00"), StartTime: &previousStart, EndTime: &previousEnd},
+				},
+			}
+		})
+
+		It("CASE: Successful add session on a new day", func() {
+
+			mockUserDAO.EXPECT().GetUserWithHistoryBetween(userID, parseTime("2024-12-02T00:00:00"),
+				parseTime("2024-12-04T00:00:00"), ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().AddGymAttendance(userID, date, ctxLogger).
+				Times(1).
+				Return(nil)
+
+			mockUserDAO.EXPECT().GetUserWithStreakProtection(userID, ctxLogger).
+				Times(1).
+				Return(&userDAO.User{ID: userID, WeeklyGoal: 3}, nil)
+
+			mockUserDAO.EXPECT().UpdateStreak(userID, int32(0), gomock.Any(), gomock.Any(), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			mockUserDAO.EXPECT().SetGymSession(userID, gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(_ string, session userDAO.GymAttendance, _ *log.Entry) error {
+					Expect(session.Date).To(Equal(date))
+					Expect(*session.StartTime).To(Equal(parseTime("2024-12-03T05:30:00")))
+					Expect(*session.EndTime).To(Equal(parseTime("2024-12-03T07:00:00")))
+					Expect(*session.StartMinute).To(Equal(int16(390)))
+					return nil
+				})
+
+			// Once for the new attendance and once for its times
+			mockEventsService.EXPECT().Publish(eventsService.Event{Type: eventsService.AttendanceAdded, UserID: userID}, ctxLogger).
+				Times(2)
+
+			err := service.AddGymSession(userID, date, start, end, ctxLogger)
+			Expect(err).To(BeNil())
+		})
+
+		It("CASE: Successful add session on an already attended day", func() {
+
+			user.GymAttendance = append(user.GymAttendance, userDAO.GymAttendance{UserID: userID, Date: date})
+
+			mockUserDAO.EXPECT().GetUserWithHistoryBetween(userID, gomock.Any(), gomock.Any(), ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			mockUserDAO.EXPECT().AddGymAttendance(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			mockUserDAO.EXPECT().SetGymSession(userID, gomock.Any(), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			mockEventsService.EXPECT().Publish(eventsService.Event{Type: eventsService.AttendanceAdded, UserID: userID}, ctxLogger).
+				Times(1)
+
+			err := service.AddGymSession(userID, date, start, end, ctxLogger)
+			Expect(err).To(BeNil())
+		})
+
+		It("CASE: Add session failed because it overlaps the session of the previous night", func() {
+
+			previousEnd := parseTime("2024-12-03T06:00:00")
+			user.GymAttendance[0].EndTime = &previousEnd
+
+			mockUserDAO.EXPECT().GetUserWithHistoryBetween(userID, gomock.Any(), gomock.Any(), ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			err := service.AddGymSession(userID, date, start, end, ctxLogger)
+			Expect(errors.As(err, &customErrors.Conflict)).To(BeTrue())
+			Expect(err.Error()).To(Equal("The session overlaps the session of 2024-12-02."))
+		})
+
+		It("CASE: Add session failed because it ends before it starts", func() {
+
+			err := service.AddGymSession(userID, date, end, start, ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+		})
+
+		It("CASE: Add session failed because it is too long", func() {
+
+			err := service.AddGymSession(userID, date, start, start.Add(13*time.Hour), ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+		})
+
+		It("CASE: Add session failed because it starts on another day", func() {
+
+			// 23:30 of the day before
+			err := service.AddGymSession(userID, date, start.Add(-7*time.Hour), end, ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+		})
+
+		It("CASE: Add session failed because it ends in the future", func() {
+
+			now := time.Now().UTC()
+
+			err := service.AddGymSession(userID, now.Truncate(24*time.Hour), now, now.Add(time.Hour), ctxLogger)
+			Expect(errors.As(err, &customErrors.BadRequest)).To(BeTrue())
+		})
+
+		It("CASE: Add session failed because the user does not exist", func() {
+
+			mockUserDAO.EXPECT().GetUserWithHistoryBetween(userID, gomock.Any(), gomock.Any(), ctxLogger).
+				Times(1).
+				Return(nil, customErrors.BuildNotFoundError("test error"))
+
+			err := service.AddGymSession(userID, date, start, end, ctxLogger)
+			Expect(errors.As(err, &customErrors.NotFound)).To(BeTrue())
+		})
+	})
+
+	Context("Get Training Time", func() {
+
+		var (
+			ctxLogger *log.Entry
+			userID    string
+			user      userDAO.User
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+
+			userID = "admin"
+
+			session := func(date string, start string, minutes int) userDAO.GymAttendance {
+				startTime := parseTime(date + "T" + start)
+				endTime := startTime.Add(time.Duration(minutes) * time.Minute)
+				return userDAO.GymAttendance{UserID: userID, Date: parseTime(date + "T00:00:00"),
+					StartTime: &startTime, EndTime: &endTime}
+			}
+
+			user = userDAO.User{
+				ID: userID,
+				GymAttendance: []userDAO.GymAttendance{
+					session("2024-11-28", "18:00:00", 60),
+					session("2024-12-02", "23:30:00", 90),
+					{UserID: userID, Date: parseTime("2024-12-04T00:00:00")},
+				},
+			}
+		})
+
+		It("CASE: Successful get training time per week and month", func() {
+
+			mockUserDAO.EXPECT().GetUserWithHistoryBetween(userID, time.Time{}, time.Time{}, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			response, err := service.GetTrainingTime(userID, 0, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response.TotalMinutes).To(Equal(int32(150)))
+			Expect(response.TimedSessions).To(Equal(int32(2)))
+			Expect(response.AverageSessionMinutes).To(Equal(float32(75)))
+			Expect(response.Weeks).To(Equal([]*models.TrainingTimePeriod{
+				{Period: "2024-11-25", Sessions: 1, TimedSessions: 1, Minutes: 60},
+				{Period: "2024-12-02", Sessions: 2, TimedSessions: 1, Minutes: 90},
+			}))
+			Expect(response.Months).To(Equal([]*models.TrainingTimePeriod{
+				{Period: "2024-11", Sessions: 1, TimedSessions: 1, Minutes: 60},
+				{Period: "2024-12", Sessions: 2, TimedSessions: 1, Minutes: 90},
+			}))
+		})
+
+		It("CASE: Successful get training time without timed sessions", func() {
+
+			user.GymAttendance = user.GymAttendance[2:]
+
+			mockUserDAO.EXPECT().GetUserWithHistoryBetween(userID, gomock.Any(), time.Time{}, ctxLogger).
+				Times(1).
+				Return(&user, nil)
+
+			response, err := service.GetTrainingTime(userID, 3, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response.TotalMinutes).To(Equal(int32(0)))
+			Expect(response.AverageSessionMinutes).To(Equal(float32(0)))
+			Expect(response.Weeks).To(HaveLen(1))
+		})
+
+		It("CASE: Get training time failed because the user does not exist", func() {
+
+			mockUserDAO.EXPECT().GetUserWithHistoryBetween(userID, gomock.Any(), gomock.Any(), ctxLogger).
+				Times(1).
+				Return(nil, customErrors.BuildNotFoundError("test error"))
 
+			response, err := service.GetTrainingTime(userID, 0, ctxLogger)
+			Expect(errors.As(err, &customErrors.NotFound)).To(BeTrue())
+			Expect(response).To(BeNil())
+		})
 	})
 
 	Context("Get Streak Calendar By Year And Month", func() {
@@ -297,6 +1598,11 @@ var _ = Describe("SERVICE: Stats Test Suite", func() {
 
 })
 
+// weekStart returns the Monday of the week of the date
+func weekStart(date time.Time) time.Time {
+	return weeksStartingOn(userDAO.DefaultWeekStart).start(date)
+}
+
 func parseTime(dateStr string) time.Time {
 	parsedTime, err := time.Parse("2006-01-02T15:04:05", dateStr)
 	if err != nil {
@@ -0,0 +1,209 @@
+package stats_service
+
+import (
+	"fmt"
+	"gym-badges-api/internal/constants"
+	userDAO "gym-badges-api/internal/repository/user"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// *******************************************************************
+// STREAK EVALUATION
+// *******************************************************************
+
+func (s statService) RecomputeStreak(userID string, ctxLog *log.Entry) (int32, error) {
+
+	ctxLog.Debugf("STATS_SERVICE: Recomputing streak for user: %s", userID)
+
+	user, err := s.UserDAO.GetUserWithStreakProtection(userID, ctxLog)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+
+	days := make([]time.Time, len(user.GymAttendance))
+	for i, attendance := range user.GymAttendance {
+		days[i] = attendance.Date
+	}
+
+	period := userGoalPeriod(user)
+
+	vacations := make(map[time.Time]bool)
+	for _, vacation := range user.VacationWeeks {
+		vacations[period.start(vacation.Week)] = true
+	}
+
+	earned := earnFreezes(user, now)
+	freezes := make([]*userDAO.StreakFreeze, 0, len(user.StreakFreezes)+len(earned))
+	for i := range user.StreakFreezes {
+		freezes = append(freezes, &user.StreakFreezes[i])
+	}
+	for i := range earned {
+		freezes = append(freezes, &earned[i])
+	}
+
+	goals := userWeeklyGoals(user, period)
+	streak, currentPeriod, used := evaluateStreak(days, period, goals, vacations, freezes, now)
+
+	// Earned freezes are saved even if they are used right away
+	changed := earned
+	for _, freeze := range used {
+		if freeze.ID != 0 {
+			changed = append(changed, *freeze)
+		}
+		ctxLog.Infof("STATS_SERVICE: Streak freeze used by user %s for the period of %s", userID, freeze.UsedWeek.Format(constants.ISODateLayout))
+	}
+
+	if len(changed) > 0 {
+		if err := s.UserDAO.SaveStreakFreezes(userID, changed, ctxLog); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := s.UserDAO.UpdateStreak(userID, streak, currentPeriod, goals.at(period.start(now)), ctxLog); err != nil {
+		return 0, err
+	}
+
+	return streak, nil
+}
+
+func (s statService) RecomputeStreaks(ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("STATS_SERVICE: Recomputing the streaks of every user")
+
+	userIDs, err := s.UserDAO.GetUserIDs(ctxLog)
+	if err != nil {
+		return err
+	}
+
+	// A user failing does not stop the others
+	failed := 0
+	for _, userID := range userIDs {
+		if _, err := s.RecomputeStreak(userID, ctxLog); err != nil {
+			ctxLog.Warnf("STATS_SERVICE: Recomputing streak for user %s failed: %s", userID, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("the streaks of %d of %d users could not be recomputed", failed, len(userIDs))
+	}
+
+	return nil
+}
+
+// evaluateStreak counts the consecutive goal periods that met their weekly goal up to the current one, and
+// marks the attended days of the current period. The current period only adds to the streak once its goal is met,
+// until then it does not break it.
+//
+// Missed periods do not break the streak when they are vacations or were already protected by a freeze.
+// Otherwise an available freeze earned before the end of the period is used, only if the streak goes on
+// before that period. The used freezes are returned with the protected period set.
+func evaluateStreak(days []time.Time, period goalPeriod, goals weeklyGoals, vacations map[time.Time]bool,
+	freezes []*userDAO.StreakFreeze, now time.Time) (int32, []bool, []*userDAO.StreakFreeze) {
+
+	currentPeriod := make([]bool, period.days)
+	thisPeriod := period.start(now)
+
+	attended := make(map[time.Time]bool)
+	perPeriod := make(map[time.Time]int32)
+
+	for _, date := range days {
+
+		day := truncateDay(date)
+		if attended[day] {
+			continue
+		}
+		attended[day] = true
+
+		start := period.start(day)
+		perPeriod[start]++
+
+		if start.Equal(thisPeriod) {
+			currentPeriod[period.dayIndex(day)] = true
+		}
+	}
+
+	used := make([]*userDAO.StreakFreeze, 0)
+
+	// Without a goal there is nothing to keep
+	if goals.at(thisPeriod) <= 0 {
+		return 0, currentPeriod, used
+	}
+
+	protected := make(map[time.Time]bool)
+	for start := range vacations {
+		protected[start] = true
+	}
+
+	available := make([]*userDAO.StreakFreeze, 0, len(freezes))
+	for _, freeze := range freezes {
+		if freeze.UsedWeek != nil {
+			protected[period.start(*freeze.UsedWeek)] = true
+			continue
+		}
+		available = append(available, freeze)
+	}
+
+	type pendingFreeze struct {
+		freeze *userDAO.StreakFreeze
+		start  time.Time
+	}
+
+	// Freezes taken for missed periods, they are only used once an older period met the goal
+	pending := make([]pendingFreeze, 0)
+	taken := make([]bool, len(available))
+
+	start := thisPeriod
+	if perPeriod[start] < goals.at(start) {
+		start = period.previous(start)
+	}
+
+	var streak int32
+	for ; ; start = period.previous(start) {
+
+		weeklyGoal := goals.at(start)
+		if weeklyGoal <= 0 {
+			break
+		}
+
+		if perPeriod[start] >= weeklyGoal {
+			streak++
+			for _, p := range pending {
+				usedWeek := p.start
+				usedAt := now
+				p.freeze.UsedWeek = &usedWeek
+				p.freeze.UsedAt = &usedAt
+				used = append(used, p.freeze)
+			}
+			pending = pending[:0]
+			continue
+		}
+
+		if protected[start] {
+			continue
+		}
+
+		// Older periods have earlier deadlines, so each period takes the newest freeze that is valid for it
+		end := period.next(start)
+		freeze := -1
+		for i, candidate := range available {
+			if !taken[i] && candidate.EarnedAt.Before(end) &&
+				(freeze < 0 || candidate.EarnedAt.After(available[freeze].EarnedAt)) {
+				freeze = i
+			}
+		}
+
+		if freeze < 0 {
+			break
+		}
+
+		taken[freeze] = true
+		pending = append(pending, pendingFreeze{freeze: available[freeze], start: start})
+	}
+
+	return streak, currentPeriod, used
+}
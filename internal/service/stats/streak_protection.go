@@ -0,0 +1,199 @@
+package stats_service
+
+import (
+	configs "gym-badges-api/config/gym-badges-server"
+	"gym-badges-api/internal/constants"
+	customErrors "gym-badges-api/internal/custom-errors"
+	badgeDAO "gym-badges-api/internal/repository/badge"
+	userDAO "gym-badges-api/internal/repository/user"
+	"gym-badges-api/models"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Metrics of the rules of the consistency badges, each of them grants one streak freeze
+var freezeMetrics = map[string]bool{
+	badgeDAO.MetricStreak:          true,
+	badgeDAO.MetricAttendances:     true,
+	badgeDAO.MetricAccountAgeDays:  true,
+	badgeDAO.MetricTrainingHours:   true,
+	badgeDAO.MetricSessionsBetween: true,
+}
+
+// *******************************************************************
+// STREAK PROTECTION
+// *******************************************************************
+
+func (s statService) GetStreakProtection(userID string, ctxLog *log.Entry) (*models.StreakProtectionResponse, error) {
+
+	ctxLog.Debugf("STATS_SERVICE: Processing GetStreakProtection request for user: %s", userID)
+
+	// Read only, the freezes are earned and used on the writes and by the periodic job
+	user, err := s.UserDAO.GetUserWithStreakProtection(userID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	response := models.StreakProtectionResponse{
+		Streak:        user.Streak,
+		Freezes:       make([]*models.StreakFreeze, len(user.StreakFreezes)),
+		VacationWeeks: make([]string, len(user.VacationWeeks)),
+	}
+
+	for i, freeze := range user.StreakFreezes {
+		if freeze.UsedWeek == nil {
+			response.AvailableFreezes++
+		}
+		response.Freezes[i] = mapFreeze(&freeze)
+	}
+
+	for i, vacation := range user.VacationWeeks {
+		response.VacationWeeks[i] = vacation.Week.Format(constants.ISODateLayout)
+	}
+
+	return &response, nil
+}
+
+func (s statService) AddVacationWeek(userID string, date time.Time, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("STATS_SERVICE: Processing AddVacationWeek request for user: %s", userID)
+
+	user, err := s.UserDAO.GetUserWithStreakProtection(userID, ctxLog)
+	if err != nil {
+		return err
+	}
+
+	// A vacation covers a whole goal period
+	period := userGoalPeriod(user)
+	week := period.start(date)
+
+	// Vacations are planned, past periods can only be protected by freezes
+	if week.Before(period.start(time.Now())) {
+		return customErrors.BuildBadRequestError("Vacation weeks cannot be in the past.")
+	}
+
+	sameYear := 0
+	for _, vacation := range user.VacationWeeks {
+		// Vacations declared before the goal period changed are compared by their current period
+		if period.start(vacation.Week).Equal(week) {
+			return customErrors.BuildConflictError("The week of %s is already a vacation week.", week.Format(constants.ISODateLayout))
+		}
+		if vacation.Week.Year() == week.Year() {
+			sameYear++
+		}
+	}
+
+	if sameYear >= configs.Basic.MaxVacationWeeks {
+		return customErrors.BuildBadRequestError("There can only be %d vacation weeks per year.", configs.Basic.MaxVacationWeeks)
+	}
+
+	if err := s.UserDAO.AddVacationWeek(userID, week, ctxLog); err != nil {
+		return err
+	}
+
+	s.recomputeStreak(userID, ctxLog)
+
+	return nil
+}
+
+func (s statService) DeleteVacationWeek(userID string, date time.Time, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("STATS_SERVICE: Processing DeleteVacationWeek request for user: %s", userID)
+
+	user, err := s.UserDAO.GetUserWithStreakProtection(userID, ctxLog)
+	if err != nil {
+		return err
+	}
+
+	period := userGoalPeriod(user)
+	week := period.start(date)
+
+	// Ended vacations may already be part of the streak
+	if week.Before(period.start(time.Now())) {
+		return customErrors.BuildBadRequestError("Past vacation weeks cannot be cancelled.")
+	}
+
+	for _, vacation := range user.VacationWeeks {
+		if period.start(vacation.Week).Equal(week) {
+			if err := s.UserDAO.DeleteVacationWeek(userID, vacation.Week, ctxLog); err != nil {
+				return err
+			}
+			s.recomputeStreak(userID, ctxLog)
+			return nil
+		}
+	}
+
+	return customErrors.BuildNotFoundError("The week of %s is not a vacation week.", week.Format(constants.ISODateLayout))
+}
+
+// earnFreezes returns the freezes the user has earned and not received yet, one for each consistency badge, known
+// by the metric of its rule, and one each time the experience goes over a multiple of the configured experience
+func earnFreezes(user *userDAO.User, now time.Time) []userDAO.StreakFreeze {
+
+	earned := make([]userDAO.StreakFreeze, 0)
+
+	var fromExperience int64
+	fromBadges := make(map[int16]bool)
+
+	for _, freeze := range user.StreakFreezes {
+		switch freeze.Reason {
+		case userDAO.FreezeReasonExperience:
+			fromExperience++
+		case userDAO.FreezeReasonBadge:
+			if freeze.BadgeID != nil {
+				fromBadges[*freeze.BadgeID] = true
+			}
+		}
+	}
+
+	if configs.Basic.FreezeExperience > 0 {
+		for i := fromExperience; i < user.Experience/configs.Basic.FreezeExperience; i++ {
+			earned = append(earned, userDAO.StreakFreeze{
+				UserID:   user.ID,
+				Reason:   userDAO.FreezeReasonExperience,
+				EarnedAt: now,
+			})
+		}
+	}
+
+	for _, badge := range user.Badges {
+		if badge.Rule != nil && freezeMetrics[badge.Rule.Metric] && !fromBadges[badge.ID] {
+			badgeID := badge.ID
+			earned = append(earned, userDAO.StreakFreeze{
+				UserID:   user.ID,
+				Reason:   userDAO.FreezeReasonBadge,
+				BadgeID:  &badgeID,
+				EarnedAt: now,
+			})
+		}
+	}
+
+	return earned
+}
+
+func mapFreeze(freeze *userDAO.StreakFreeze) *models.StreakFreeze {
+
+	response := models.StreakFreeze{
+		ID:       freeze.ID,
+		Reason:   freeze.Reason,
+		EarnedAt: freeze.EarnedAt.Format(constants.ISODateLayout),
+	}
+
+	if freeze.BadgeID != nil {
+		badgeID := int32(*freeze.BadgeID)
+		response.BadgeID = &badgeID
+	}
+
+	if freeze.UsedWeek != nil {
+		usedWeek := freeze.UsedWeek.Format(constants.ISODateLayout)
+		response.UsedWeek = &usedWeek
+	}
+
+	if freeze.UsedAt != nil {
+		usedAt := freeze.UsedAt.Format(constants.ISODateLayout)
+		response.UsedAt = &usedAt
+	}
+
+	return &response
+}
@@ -0,0 +1,165 @@
+package stats_service
+
+import (
+	"gym-badges-api/internal/constants"
+	customErrors "gym-badges-api/internal/custom-errors"
+	userDAO "gym-badges-api/internal/repository/user"
+	eventsService "gym-badges-api/internal/service/events"
+	"gym-badges-api/models"
+	"math"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// Longer sessions are most likely a forgotten check out
+	maxSessionDuration = 12 * time.Hour
+
+	monthLayout = "2006-01"
+)
+
+// *******************************************************************
+// GYM SESSIONS
+// *******************************************************************
+
+func (s statService) AddGymSession(userID string, date time.Time, start time.Time, end time.Time,
+	ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("STATS_SERVICE: Processing AddGymSession request for user: %s", userID)
+
+	date = truncateDay(date)
+
+	switch {
+	case !end.After(start):
+		return customErrors.BuildBadRequestError("The session must end after it starts.")
+	case end.Sub(start) > maxSessionDuration:
+		return customErrors.BuildBadRequestError("A session cannot be longer than %d hours.", int(maxSessionDuration.Hours()))
+	case !truncateDay(start).Equal(date):
+		// The start keeps the offset of the user, so its day is the local one
+		return customErrors.BuildBadRequestError("The session must start on the attended date.")
+	case end.After(time.Now()):
+		return customErrors.BuildBadRequestError("The session cannot end in the future.")
+	}
+
+	// Sessions can cross midnight, so only the neighbour days can overlap
+	user, err := s.UserDAO.GetUserWithHistoryBetween(userID, date.AddDate(0, 0, -1), date.AddDate(0, 0, 1), ctxLog)
+	if err != nil {
+		return err
+	}
+
+	attended := false
+	for _, attendance := range user.GymAttendance {
+
+		if truncateDay(attendance.Date).Equal(date) {
+			attended = true
+			continue
+		}
+
+		if attendance.StartTime != nil && attendance.EndTime != nil &&
+			start.Before(*attendance.EndTime) && attendance.StartTime.Before(end) {
+			return customErrors.BuildConflictError("The session overlaps the session of %s.",
+				attendance.Date.Format(constants.ISODateLayout))
+		}
+	}
+
+	// Timing an already attended day only sets its times
+	if !attended {
+		if err := s.AddGymAttendance(userID, date, ctxLog); err != nil {
+			return err
+		}
+	}
+
+	startMinute := int16(start.Hour()*60 + start.Minute())
+	startTime, endTime := start.UTC(), end.UTC()
+
+	if err := s.UserDAO.SetGymSession(userID, userDAO.GymAttendance{
+		UserID:      userID,
+		Date:        date,
+		StartTime:   &startTime,
+		EndTime:     &endTime,
+		StartMinute: &startMinute,
+	}, ctxLog); err != nil {
+		return err
+	}
+
+	// The times count for the training time and time of day badges
+	s.eventsService.Publish(eventsService.Event{Type: eventsService.AttendanceAdded, UserID: userID}, ctxLog)
+
+	return nil
+}
+
+// *******************************************************************
+// TRAINING TIME
+// *******************************************************************
+
+func (s statService) GetTrainingTime(userID string, months int32, ctxLog *log.Entry) (*models.TrainingTimeResponse, error) {
+
+	ctxLog.Debugf("STATS_SERVICE: Processing GetTrainingTime request for user: %s", userID)
+
+	var from time.Time
+	if months > 0 {
+		from = truncateDay(time.Now().AddDate(0, -int(months), 0))
+	}
+
+	user, err := s.UserDAO.GetUserWithHistoryBetween(userID, from, time.Time{}, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	response := models.TrainingTimeResponse{
+		Weeks:  make([]*models.TrainingTimePeriod, 0),
+		Months: make([]*models.TrainingTimePeriod, 0),
+	}
+
+	// Training time is reported per calendar week, whatever the goal period is
+	calendarWeeks := userWeeks(user)
+	weeks := make(map[string]*models.TrainingTimePeriod)
+	monthsByKey := make(map[string]*models.TrainingTimePeriod)
+
+	// The attendance is sorted by date, so the periods are added sorted
+	for _, attendance := range user.GymAttendance {
+
+		day := truncateDay(attendance.Date)
+		periods := []*models.TrainingTimePeriod{
+			trainingPeriod(&response.Weeks, weeks, calendarWeeks.start(day).Format(constants.ISODateLayout)),
+			trainingPeriod(&response.Months, monthsByKey, day.Format(monthLayout)),
+		}
+
+		var minutes int32
+		timed := attendance.StartTime != nil && attendance.EndTime != nil
+		if timed {
+			minutes = int32(math.Round(attendance.EndTime.Sub(*attendance.StartTime).Minutes()))
+			response.TimedSessions++
+			response.TotalMinutes += minutes
+		}
+
+		for _, period := range periods {
+			period.Sessions++
+			if timed {
+				period.TimedSessions++
+				period.Minutes += minutes
+			}
+		}
+	}
+
+	if response.TimedSessions > 0 {
+		response.AverageSessionMinutes = roundValue(float32(response.TotalMinutes) / float32(response.TimedSessions))
+	}
+
+	return &response, nil
+}
+
+// trainingPeriod returns the period of the key, appending a new one the first time
+func trainingPeriod(periods *[]*models.TrainingTimePeriod, byKey map[string]*models.TrainingTimePeriod,
+	key string) *models.TrainingTimePeriod {
+
+	period, ok := byKey[key]
+	if !ok {
+		period = &models.TrainingTimePeriod{Period: key}
+		byKey[key] = period
+		*periods = append(*periods, period)
+	}
+
+	return period
+}
@@ -0,0 +1,241 @@
+package stats_service
+
+import (
+	"gym-badges-api/internal/constants"
+	customErrors "gym-badges-api/internal/custom-errors"
+	"gym-badges-api/models"
+	"math"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	measurementWeight = "weight"
+	measurementFat    = "fat"
+
+	// Each day the trend moves a 10% of the distance to the measured value
+	trendSmoothing = 0.1
+
+	defaultFitWindowDays = 28
+
+	// Further projections are not reliable
+	maxProjectionDays = 2 * 365
+
+	hoursPerDay = 24
+)
+
+// *******************************************************************
+// TREND ANALYTICS
+// *******************************************************************
+
+func (s statService) GetMeasurementTrend(userID string, authUserID string, measurementType string, months int32,
+	window int32, target *float32, ctxLog *log.Entry) (*models.MeasurementTrendResponse, error) {
+
+	ctxLog.Debugf("STATS_SERVICE: Processing GetMeasurementTrend request for user: %s measurement: %s", userID, measurementType)
+
+	// The measurement histories do not load the preferences, only needed when the user is not the owner
+	if authUserID != userID {
+		user, err := s.UserDAO.GetUser(userID, ctxLog)
+		if err != nil {
+			return nil, err
+		}
+		if bodyStatsHidden(user) {
+			return nil, customErrors.BuildForbiddenError(bodyStatsHiddenErrorMsg, userID)
+		}
+	}
+
+	if window <= 0 {
+		window = defaultFitWindowDays
+	}
+
+	series, err := s.getMeasurements(userID, measurementType, months, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	response := models.MeasurementTrendResponse{
+		Measurement: measurementType,
+		Days:        make([]*models.TrendDay, len(series)),
+	}
+
+	if len(series) == 0 {
+		return &response, nil
+	}
+
+	trend := smoothedTrend(series)
+
+	for i, m := range series {
+		response.Days[i] = &models.TrendDay{
+			Date:  m.Date.Format(constants.ISODateLayout),
+			Value: roundValue(m.Value),
+			Trend: roundValue(trend[i]),
+		}
+	}
+
+	response.Trend = roundPointer(trend[len(trend)-1])
+	response.WeeklyRate = weeklyRate(series, trend)
+	response.Fit = linearFit(series, window)
+
+	if target != nil {
+		response.Projection = projectGoal(series[len(series)-1].Date, trend[len(trend)-1], response.Fit, *target)
+	}
+
+	return &response, nil
+}
+
+func (s statService) getMeasurements(userID string, measurementType string, months int32,
+	ctxLog *log.Entry) ([]measurement, error) {
+
+	switch measurementType {
+	case measurementWeight:
+		user, err := s.UserDAO.GetUserWithWeightHistory(userID, months, ctxLog)
+		if err != nil {
+			return nil, err
+		}
+
+		series := make([]measurement, len(user.WeightHistory))
+		for i, weight := range user.WeightHistory {
+			series[i] = measurement{Date: truncateDay(weight.Date), Value: weight.Weight}
+		}
+		return series, nil
+
+	case measurementFat:
+		user, err := s.UserDAO.GetUserWithFatHistory(userID, months, ctxLog)
+		if err != nil {
+			return nil, err
+		}
+
+		series := make([]measurement, len(user.FatHistory))
+		for i, fat := range user.FatHistory {
+			series[i] = measurement{Date: truncateDay(fat.Date), Value: fat.Fat}
+		}
+		return series, nil
+	}
+
+	return nil, customErrors.BuildBadRequestError("Unknown measurement %s.", measurementType)
+}
+
+// smoothedTrend is an exponential moving average that takes into account the days between measurements
+func smoothedTrend(series []measurement) []float32 {
+
+	trend := make([]float32, len(series))
+	trend[0] = series[0].Value
+
+	for i := 1; i < len(series); i++ {
+		days := daysBetween(series[i-1].Date, series[i].Date)
+		alpha := 1 - math.Pow(1-trendSmoothing, days)
+		trend[i] = trend[i-1] + float32(alpha)*(series[i].Value-trend[i-1])
+	}
+
+	return trend
+}
+
+// weeklyRate is the change of the trend since the last measurement at least a week older than the
+// last one, scaled to 7 days
+func weeklyRate(series []measurement, trend []float32) *float32 {
+
+	last := len(series) - 1
+	weekAgo := series[last].Date.AddDate(0, 0, -7)
+
+	for i := last - 1; i >= 0; i-- {
+		if !series[i].Date.After(weekAgo) {
+			days := daysBetween(series[i].Date, series[last].Date)
+			return roundPointer((trend[last] - trend[i]) / float32(days) * 7)
+		}
+	}
+
+	return nil
+}
+
+// linearFit is the least squares line of the measurements in the window days before the last one
+func linearFit(series []measurement, window int32) *models.LinearFit {
+
+	last := series[len(series)-1].Date
+	start := last.AddDate(0, 0, -int(window))
+
+	var xs, ys []float64
+	for _, m := range series {
+		if m.Date.Before(start) {
+			continue
+		}
+		xs = append(xs, daysBetween(start, m.Date))
+		ys = append(ys, float64(m.Value))
+	}
+
+	n := float64(len(xs))
+	if n < 2 {
+		return nil
+	}
+
+	var meanX, meanY float64
+	for i := range xs {
+		meanX += xs[i] / n
+		meanY += ys[i] / n
+	}
+
+	var covariance, varianceX, varianceY float64
+	for i := range xs {
+		covariance += (xs[i] - meanX) * (ys[i] - meanY)
+		varianceX += (xs[i] - meanX) * (xs[i] - meanX)
+		varianceY += (ys[i] - meanY) * (ys[i] - meanY)
+	}
+
+	if varianceX == 0 {
+		return nil
+	}
+
+	slope := covariance / varianceX
+	intercept := meanY - slope*meanX
+
+	// A flat series is perfectly explained by a flat line
+	rSquared := 1.0
+	if varianceY > 0 {
+		rSquared = covariance * covariance / (varianceX * varianceY)
+	}
+
+	return &models.LinearFit{
+		WindowDays:   window,
+		Points:       int32(n),
+		SlopePerWeek: roundValue(float32(slope * 7)),
+		Value:        roundValue(float32(intercept + slope*daysBetween(start, last))),
+		RSquared:     roundValue(float32(rSquared)),
+	}
+}
+
+// projectGoal estimates when the target will be reached from the current trend at the fitted rate
+func projectGoal(lastDate time.Time, current float32, fit *models.LinearFit, target float32) *models.GoalProjection {
+
+	projection := models.GoalProjection{
+		Target: target,
+	}
+
+	distance := float64(target - current)
+
+	var days float64
+	switch {
+	case math.Abs(distance) < 0.01:
+		days = 0
+	case fit == nil || fit.SlopePerWeek == 0 || math.Signbit(distance) != math.Signbit(float64(fit.SlopePerWeek)):
+		return &projection
+	default:
+		days = math.Ceil(distance / (float64(fit.SlopePerWeek) / 7))
+	}
+
+	if days > maxProjectionDays {
+		return &projection
+	}
+
+	date := lastDate.AddDate(0, 0, int(days)).Format(constants.ISODateLayout)
+	daysInt := int32(days)
+
+	projection.Reachable = true
+	projection.Date = &date
+	projection.Days = &daysInt
+
+	return &projection
+}
+
+func daysBetween(from time.Time, to time.Time) float64 {
+	return to.Sub(from).Hours() / hoursPerDay
+}
@@ -2,26 +2,33 @@ package user_service
 
 import (
 	"errors"
+	"gym-badges-api/internal/constants"
 	customErrors "gym-badges-api/internal/custom-errors"
 	badgeDAO "gym-badges-api/internal/repository/badge"
 	userDAO "gym-badges-api/internal/repository/user"
 	sessionService "gym-badges-api/internal/service/session"
+	statsService "gym-badges-api/internal/service/stats"
 	"gym-badges-api/models"
 
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/crypto/bcrypt"
 )
 
-func NewUserService(userDAO userDAO.IUserDAO, sessionService sessionService.ISessionService) IUserService {
+var categoryBadgeIDs = []int16{-1, -2, -3, -4, -5, -6, -7}
+
+func NewUserService(userDAO userDAO.IUserDAO, sessionService sessionService.ISessionService,
+	statsService statsService.IStatsService) IUserService {
 	return &UserService{
 		UserDAO:        userDAO,
 		sessionService: sessionService,
+		statsService:   statsService,
 	}
 }
 
 type UserService struct {
 	UserDAO        userDAO.IUserDAO
 	sessionService sessionService.ISessionService
+	statsService   statsService.IStatsService
 }
 
 func (s UserService) GetUser(userID string, ctxLog *log.Entry) (*models.GetUserInfoResponse, error) {
@@ -34,23 +41,41 @@ func (s UserService) GetUser(userID string, ctxLog *log.Entry) (*models.GetUserI
 		return nil, err
 	}
 
-	response := models.GetUserInfoResponse{
-		UserID:      user.ID,
-		BodyFat:     user.BodyFat,
-		CurrentWeek: user.CurrentWeek,
-		Experience:  user.Experience,
-		Image:       user.Image,
-		Name:        user.Name,
-		Streak:      user.Streak,
-		Weight:      user.Weight,
-		Height:      *user.Height,
-		Sex:         user.Sex,
-		WeeklyGoal:  user.WeeklyGoal,
-		TopFeats:    mapTopFeats(user.TopFeats),
-		Preferences: mapPreferences(user.Preferences),
-	}
+	return mapUserInfo(user), nil
+}
 
-	return &response, nil
+func mapUserInfo(user *userDAO.User) *models.GetUserInfoResponse {
+
+	response := models.GetUserInfoResponse{
+		UserID:         user.ID,
+		BodyFat:        user.BodyFat,
+		CurrentWeek:    user.CurrentWeek,
+		Experience:     user.Experience,
+		Image:          user.Image,
+		Name:           user.Name,
+		Streak:         user.Streak,
+		Weight:         user.Weight,
+		Height:         *user.Height,
+		Sex:            user.Sex,
+		WeeklyGoal:     user.WeeklyGoal,
+		WeekStart:      int32(user.WeekStart),
+		GoalPeriodDays: int32(user.GoalPeriodDays),
+		TopFeats:       mapTopFeats(user.TopFeats),
+		Preferences:    mapPreferences(user.Preferences),
+	}
+
+	if user.GoalPeriodAnchor != nil {
+		response.GoalPeriodAnchor = user.GoalPeriodAnchor.Format(constants.ISODateLayout)
+	}
+
+	// The goal history is only loaded with the changes not in force yet
+	if n := len(user.GoalHistory); n > 0 {
+		next := user.GoalHistory[n-1]
+		response.NextWeeklyGoal = &next.WeeklyGoal
+		response.NextWeeklyGoalFrom = next.EffectiveFrom.Format(constants.ISODateLayout)
+	}
+
+	return &response
 }
 
 func mapPreferences(dbPreferences []userDAO.Preference) []*models.Preference {
@@ -125,22 +150,26 @@ func (s UserService) CreateUser(user *models.CreateUserRequest, ctxLog *log.Entr
 		Height:      &user.Height,
 		Sex:         user.Sex,
 		WeeklyGoal:  3,
+		WeekStart:   userDAO.DefaultWeekStart,
+		// Weekly goals, the periods only need an anchor when they are not weeks
+		GoalPeriodDays: userDAO.DefaultGoalPeriodDays,
 		Preferences: []userDAO.Preference{
 			{ID: 1, On: false, UserID: user.UserID}, // Private account
 			{ID: 2, On: false, UserID: user.UserID}, // Hide weight, fat, height and sex
 		},
-		Badges: []*badgeDAO.Badge{ // Base category badges
-			{ID: -1},
-			{ID: -2},
-			{ID: -3},
-			{ID: -4},
-			{ID: -5},
-			{ID: -6},
-			{ID: -7},
-		},
 	}
 
-	if err = s.UserDAO.CreateUser(&newUser, ctxLog); err != nil {
+	// Base category badges, every user has them so they are not awards
+	awards := make([]*badgeDAO.UserBadge, 0, len(categoryBadgeIDs))
+	for _, badgeID := range categoryBadgeIDs {
+		awards = append(awards, &badgeDAO.UserBadge{
+			UserID:  newUser.ID,
+			BadgeID: badgeID,
+			Source:  badgeDAO.AwardSourceSystem,
+		})
+	}
+
+	if err = s.UserDAO.CreateUser(&newUser, awards, ctxLog); err != nil {
 		return nil, err
 	}
 
@@ -165,7 +194,8 @@ func (s UserService) EditUserInfo(userID string, request *models.EditUserInfoReq
 	newUserInfo.Email = request.Email
 	newUserInfo.Name = request.Name
 	newUserInfo.Image = request.Image
-	newUserInfo.WeeklyGoal = request.WeeklyGoal
+	newUserInfo.WeekStart = int16(request.WeekStart)
+	newUserInfo.GoalPeriodDays = int16(request.GoalPeriodDays)
 	newUserInfo.Height = &request.Height
 	newUserInfo.Sex = request.Sex
 
@@ -190,20 +220,22 @@ func (s UserService) EditUserInfo(userID string, request *models.EditUserInfoReq
 		return nil, err
 	}
 
-	response := models.GetUserInfoResponse{
-		UserID:      user.ID,
-		BodyFat:     user.BodyFat,
-		CurrentWeek: user.CurrentWeek,
-		Experience:  user.Experience,
-		Image:       user.Image,
-		Name:        user.Name,
-		Streak:      user.Streak,
-		Weight:      user.Weight,
-		Height:      *user.Height,
-		Sex:         user.Sex,
-		TopFeats:    mapTopFeats(user.TopFeats),
-		Preferences: mapPreferences(user.Preferences),
+	// The streak and the current period are counted with the goal settings
+	if request.WeekStart != 0 || request.GoalPeriodDays != 0 || request.WeeklyGoal != 0 {
+
+		// The goal is kept in its history, changing it also recomputes the streak
+		if request.WeeklyGoal != 0 {
+			if err := s.statsService.ChangeWeeklyGoal(userID, request.WeeklyGoal, ctxLog); err != nil {
+				return nil, err
+			}
+		} else if _, err := s.statsService.RecomputeStreak(userID, ctxLog); err != nil {
+			ctxLog.Warnf("USER_SERVICE: Recomputing streak for user %s failed: %s", userID, err)
+		}
+
+		if updated, err := s.UserDAO.GetUser(userID, ctxLog); err == nil {
+			user = updated
+		}
 	}
 
-	return &response, nil
+	return mapUserInfo(user), nil
 }
@@ -3,6 +3,7 @@ package user_service
 import (
 	"errors"
 	customErrors "gym-badges-api/internal/custom-errors"
+	badgeDAO "gym-badges-api/internal/repository/badge"
 	userDAO "gym-badges-api/internal/repository/user"
 	mockDAO "gym-badges-api/mocks/dao"
 	mockService "gym-badges-api/mocks/service"
@@ -28,6 +29,7 @@ var _ = Describe("SERVICE: User Test Suite", func() {
 		mockCtrl           *gomock.Controller
 		mockUserDAO        *mockDAO.MockIUserDAO
 		mockSessionService *mockService.MockISessionService
+		mockStatsService   *mockService.MockIStatsService
 		service            IUserService
 	)
 
@@ -35,7 +37,8 @@ var _ = Describe("SERVICE: User Test Suite", func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockUserDAO = mockDAO.NewMockIUserDAO(mockCtrl)
 		mockSessionService = mockService.NewMockISessionService(mockCtrl)
-		service = NewUserService(mockUserDAO, mockSessionService)
+		mockStatsService = mockService.NewMockIStatsService(mockCtrl)
+		service = NewUserService(mockUserDAO, mockSessionService, mockStatsService)
 	})
 
 	AfterEach(func() {
@@ -84,9 +87,15 @@ var _ = Describe("SERVICE: User Test Suite", func() {
 				Times(1).
 				Return(nil, customErrors.BuildNotFoundError("not found"))
 
-			mockUserDAO.EXPECT().CreateUser(gomock.Any(), ctxLogger).
+			mockUserDAO.EXPECT().CreateUser(gomock.Any(), gomock.Any(), ctxLogger).
 				Times(1).
-				Return(nil)
+				DoAndReturn(func(_ *userDAO.User, awards []*badgeDAO.UserBadge, _ *log.Entry) error {
+					Expect(len(awards)).To(Equal(7))
+					Expect(awards[0].UserID).To(Equal(request.UserID))
+					Expect(awards[0].BadgeID).To(Equal(int16(-1)))
+					Expect(awards[0].Source).To(Equal(badgeDAO.AwardSourceSystem))
+					return nil
+				})
 
 			mockSessionService.EXPECT().GenerateSession(request.UserID).
 				Times(1).
@@ -159,7 +168,7 @@ var _ = Describe("SERVICE: User Test Suite", func() {
 				Times(1).
 				Return(nil, customErrors.BuildNotFoundError("not found"))
 
-			mockUserDAO.EXPECT().CreateUser(gomock.Any(), ctxLogger).
+			mockUserDAO.EXPECT().CreateUser(gomock.Any(), gomock.Any(), ctxLogger).
 				Times(1).
 				Return(errors.New("panic"))
 
@@ -178,7 +187,7 @@ var _ = Describe("SERVICE: User Test Suite", func() {
 				Times(1).
 				Return(nil, customErrors.BuildNotFoundError("not found"))
 
-			mockUserDAO.EXPECT().CreateUser(gomock.Any(), ctxLogger).
+			mockUserDAO.EXPECT().CreateUser(gomock.Any(), gomock.Any(), ctxLogger).
 				Times(1).
 				Return(nil)
 
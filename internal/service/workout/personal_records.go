@@ -0,0 +1,287 @@
+package workout_service
+
+import (
+	"gym-badges-api/internal/constants"
+	workoutDAO "gym-badges-api/internal/repository/workout"
+	eventsService "gym-badges-api/internal/service/events"
+	"gym-badges-api/models"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Both formulas lose accuracy with high reps, and Brzycki is not defined from 37 reps on
+const maxEstimationReps = 12
+
+// repMaxRecords is the minimum reps of each rep max record
+var repMaxRecords = []struct {
+	Type string
+	Reps int32
+}{
+	{workoutDAO.RecordOneRM, 1},
+	{workoutDAO.RecordThreeRM, 3},
+	{workoutDAO.RecordFiveRM, 5},
+	{workoutDAO.RecordTenRM, 10},
+}
+
+// recordsOrder sorts the records of an exercise in the board
+var recordsOrder = map[string]int{
+	workoutDAO.RecordOneRM:            0,
+	workoutDAO.RecordThreeRM:          1,
+	workoutDAO.RecordFiveRM:           2,
+	workoutDAO.RecordTenRM:            3,
+	workoutDAO.RecordEstimatedEpley:   4,
+	workoutDAO.RecordEstimatedBrzycki: 5,
+}
+
+// *******************************************************************
+// ONE REP MAX ESTIMATION
+// *******************************************************************
+
+func estimateEpley(weight float32, reps int32) float32 {
+	if reps == 1 {
+		return weight
+	}
+	return roundWeight(weight * (1 + float32(reps)/30))
+}
+
+func estimateBrzycki(weight float32, reps int32) float32 {
+	return roundWeight(weight * 36 / float32(37-reps))
+}
+
+// roundWeight keeps the precision of the weight columns, otherwise a stored record could be broken
+// again by its own value
+func roundWeight(weight float32) float32 {
+	return float32(math.Round(float64(weight)*100) / 100)
+}
+
+// *******************************************************************
+// PERSONAL RECORDS
+// *******************************************************************
+
+func (s workoutService) GetPersonalRecords(userID string, ctxLog *log.Entry) (*models.PersonalRecordsResponse, error) {
+
+	ctxLog.Debugf("WORKOUT_SERVICE: Processing GetPersonalRecords request for user: %s", userID)
+
+	records, err := s.WorkoutDAO.GetRecords(userID, constants.EmptyString, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	best := bestRecords(records)
+
+	board := make([]*workoutDAO.PersonalRecord, 0, len(best))
+	for _, record := range best {
+		board = append(board, record)
+	}
+
+	sort.Slice(board, func(i, j int) bool {
+		exerciseI, exerciseJ := strings.ToLower(board[i].Exercise), strings.ToLower(board[j].Exercise)
+		if exerciseI != exerciseJ {
+			return exerciseI < exerciseJ
+		}
+		return recordsOrder[board[i].Type] < recordsOrder[board[j].Type]
+	})
+
+	response := models.PersonalRecordsResponse{
+		Records: make([]*models.PersonalRecord, len(board)),
+	}
+
+	for i, record := range board {
+		response.Records[i] = mapRecord(record)
+	}
+
+	return &response, nil
+}
+
+func (s workoutService) GetExerciseProgression(userID string, exercise string, months int32,
+	ctxLog *log.Entry) (*models.ExerciseProgressionResponse, error) {
+
+	ctxLog.Debugf("WORKOUT_SERVICE: Processing GetExerciseProgression request for user: %s exercise: %s", userID, exercise)
+
+	exercise = strings.TrimSpace(exercise)
+
+	workouts, err := s.WorkoutDAO.GetExerciseHistory(userID, exercise, months, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.WorkoutDAO.GetRecords(userID, exercise, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	response := models.ExerciseProgressionResponse{
+		Exercise: exercise,
+		Points:   make([]*models.ProgressionPoint, 0, len(workouts)),
+		Records:  make([]*models.PersonalRecord, 0, len(records)),
+	}
+
+	// Workouts come ordered by date, several sessions in the same day are merged
+	var point *models.ProgressionPoint
+
+	for _, workout := range workouts {
+
+		date := workout.Date.Format(constants.ISODateLayout)
+		if point == nil || point.Date != date {
+			point = &models.ProgressionPoint{Date: date}
+			response.Points = append(response.Points, point)
+		}
+
+		for _, workoutExercise := range workout.Exercises {
+			for _, set := range workoutExercise.Sets {
+
+				point.Volume += float32(set.Reps) * set.Weight
+				point.TopWeight = max(point.TopWeight, set.Weight)
+
+				if set.Reps <= maxEstimationReps {
+					point.EstimatedEpley = max(point.EstimatedEpley, estimateEpley(set.Weight, set.Reps))
+					point.EstimatedBrzycki = max(point.EstimatedBrzycki, estimateBrzycki(set.Weight, set.Reps))
+				}
+			}
+		}
+
+		point.Volume = roundWeight(point.Volume)
+	}
+
+	var startDate time.Time
+	if months > 0 {
+		startDate = time.Now().AddDate(0, -int(months), 0)
+	}
+
+	for _, record := range records {
+		if record.Date.Before(startDate) {
+			continue
+		}
+		response.Records = append(response.Records, mapRecord(record))
+	}
+
+	return &response, nil
+}
+
+// checkRecords stores the records broken with the workout sets, each one is a "new PR" event. The
+// workout is already saved, so a failure here is only logged.
+func (s workoutService) checkRecords(userID string, workout *workoutDAO.WorkoutSession,
+	ctxLog *log.Entry) []*models.PersonalRecord {
+
+	current, err := s.WorkoutDAO.GetRecords(userID, constants.EmptyString, ctxLog)
+	if err != nil {
+		ctxLog.Warnf("WORKOUT_SERVICE: Checking personal records for user %s failed: %s", userID, err)
+		return []*models.PersonalRecord{}
+	}
+
+	best := bestRecords(current)
+
+	broken := make([]*workoutDAO.PersonalRecord, 0)
+
+	for _, candidate := range workoutRecords(workout) {
+		if record, found := best[recordKey(candidate)]; found && candidate.Weight <= record.Weight {
+			continue
+		}
+		broken = append(broken, candidate)
+	}
+
+	if len(broken) > 0 {
+		if err := s.WorkoutDAO.AddRecords(broken, ctxLog); err != nil {
+			ctxLog.Warnf("WORKOUT_SERVICE: Saving personal records for user %s failed: %s", userID, err)
+			return []*models.PersonalRecord{}
+		}
+	}
+
+	response := make([]*models.PersonalRecord, len(broken))
+
+	for i, record := range broken {
+		ctxLog.Infof("WORKOUT_SERVICE: New PR for user %s: %s %s %.2f kg", userID, record.Exercise, record.Type, record.Weight)
+		s.eventsService.Publish(eventsService.Event{Type: eventsService.NewPersonalRecord, UserID: userID}, ctxLog)
+		response[i] = mapRecord(record)
+	}
+
+	return response
+}
+
+// workoutRecords returns the best value of each record type achieved in the workout
+func workoutRecords(workout *workoutDAO.WorkoutSession) []*workoutDAO.PersonalRecord {
+
+	best := make(map[string]*workoutDAO.PersonalRecord)
+	records := make([]*workoutDAO.PersonalRecord, 0)
+
+	candidate := func(exercise string, recordType string, weight float32, reps int32) {
+
+		record := &workoutDAO.PersonalRecord{
+			UserID:           workout.UserID,
+			WorkoutSessionID: workout.ID,
+			Exercise:         exercise,
+			Type:             recordType,
+			Weight:           weight,
+			Reps:             reps,
+			Date:             workout.Date,
+		}
+
+		key := recordKey(record)
+		if current, found := best[key]; found {
+			if weight > current.Weight {
+				*current = *record
+			}
+			return
+		}
+
+		best[key] = record
+		records = append(records, record)
+	}
+
+	for _, exercise := range workout.Exercises {
+		for _, set := range exercise.Sets {
+
+			// Bodyweight sets do not set load records
+			if set.Weight <= 0 {
+				continue
+			}
+
+			for _, repMax := range repMaxRecords {
+				if set.Reps >= repMax.Reps {
+					candidate(exercise.Name, repMax.Type, set.Weight, set.Reps)
+				}
+			}
+
+			if set.Reps <= maxEstimationReps {
+				candidate(exercise.Name, workoutDAO.RecordEstimatedEpley, estimateEpley(set.Weight, set.Reps), set.Reps)
+				candidate(exercise.Name, workoutDAO.RecordEstimatedBrzycki, estimateBrzycki(set.Weight, set.Reps), set.Reps)
+			}
+		}
+	}
+
+	return records
+}
+
+// bestRecords returns the highest record of each exercise and type
+func bestRecords(records []*workoutDAO.PersonalRecord) map[string]*workoutDAO.PersonalRecord {
+
+	best := make(map[string]*workoutDAO.PersonalRecord)
+
+	for _, record := range records {
+		key := recordKey(record)
+		if current, found := best[key]; !found || record.Weight > current.Weight {
+			best[key] = record
+		}
+	}
+
+	return best
+}
+
+func recordKey(record *workoutDAO.PersonalRecord) string {
+	return strings.ToLower(record.Exercise) + "|" + record.Type
+}
+
+func mapRecord(record *workoutDAO.PersonalRecord) *models.PersonalRecord {
+	return &models.PersonalRecord{
+		Exercise:  record.Exercise,
+		Type:      record.Type,
+		Weight:    record.Weight,
+		Reps:      record.Reps,
+		Date:      record.Date.Format(constants.ISODateLayout),
+		WorkoutID: record.WorkoutSessionID,
+	}
+}
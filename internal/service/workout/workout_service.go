@@ -0,0 +1,337 @@
+package workout_service
+
+import (
+	configs "gym-badges-api/config/gym-badges-server"
+	"gym-badges-api/internal/constants"
+	customErrors "gym-badges-api/internal/custom-errors"
+	userDAO "gym-badges-api/internal/repository/user"
+	workoutDAO "gym-badges-api/internal/repository/workout"
+	badgeService "gym-badges-api/internal/service/badge"
+	eventsService "gym-badges-api/internal/service/events"
+	goalService "gym-badges-api/internal/service/goal"
+	statsService "gym-badges-api/internal/service/stats"
+	"gym-badges-api/models"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	minRPE = 1
+	maxRPE = 10
+)
+
+func NewWorkoutService(workoutDAO workoutDAO.IWorkoutDAO, userDAO userDAO.IUserDAO, statsService statsService.IStatsService,
+	badgeService badgeService.IBadgeService, goalService goalService.IGoalService,
+	eventsService eventsService.IEventsService) IWorkoutService {
+	return &workoutService{
+		WorkoutDAO:    workoutDAO,
+		UserDAO:       userDAO,
+		statsService:  statsService,
+		badgeService:  badgeService,
+		goalService:   goalService,
+		eventsService: eventsService,
+	}
+}
+
+type workoutService struct {
+	WorkoutDAO    workoutDAO.IWorkoutDAO
+	UserDAO       userDAO.IUserDAO
+	statsService  statsService.IStatsService
+	badgeService  badgeService.IBadgeService
+	goalService   goalService.IGoalService
+	eventsService eventsService.IEventsService
+}
+
+// *******************************************************************
+// WORKOUT SESSIONS
+// *******************************************************************
+
+func (s workoutService) GetWorkoutHistory(userID string, from time.Time, to time.Time, page int32,
+	ctxLog *log.Entry) (*models.WorkoutHistoryResponse, error) {
+
+	ctxLog.Debugf("WORKOUT_SERVICE: Processing GetWorkoutHistory request for user: %s", userID)
+
+	offset := (page - 1) * configs.Basic.WorkoutsPageSize
+	size := configs.Basic.WorkoutsPageSize
+
+	workouts, err := s.WorkoutDAO.GetWorkouts(userID, from, to, offset, size, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	response := models.WorkoutHistoryResponse{
+		Workouts: make([]*models.WorkoutSession, len(workouts)),
+	}
+
+	for i, workout := range workouts {
+		response.Workouts[i] = mapWorkout(workout)
+	}
+
+	return &response, nil
+}
+
+func (s workoutService) GetWorkout(userID string, workoutID int64, ctxLog *log.Entry) (*models.WorkoutSession, error) {
+
+	ctxLog.Debugf("WORKOUT_SERVICE: Processing GetWorkout request for user: %s workout: %d", userID, workoutID)
+
+	workout, err := s.WorkoutDAO.GetWorkout(userID, workoutID, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapWorkout(workout), nil
+}
+
+func (s workoutService) CreateWorkout(userID string, request *models.WorkoutSessionRequest,
+	ctxLog *log.Entry) (*models.WorkoutSession, error) {
+
+	ctxLog.Debugf("WORKOUT_SERVICE: Processing CreateWorkout request for user: %s", userID)
+
+	if err := validateWorkout(request); err != nil {
+		return nil, err
+	}
+
+	workout := buildWorkout(userID, request)
+
+	if err := s.WorkoutDAO.CreateWorkout(workout, ctxLog); err != nil {
+		return nil, err
+	}
+
+	if err := s.markAttendance(userID, workout.Date, ctxLog); err != nil {
+		return nil, err
+	}
+
+	response := mapWorkout(workout)
+	response.AwardedBadges = s.checkStrengthBadges(userID, workout, ctxLog)
+	response.NewRecords = s.checkRecords(userID, workout, ctxLog)
+	s.checkGoals(userID, ctxLog)
+
+	return response, nil
+}
+
+func (s workoutService) EditWorkout(userID string, workoutID int64, request *models.WorkoutSessionRequest,
+	ctxLog *log.Entry) (*models.WorkoutSession, error) {
+
+	ctxLog.Debugf("WORKOUT_SERVICE: Processing EditWorkout request for user: %s workout: %d", userID, workoutID)
+
+	if err := validateWorkout(request); err != nil {
+		return nil, err
+	}
+
+	workout := buildWorkout(userID, request)
+	workout.ID = workoutID
+
+	if err := s.WorkoutDAO.EditWorkout(workout, ctxLog); err != nil {
+		return nil, err
+	}
+
+	// The date may have been changed
+	if err := s.markAttendance(userID, workout.Date, ctxLog); err != nil {
+		return nil, err
+	}
+
+	// The records of the previous sets are checked again against the new ones
+	if err := s.WorkoutDAO.DeleteWorkoutRecords(userID, workoutID, ctxLog); err != nil {
+		return nil, err
+	}
+
+	response := mapWorkout(workout)
+	response.AwardedBadges = s.checkStrengthBadges(userID, workout, ctxLog)
+	response.NewRecords = s.checkRecords(userID, workout, ctxLog)
+	s.checkGoals(userID, ctxLog)
+
+	return response, nil
+}
+
+func (s workoutService) DeleteWorkout(userID string, workoutID int64, ctxLog *log.Entry) error {
+
+	ctxLog.Debugf("WORKOUT_SERVICE: Processing DeleteWorkout request for user: %s workout: %d", userID, workoutID)
+
+	// The gym attendance is kept, the user went to the gym anyway
+	return s.WorkoutDAO.DeleteWorkout(userID, workoutID, ctxLog)
+}
+
+// markAttendance adds the workout date as attended, unless it already was. Adding it twice would
+// count the same day twice in the streak.
+func (s workoutService) markAttendance(userID string, date time.Time, ctxLog *log.Entry) error {
+
+	attended, err := s.UserDAO.CheckGymAttendance(userID, date, ctxLog)
+	if err != nil {
+		return err
+	}
+
+	if attended {
+		return nil
+	}
+
+	return s.statsService.AddGymAttendance(userID, date, ctxLog)
+}
+
+// checkStrengthBadges awards the badges achieved with the workout sets. The workout is already saved,
+// so a failure here is only logged.
+func (s workoutService) checkStrengthBadges(userID string, workout *workoutDAO.WorkoutSession, ctxLog *log.Entry) []int32 {
+
+	awarded, err := s.badgeService.CheckStrengthBadges(userID, workout.Exercises, ctxLog)
+	if err != nil {
+		ctxLog.Warnf("WORKOUT_SERVICE: Checking strength badges for user %s failed: %s", userID, err)
+	}
+
+	response := make([]int32, len(awarded))
+	for i, badgeID := range awarded {
+		response[i] = int32(badgeID)
+	}
+
+	return response
+}
+
+// checkGoals marks the lift goals reached with the new records as achieved. The workout is already saved,
+// so a failure here is only logged.
+func (s workoutService) checkGoals(userID string, ctxLog *log.Entry) {
+	if _, err := s.goalService.CheckGoals(userID, ctxLog); err != nil {
+		ctxLog.Warnf("WORKOUT_SERVICE: Checking goals for user %s failed: %s", userID, err)
+	}
+}
+
+// *******************************************************************
+// EXERCISE HISTORY
+// *******************************************************************
+
+func (s workoutService) GetExerciseHistory(userID string, exercise string, months int32,
+	ctxLog *log.Entry) (*models.ExerciseHistoryResponse, error) {
+
+	ctxLog.Debugf("WORKOUT_SERVICE: Processing GetExerciseHistory request for user: %s exercise: %s", userID, exercise)
+
+	workouts, err := s.WorkoutDAO.GetExerciseHistory(userID, strings.TrimSpace(exercise), months, ctxLog)
+	if err != nil {
+		return nil, err
+	}
+
+	response := models.ExerciseHistoryResponse{
+		Exercise: strings.TrimSpace(exercise),
+		Days:     make([]*models.ExerciseHistoryDay, 0, len(workouts)),
+	}
+
+	// Workouts come ordered by date, several sessions in the same day are merged
+	var day *models.ExerciseHistoryDay
+
+	for _, workout := range workouts {
+
+		date := workout.Date.Format(constants.ISODateLayout)
+		if day == nil || day.Date != date {
+			day = &models.ExerciseHistoryDay{
+				Date: date,
+				Sets: make([]*models.WorkoutSet, 0),
+			}
+			response.Days = append(response.Days, day)
+		}
+
+		for _, workoutExercise := range workout.Exercises {
+			day.Sets = append(day.Sets, mapSets(workoutExercise.Sets)...)
+		}
+	}
+
+	return &response, nil
+}
+
+// *******************************************************************
+// VALIDATION AND MAPPING
+// *******************************************************************
+
+func validateWorkout(request *models.WorkoutSessionRequest) error {
+
+	if time.Time(request.Date).After(time.Now()) {
+		return customErrors.BuildBadRequestError("Date cannot be in the future.")
+	}
+
+	for _, exercise := range request.Exercises {
+
+		if strings.TrimSpace(exercise.Name) == constants.EmptyString {
+			return customErrors.BuildBadRequestError("Exercise name cannot be empty.")
+		}
+
+		for _, set := range exercise.Sets {
+			switch {
+			case set.Reps < 1:
+				return customErrors.BuildBadRequestError("%s: reps must be at least 1.", exercise.Name)
+			case set.Weight < 0:
+				return customErrors.BuildBadRequestError("%s: weight cannot be negative.", exercise.Name)
+			case set.Rpe != nil && (*set.Rpe < minRPE || *set.Rpe > maxRPE):
+				return customErrors.BuildBadRequestError("%s: RPE must be between %d and %d.", exercise.Name, minRPE, maxRPE)
+			case set.RestSeconds != nil && *set.RestSeconds < 0:
+				return customErrors.BuildBadRequestError("%s: rest time cannot be negative.", exercise.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func buildWorkout(userID string, request *models.WorkoutSessionRequest) *workoutDAO.WorkoutSession {
+
+	workout := workoutDAO.WorkoutSession{
+		UserID:    userID,
+		Date:      time.Time(request.Date),
+		Name:      request.Name,
+		Notes:     request.Notes,
+		Exercises: make([]workoutDAO.WorkoutExercise, len(request.Exercises)),
+	}
+
+	for i, exercise := range request.Exercises {
+
+		workout.Exercises[i] = workoutDAO.WorkoutExercise{
+			Position: int32(i),
+			Name:     strings.TrimSpace(exercise.Name),
+			Sets:     make([]workoutDAO.WorkoutSet, len(exercise.Sets)),
+		}
+
+		for j, set := range exercise.Sets {
+			workout.Exercises[i].Sets[j] = workoutDAO.WorkoutSet{
+				Position:    int32(j),
+				Reps:        set.Reps,
+				Weight:      set.Weight,
+				RPE:         set.Rpe,
+				RestSeconds: set.RestSeconds,
+			}
+		}
+	}
+
+	return &workout
+}
+
+func mapWorkout(workout *workoutDAO.WorkoutSession) *models.WorkoutSession {
+
+	response := models.WorkoutSession{
+		ID:        workout.ID,
+		Date:      workout.Date.Format(constants.ISODateLayout),
+		Name:      workout.Name,
+		Notes:     workout.Notes,
+		Exercises: make([]*models.WorkoutExercise, len(workout.Exercises)),
+	}
+
+	for i, exercise := range workout.Exercises {
+		response.Exercises[i] = &models.WorkoutExercise{
+			Name: exercise.Name,
+			Sets: mapSets(exercise.Sets),
+		}
+	}
+
+	return &response
+}
+
+func mapSets(sets []workoutDAO.WorkoutSet) []*models.WorkoutSet {
+
+	response := make([]*models.WorkoutSet, len(sets))
+
+	for i, set := range sets {
+		response[i] = &models.WorkoutSet{
+			Reps:        set.Reps,
+			Weight:      set.Weight,
+			Rpe:         set.RPE,
+			RestSeconds: set.RestSeconds,
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,21 @@
+package workout_service
+
+import (
+	"gym-badges-api/models"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type IWorkoutService interface {
+	GetWorkoutHistory(userID string, from time.Time, to time.Time, page int32, ctxLog *log.Entry) (*models.WorkoutHistoryResponse, error)
+	GetWorkout(userID string, workoutID int64, ctxLog *log.Entry) (*models.WorkoutSession, error)
+	CreateWorkout(userID string, request *models.WorkoutSessionRequest, ctxLog *log.Entry) (*models.WorkoutSession, error)
+	EditWorkout(userID string, workoutID int64, request *models.WorkoutSessionRequest, ctxLog *log.Entry) (*models.WorkoutSession, error)
+	DeleteWorkout(userID string, workoutID int64, ctxLog *log.Entry) error
+
+	GetExerciseHistory(userID string, exercise string, months int32, ctxLog *log.Entry) (*models.ExerciseHistoryResponse, error)
+
+	GetPersonalRecords(userID string, ctxLog *log.Entry) (*models.PersonalRecordsResponse, error)
+	GetExerciseProgression(userID string, exercise string, months int32, ctxLog *log.Entry) (*models.ExerciseProgressionResponse, error)
+}
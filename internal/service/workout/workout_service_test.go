@@ -0,0 +1,620 @@
+package workout_service
+
+import (
+	"errors"
+	"fmt"
+	configs "gym-badges-api/config/gym-badges-server"
+	customErrors "gym-badges-api/internal/custom-errors"
+	workoutDAO "gym-badges-api/internal/repository/workout"
+	eventsService "gym-badges-api/internal/service/events"
+	mockDAO "gym-badges-api/mocks/dao"
+	mockService "gym-badges-api/mocks/service"
+	"gym-badges-api/models"
+	toolsLogging "gym-badges-api/tools/logging"
+	toolsTesting "gym-badges-api/tools/testing"
+	"gym-badges-api/tools/utils"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	log "github.com/sirupsen/logrus"
+	"go.uber.org/mock/gomock"
+)
+
+func TestServiceWorkoutSuite(t *testing.T) {
+	toolsTesting.ConfigureTestSuite(t, "SERVICE: Workout Test Suite")
+}
+
+var _ = Describe("SERVICE: Workout Test Suite", func() {
+
+	var (
+		mockCtrl          *gomock.Controller
+		mockWorkoutDAO    *mockDAO.MockIWorkoutDAO
+		mockUserDAO       *mockDAO.MockIUserDAO
+		mockStatsService  *mockService.MockIStatsService
+		mockBadgeService  *mockService.MockIBadgeService
+		mockGoalService   *mockService.MockIGoalService
+		mockEventsService *mockService.MockIEventsService
+		service           IWorkoutService
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockWorkoutDAO = mockDAO.NewMockIWorkoutDAO(mockCtrl)
+		mockUserDAO = mockDAO.NewMockIUserDAO(mockCtrl)
+		mockStatsService = mockService.NewMockIStatsService(mockCtrl)
+		mockBadgeService = mockService.NewMockIBadgeService(mockCtrl)
+		mockGoalService = mockService.NewMockIGoalService(mockCtrl)
+		mockEventsService = mockService.NewMockIEventsService(mockCtrl)
+		service = NewWorkoutService(mockWorkoutDAO, mockUserDAO, mockStatsService, mockBadgeService, mockGoalService, mockEventsService)
+	})
+
+	AfterEach(func() {
+		defer mockCtrl.Finish()
+	})
+
+	Context("Get Workout History", func() {
+
+		var (
+			ctxLogger *log.Entry
+			userID    string
+			page      int32
+			workouts  []*workoutDAO.WorkoutSession
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+
+			userID = "admin"
+			page = 2
+			configs.Basic.WorkoutsPageSize = 10
+
+			workouts = []*workoutDAO.WorkoutSession{
+				{
+					ID:     2,
+					UserID: "admin",
+					Date:   parseTime("2024-11-07T00:00:00"),
+					Name:   "Push",
+					Exercises: []workoutDAO.WorkoutExercise{
+						{
+							Name: "Bench press",
+							Sets: []workoutDAO.WorkoutSet{
+								{Reps: 5, Weight: 100, RPE: utils.NewFloat32(8)},
+								{Reps: 5, Weight: 100},
+							},
+						},
+					},
+				},
+				{
+					ID:     1,
+					UserID: "admin",
+					Date:   parseTime("2024-11-01T00:00:00"),
+					Name:   "Legs",
+				},
+			}
+		})
+
+		It("CASE: Successful get workout history", func() {
+
+			mockWorkoutDAO.EXPECT().GetWorkouts(userID, time.Time{}, time.Time{}, int32(10), int32(10), ctxLogger).
+				Times(1).
+				Return(workouts, nil)
+
+			response, err := service.GetWorkoutHistory(userID, time.Time{}, time.Time{}, page, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(len(response.Workouts)).To(Equal(2))
+			Expect(response.Workouts[0].ID).To(Equal(int64(2)))
+			Expect(response.Workouts[0].Date).To(Equal("2024-11-07"))
+			Expect(len(response.Workouts[0].Exercises)).To(Equal(1))
+			Expect(response.Workouts[0].Exercises[0].Name).To(Equal("Bench press"))
+			Expect(len(response.Workouts[0].Exercises[0].Sets)).To(Equal(2))
+			Expect(*response.Workouts[0].Exercises[0].Sets[0].Rpe).To(Equal(float32(8)))
+			Expect(response.Workouts[0].Exercises[0].Sets[1].Rpe).To(BeNil())
+			Expect(len(response.Workouts[1].Exercises)).To(Equal(0))
+		})
+
+		It("CASE: Get workout history failed cause user not exist", func() {
+
+			mockWorkoutDAO.EXPECT().GetWorkouts(userID, time.Time{}, time.Time{}, int32(10), int32(10), ctxLogger).
+				Times(1).
+				Return(nil, customErrors.BuildNotFoundError("not found"))
+
+			response, err := service.GetWorkoutHistory(userID, time.Time{}, time.Time{}, page, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.NotFoundError{}))
+			Expect(response).To(BeNil())
+		})
+
+	})
+
+	Context("Create Workout", func() {
+
+		var (
+			ctxLogger *log.Entry
+			userID    string
+			date      time.Time
+			request   *models.WorkoutSessionRequest
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+
+			userID = "admin"
+			date = parseTime("2024-11-07T00:00:00")
+
+			request = &models.WorkoutSessionRequest{
+				Date: strfmt.Date(date),
+				Name: "Push",
+				Exercises: []*models.WorkoutExercise{
+					{
+						Name: " Bench press ",
+						Sets: []*models.WorkoutSet{
+							{Reps: 5, Weight: 100, Rpe: utils.NewFloat32(8)},
+							{Reps: 5, Weight: 100},
+						},
+					},
+				},
+			}
+		})
+
+		It("CASE: Successful create workout adding the gym attendance", func() {
+
+			mockWorkoutDAO.EXPECT().CreateWorkout(gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(workout *workoutDAO.WorkoutSession, _ *log.Entry) error {
+					Expect(workout.UserID).To(Equal(userID))
+					Expect(workout.Exercises[0].Name).To(Equal("Bench press"))
+					Expect(workout.Exercises[0].Sets[1].Position).To(Equal(int32(1)))
+					workout.ID = 1
+					return nil
+				})
+
+			mockUserDAO.EXPECT().CheckGymAttendance(userID, date, ctxLogger).
+				Times(1).
+				Return(false, nil)
+
+			mockStatsService.EXPECT().AddGymAttendance(userID, date, ctxLogger).
+				Times(1).
+				Return(nil)
+
+			mockBadgeService.EXPECT().CheckStrengthBadges(userID, gomock.Any(), ctxLogger).
+				Times(1).
+				Return([]int16{7, 8}, nil)
+
+			mockWorkoutDAO.EXPECT().GetRecords(userID, "", ctxLogger).
+				Times(1).
+				Return([]*workoutDAO.PersonalRecord{
+					{Exercise: "bench press", Type: "1RM", Weight: 110, Reps: 1},
+					{Exercise: "bench press", Type: "3RM", Weight: 100, Reps: 3},
+					{Exercise: "bench press", Type: "5RM", Weight: 95, Reps: 5},
+					{Exercise: "bench press", Type: "e1RM-epley", Weight: 120, Reps: 3},
+				}, nil)
+
+			mockWorkoutDAO.EXPECT().AddRecords(gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(records []*workoutDAO.PersonalRecord, _ *log.Entry) error {
+					Expect(len(records)).To(Equal(2))
+					Expect(records[0].WorkoutSessionID).To(Equal(int64(1)))
+					return nil
+				})
+
+			mockEventsService.EXPECT().Publish(eventsService.Event{Type: eventsService.NewPersonalRecord, UserID: userID}, ctxLogger).
+				Times(2)
+
+			mockGoalService.EXPECT().CheckGoals(userID, ctxLogger).
+				Times(1).
+				Return([]int64{}, nil)
+
+			response, err := service.CreateWorkout(userID, request, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response.AwardedBadges).To(Equal([]int32{7, 8}))
+			Expect(len(response.NewRecords)).To(Equal(2))
+			Expect(response.NewRecords[0].Type).To(Equal("5RM"))
+			Expect(response.NewRecords[0].Weight).To(Equal(float32(100)))
+			Expect(response.NewRecords[1].Type).To(Equal("e1RM-brzycki"))
+			Expect(response.NewRecords[1].Weight).To(Equal(float32(112.5)))
+			Expect(response.ID).To(Equal(int64(1)))
+			Expect(response.Date).To(Equal("2024-11-07"))
+			Expect(response.Exercises[0].Name).To(Equal("Bench press"))
+		})
+
+		It("CASE: Successful create workout in an already attended day", func() {
+
+			mockWorkoutDAO.EXPECT().CreateWorkout(gomock.Any(), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			mockUserDAO.EXPECT().CheckGymAttendance(userID, date, ctxLogger).
+				Times(1).
+				Return(true, nil)
+
+			mockStatsService.EXPECT().AddGymAttendance(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			mockBadgeService.EXPECT().CheckStrengthBadges(userID, gomock.Any(), ctxLogger).
+				Times(1).
+				Return([]int16{}, nil)
+
+			mockWorkoutDAO.EXPECT().GetRecords(userID, "", ctxLogger).
+				Times(1).
+				Return([]*workoutDAO.PersonalRecord{}, nil)
+
+			mockWorkoutDAO.EXPECT().AddRecords(gomock.Any(), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			mockEventsService.EXPECT().Publish(eventsService.Event{Type: eventsService.NewPersonalRecord, UserID: userID}, ctxLogger).
+				Times(5)
+
+			mockGoalService.EXPECT().CheckGoals(userID, ctxLogger).
+				Times(1).
+				Return([]int64{}, nil)
+
+			response, err := service.CreateWorkout(userID, request, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response).ToNot(BeNil())
+			Expect(len(response.NewRecords)).To(Equal(5))
+		})
+
+		It("CASE: Successful create workout even if checking badges, records and goals fails", func() {
+
+			mockWorkoutDAO.EXPECT().CreateWorkout(gomock.Any(), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			mockUserDAO.EXPECT().CheckGymAttendance(userID, date, ctxLogger).
+				Times(1).
+				Return(true, nil)
+
+			mockBadgeService.EXPECT().CheckStrengthBadges(userID, gomock.Any(), ctxLogger).
+				Times(1).
+				Return(nil, errors.New("panic"))
+
+			mockWorkoutDAO.EXPECT().GetRecords(userID, "", ctxLogger).
+				Times(1).
+				Return(nil, errors.New("panic"))
+
+			mockGoalService.EXPECT().CheckGoals(userID, ctxLogger).
+				Times(1).
+				Return(nil, errors.New("panic"))
+
+			response, err := service.CreateWorkout(userID, request, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(len(response.AwardedBadges)).To(Equal(0))
+			Expect(len(response.NewRecords)).To(Equal(0))
+		})
+
+		It("CASE: Create workout failed cause date is in the future", func() {
+
+			request.Date = strfmt.Date(time.Now().AddDate(0, 0, 2))
+
+			response, err := service.CreateWorkout(userID, request, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.BadRequestError{}))
+			Expect(response).To(BeNil())
+		})
+
+		It("CASE: Create workout failed cause of an invalid set", func() {
+
+			request.Exercises[0].Sets[0].Rpe = utils.NewFloat32(11)
+
+			response, err := service.CreateWorkout(userID, request, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.BadRequestError{}))
+			Expect(response).To(BeNil())
+
+			request.Exercises[0].Sets[0].Rpe = nil
+			request.Exercises[0].Sets[0].Reps = 0
+
+			response, err = service.CreateWorkout(userID, request, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.BadRequestError{}))
+			Expect(response).To(BeNil())
+		})
+
+		It("CASE: Create workout failed cause user not exist", func() {
+
+			mockWorkoutDAO.EXPECT().CreateWorkout(gomock.Any(), ctxLogger).
+				Times(1).
+				Return(customErrors.BuildNotFoundError("not found"))
+
+			response, err := service.CreateWorkout(userID, request, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.NotFoundError{}))
+			Expect(response).To(BeNil())
+		})
+
+	})
+
+	Context("Edit Workout", func() {
+
+		var (
+			ctxLogger *log.Entry
+			userID    string
+			date      time.Time
+			request   *models.WorkoutSessionRequest
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+
+			userID = "admin"
+			date = parseTime("2024-11-07T00:00:00")
+
+			request = &models.WorkoutSessionRequest{
+				Date: strfmt.Date(date),
+				Name: "Pull",
+			}
+		})
+
+		It("CASE: Successful edit workout", func() {
+
+			mockWorkoutDAO.EXPECT().EditWorkout(gomock.Any(), ctxLogger).
+				Times(1).
+				DoAndReturn(func(workout *workoutDAO.WorkoutSession, _ *log.Entry) error {
+					Expect(workout.ID).To(Equal(int64(3)))
+					return nil
+				})
+
+			mockUserDAO.EXPECT().CheckGymAttendance(userID, date, ctxLogger).
+				Times(1).
+				Return(true, nil)
+
+			mockWorkoutDAO.EXPECT().DeleteWorkoutRecords(userID, int64(3), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			mockBadgeService.EXPECT().CheckStrengthBadges(userID, gomock.Any(), ctxLogger).
+				Times(1).
+				Return([]int16{}, nil)
+
+			mockWorkoutDAO.EXPECT().GetRecords(userID, "", ctxLogger).
+				Times(1).
+				Return([]*workoutDAO.PersonalRecord{}, nil)
+
+			mockGoalService.EXPECT().CheckGoals(userID, ctxLogger).
+				Times(1).
+				Return([]int64{}, nil)
+
+			response, err := service.EditWorkout(userID, 3, request, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response.ID).To(Equal(int64(3)))
+			Expect(response.Name).To(Equal("Pull"))
+		})
+
+		It("CASE: Edit workout failed cause workout not exist", func() {
+
+			mockWorkoutDAO.EXPECT().EditWorkout(gomock.Any(), ctxLogger).
+				Times(1).
+				Return(customErrors.BuildNotFoundError("not found"))
+
+			response, err := service.EditWorkout(userID, 3, request, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.NotFoundError{}))
+			Expect(response).To(BeNil())
+		})
+
+	})
+
+	Context("Get Exercise History", func() {
+
+		var (
+			ctxLogger *log.Entry
+			userID    string
+			workouts  []*workoutDAO.WorkoutSession
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+
+			userID = "admin"
+
+			workouts = []*workoutDAO.WorkoutSession{
+				{
+					ID:   1,
+					Date: parseTime("2024-11-01T00:00:00"),
+					Exercises: []workoutDAO.WorkoutExercise{
+						{Name: "Squat", Sets: []workoutDAO.WorkoutSet{{Reps: 5, Weight: 120}}},
+					},
+				},
+				{
+					ID:   2,
+					Date: parseTime("2024-11-07T00:00:00"),
+					Exercises: []workoutDAO.WorkoutExercise{
+						{Name: "Squat", Sets: []workoutDAO.WorkoutSet{{Reps: 5, Weight: 125}}},
+					},
+				},
+				{
+					ID:   3,
+					Date: parseTime("2024-11-07T00:00:00"),
+					Exercises: []workoutDAO.WorkoutExercise{
+						{Name: "Squat", Sets: []workoutDAO.WorkoutSet{{Reps: 3, Weight: 130}}},
+					},
+				},
+			}
+		})
+
+		It("CASE: Successful get exercise history merging sessions of the same day", func() {
+
+			mockWorkoutDAO.EXPECT().GetExerciseHistory(userID, "Squat", int32(3), ctxLogger).
+				Times(1).
+				Return(workouts, nil)
+
+			response, err := service.GetExerciseHistory(userID, "Squat", 3, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response.Exercise).To(Equal("Squat"))
+			Expect(len(response.Days)).To(Equal(2))
+			Expect(response.Days[0].Date).To(Equal("2024-11-01"))
+			Expect(len(response.Days[0].Sets)).To(Equal(1))
+			Expect(response.Days[1].Date).To(Equal("2024-11-07"))
+			Expect(len(response.Days[1].Sets)).To(Equal(2))
+			Expect(response.Days[1].Sets[1].Weight).To(Equal(float32(130)))
+		})
+
+		It("CASE: Successful retrieval without history", func() {
+
+			mockWorkoutDAO.EXPECT().GetExerciseHistory(userID, "Squat", int32(0), ctxLogger).
+				Times(1).
+				Return([]*workoutDAO.WorkoutSession{}, nil)
+
+			response, err := service.GetExerciseHistory(userID, "Squat", 0, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(len(response.Days)).To(Equal(0))
+		})
+
+	})
+
+	Context("Get Personal Records", func() {
+
+		var (
+			ctxLogger *log.Entry
+			userID    string
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+			userID = "admin"
+		})
+
+		It("CASE: Successful get personal records board", func() {
+
+			mockWorkoutDAO.EXPECT().GetRecords(userID, "", ctxLogger).
+				Times(1).
+				Return([]*workoutDAO.PersonalRecord{
+					{Exercise: "Squat", Type: "5RM", Weight: 120, Reps: 5, Date: parseTime("2024-11-01T00:00:00")},
+					{Exercise: "Bench press", Type: "e1RM-epley", Weight: 116.67, Reps: 5, Date: parseTime("2024-11-01T00:00:00")},
+					{Exercise: "Bench press", Type: "1RM", Weight: 110, Reps: 1, Date: parseTime("2024-11-02T00:00:00")},
+					{Exercise: "squat", Type: "5RM", Weight: 125, Reps: 5, WorkoutSessionID: 2, Date: parseTime("2024-11-07T00:00:00")},
+				}, nil)
+
+			response, err := service.GetPersonalRecords(userID, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(len(response.Records)).To(Equal(3))
+			Expect(response.Records[0].Exercise).To(Equal("Bench press"))
+			Expect(response.Records[0].Type).To(Equal("1RM"))
+			Expect(response.Records[1].Type).To(Equal("e1RM-epley"))
+			Expect(response.Records[2].Weight).To(Equal(float32(125)))
+			Expect(response.Records[2].Date).To(Equal("2024-11-07"))
+			Expect(response.Records[2].WorkoutID).To(Equal(int64(2)))
+		})
+
+		It("CASE: Get personal records failed cause user not exist", func() {
+
+			mockWorkoutDAO.EXPECT().GetRecords(userID, "", ctxLogger).
+				Times(1).
+				Return(nil, customErrors.BuildNotFoundError("not found"))
+
+			response, err := service.GetPersonalRecords(userID, ctxLogger)
+			Expect(err).To(BeAssignableToTypeOf(customErrors.NotFoundError{}))
+			Expect(response).To(BeNil())
+		})
+
+	})
+
+	Context("Get Exercise Progression", func() {
+
+		var (
+			ctxLogger *log.Entry
+			userID    string
+			workouts  []*workoutDAO.WorkoutSession
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+
+			userID = "admin"
+
+			workouts = []*workoutDAO.WorkoutSession{
+				{
+					ID:   1,
+					Date: parseTime("2024-11-01T00:00:00"),
+					Exercises: []workoutDAO.WorkoutExercise{
+						{Name: "Squat", Sets: []workoutDAO.WorkoutSet{{Reps: 5, Weight: 120}, {Reps: 15, Weight: 80}}},
+					},
+				},
+				{
+					ID:   2,
+					Date: parseTime("2024-11-07T00:00:00"),
+					Exercises: []workoutDAO.WorkoutExercise{
+						{Name: "Squat", Sets: []workoutDAO.WorkoutSet{{Reps: 1, Weight: 140}}},
+					},
+				},
+			}
+		})
+
+		It("CASE: Successful get exercise progression", func() {
+
+			mockWorkoutDAO.EXPECT().GetExerciseHistory(userID, "Squat", int32(0), ctxLogger).
+				Times(1).
+				Return(workouts, nil)
+
+			mockWorkoutDAO.EXPECT().GetRecords(userID, "Squat", ctxLogger).
+				Times(1).
+				Return([]*workoutDAO.PersonalRecord{
+					{Exercise: "Squat", Type: "1RM", Weight: 140, Reps: 1, Date: parseTime("2024-11-07T00:00:00")},
+				}, nil)
+
+			response, err := service.GetExerciseProgression(userID, " Squat ", 0, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(response.Exercise).To(Equal("Squat"))
+			Expect(len(response.Points)).To(Equal(2))
+			Expect(response.Points[0].TopWeight).To(Equal(float32(120)))
+			Expect(response.Points[0].Volume).To(Equal(float32(1800)))
+			// The set of 15 reps is not used to estimate
+			Expect(response.Points[0].EstimatedEpley).To(Equal(float32(140)))
+			Expect(response.Points[0].EstimatedBrzycki).To(Equal(float32(135)))
+			Expect(response.Points[1].EstimatedEpley).To(Equal(float32(140)))
+			Expect(len(response.Records)).To(Equal(1))
+		})
+
+		It("CASE: Records older than the period are not returned", func() {
+
+			mockWorkoutDAO.EXPECT().GetExerciseHistory(userID, "Squat", int32(3), ctxLogger).
+				Times(1).
+				Return([]*workoutDAO.WorkoutSession{}, nil)
+
+			mockWorkoutDAO.EXPECT().GetRecords(userID, "Squat", ctxLogger).
+				Times(1).
+				Return([]*workoutDAO.PersonalRecord{
+					{Exercise: "Squat", Type: "1RM", Weight: 140, Reps: 1, Date: time.Now().AddDate(-1, 0, 0)},
+				}, nil)
+
+			response, err := service.GetExerciseProgression(userID, "Squat", 3, ctxLogger)
+			Expect(err).To(BeNil())
+			Expect(len(response.Points)).To(Equal(0))
+			Expect(len(response.Records)).To(Equal(0))
+		})
+
+	})
+
+	Context("Delete Workout", func() {
+
+		var (
+			ctxLogger *log.Entry
+			userID    string
+		)
+
+		BeforeEach(func() {
+			ctxLogger = toolsLogging.BuildLogger()
+			userID = "admin"
+		})
+
+		It("CASE: Successful delete workout keeping the gym attendance", func() {
+
+			mockWorkoutDAO.EXPECT().DeleteWorkout(userID, int64(3), ctxLogger).
+				Times(1).
+				Return(nil)
+
+			mockStatsService.EXPECT().DeleteGymAttendance(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			Expect(service.DeleteWorkout(userID, 3, ctxLogger)).To(BeNil())
+		})
+
+	})
+
+})
+
+func parseTime(dateStr string) time.Time {
+	parsedTime, err := time.Parse("2006-01-02T15:04:05", dateStr)
+	if err != nil {
+		Fail(fmt.Sprintf("Failed to parse date: %s", dateStr), 1)
+	}
+	return parsedTime
+}
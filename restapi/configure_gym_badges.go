@@ -4,35 +4,63 @@ package restapi
 
 import (
 	"crypto/tls"
+	configs "gym-badges-api/config/gym-badges-server"
 	badgeHandler "gym-badges-api/internal/handler/badge"
+	exerciseHandler "gym-badges-api/internal/handler/exercise"
+	exportHandler "gym-badges-api/internal/handler/exports"
+	filesHandler "gym-badges-api/internal/handler/files"
 	friendsHandler "gym-badges-api/internal/handler/friends"
+	goalHandler "gym-badges-api/internal/handler/goal"
+	gymHandler "gym-badges-api/internal/handler/gym"
+	importHandler "gym-badges-api/internal/handler/imports"
 	loginHandler "gym-badges-api/internal/handler/login"
 	rankings_handler "gym-badges-api/internal/handler/rankings"
 	statsHandler "gym-badges-api/internal/handler/stats"
 	userHandler "gym-badges-api/internal/handler/user"
+	workoutHandler "gym-badges-api/internal/handler/workout"
 	badgeDAO "gym-badges-api/internal/repository/badge/postgresql"
+	exerciseDAO "gym-badges-api/internal/repository/exercise/postgresql"
+	goalDAO "gym-badges-api/internal/repository/goal/postgresql"
+	gymDAO "gym-badges-api/internal/repository/gym/postgresql"
 	userDAO "gym-badges-api/internal/repository/user/postgresql"
+	workoutDAO "gym-badges-api/internal/repository/workout/postgresql"
 	badgeService "gym-badges-api/internal/service/badge"
+	events "gym-badges-api/internal/service/events"
+	exerciseService "gym-badges-api/internal/service/exercise"
+	exportService "gym-badges-api/internal/service/exports"
 	friendsService "gym-badges-api/internal/service/friends"
+	goalService "gym-badges-api/internal/service/goal"
+	gymService "gym-badges-api/internal/service/gym"
+	importService "gym-badges-api/internal/service/imports"
 	loginService "gym-badges-api/internal/service/login"
 	rankingsService "gym-badges-api/internal/service/rankings"
 	sessionService "gym-badges-api/internal/service/session"
 	statsService "gym-badges-api/internal/service/stats"
 	userService "gym-badges-api/internal/service/user"
+	workoutService "gym-badges-api/internal/service/workout"
 	"gym-badges-api/restapi/operations"
 	"gym-badges-api/restapi/operations/badges"
+	"gym-badges-api/restapi/operations/exercises"
+	"gym-badges-api/restapi/operations/exports"
 	"gym-badges-api/restapi/operations/friends"
+	"gym-badges-api/restapi/operations/goals"
+	"gym-badges-api/restapi/operations/gyms"
+	"gym-badges-api/restapi/operations/imports"
 	"gym-badges-api/restapi/operations/login"
 	"gym-badges-api/restapi/operations/login_with_token"
 	"gym-badges-api/restapi/operations/rankings"
 	"gym-badges-api/restapi/operations/stats"
 	"gym-badges-api/restapi/operations/user"
+	"gym-badges-api/restapi/operations/workouts"
+	toolsLogging "gym-badges-api/tools/logging"
 	"net/http"
+	"time"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/runtime/security"
+	log "github.com/sirupsen/logrus"
 )
 
 //go:generate swagger generate server --target ../../gym-badges-api --name GymBadges --spec ../swagger.yml --principal interface{} --exclude-main
@@ -56,15 +84,38 @@ func configureAPI(api *operations.GymBadgesAPI) http.Handler {
 	// DAO'S
 	userDAO := userDAO.NewUserDAO()
 	badgeDAO := badgeDAO.NewBadgeDAO()
+	workoutDAO := workoutDAO.NewWorkoutDAO()
+	exerciseDAO := exerciseDAO.NewExerciseDAO()
+	goalDAO := goalDAO.NewGoalDAO()
+	gymDAO := gymDAO.NewGymDAO()
 
 	// SERVICES
+	eventsService := events.NewEventsService()
 	sessionService := sessionService.NewSessionService()
 	loginService := loginService.NewLoginService(userDAO, sessionService)
-	userService := userService.NewUserService(userDAO, sessionService)
-	statsService := statsService.NewStatsService(userDAO, sessionService)
-	friendsService := friendsService.NewFriendsService(userDAO)
-	badgeService := badgeService.NewBadgeService(userDAO, badgeDAO)
-	rankingsService := rankingsService.NewRankingsService(userDAO)
+	goalService := goalService.NewGoalService(goalDAO, userDAO, workoutDAO, eventsService)
+	statsService := statsService.NewStatsService(userDAO, sessionService, goalService, eventsService)
+	userService := userService.NewUserService(userDAO, sessionService, statsService)
+	friendsService := friendsService.NewFriendsService(userDAO, eventsService)
+	badgeService := badgeService.NewBadgeService(userDAO, badgeDAO, eventsService)
+	rankingsService := rankingsService.NewRankingsService(userDAO, eventsService)
+	workoutService := workoutService.NewWorkoutService(workoutDAO, userDAO, statsService, badgeService, goalService, eventsService)
+	exerciseService := exerciseService.NewExerciseService(exerciseDAO)
+	importService := importService.NewImportService(userDAO, statsService, eventsService, goalService)
+	exportService := exportService.NewExportService(userDAO)
+	gymService := gymService.NewGymService(gymDAO, userDAO, statsService)
+
+	// EVENTS
+	eventsService.Subscribe("auto badges", func(event events.Event, ctxLog *log.Entry) error {
+		return badgeService.CheckAutoBadges(event.UserID, ctxLog)
+	}, events.AttendanceAdded, events.FriendAdded, events.ExperienceChanged, events.RankChanged)
+
+	eventsService.Subscribe("friends rank", rankingsService.PublishFriendsRankChanges, events.ExperienceChanged)
+
+	// JOBS
+	go runPeriodically("badge stats", configs.Basic.BadgeStatsInterval, badgeService.RefreshBadgeStats)
+	go runPeriodically("streaks", configs.Basic.StreakInterval, statsService.RecomputeStreaks)
+	go runPeriodically("global rank badges", configs.Basic.RankBadgesInterval, badgeService.CheckGlobalRankBadges)
 
 	// HANDLERS
 	loginHandler := loginHandler.NewLoginHandler(loginService)
@@ -73,14 +124,23 @@ func configureAPI(api *operations.GymBadgesAPI) http.Handler {
 	friendsHandler := friendsHandler.NewFriendsHandler(friendsService)
 	badgeHandler := badgeHandler.NewBadgeHandler(badgeService)
 	rankingsHandler := rankings_handler.NewRankingsHandler(rankingsService)
+	workoutHandler := workoutHandler.NewWorkoutHandler(workoutService)
+	exerciseHandler := exerciseHandler.NewExerciseHandler(exerciseService)
+	goalHandler := goalHandler.NewGoalHandler(goalService)
+	importHandler := importHandler.NewImportHandler(importService)
+	exportHandler := exportHandler.NewExportHandler(exportService)
+	gymHandler := gymHandler.NewGymHandler(gymService)
 
 	api.ServeError = errors.ServeError
 
 	api.UseSwaggerUI()
 
 	api.JSONConsumer = runtime.JSONConsumer()
+	api.MultipartformConsumer = runtime.DiscardConsumer
 
 	api.JSONProducer = runtime.JSONProducer()
+	api.CsvProducer = runtime.CSVProducer()
+	api.TextCalendarProducer = runtime.TextProducer()
 
 	// *******************************************************************
 	// AUTHENTICATION
@@ -130,6 +190,14 @@ func configureAPI(api *operations.GymBadgesAPI) http.Handler {
 		return statsHandler.AddBodyFat(params)
 	})
 
+	api.StatsGetBodyCompositionByUserIDHandler = stats.GetBodyCompositionByUserIDHandlerFunc(func(params stats.GetBodyCompositionByUserIDParams, new interface{}) middleware.Responder {
+		return statsHandler.GetBodyComposition(params)
+	})
+
+	api.StatsGetMeasurementTrendByUserIDHandler = stats.GetMeasurementTrendByUserIDHandlerFunc(func(params stats.GetMeasurementTrendByUserIDParams, new interface{}) middleware.Responder {
+		return statsHandler.GetMeasurementTrend(params)
+	})
+
 	api.StatsGetStreakCalendarByUserIDHandler = stats.GetStreakCalendarByUserIDHandlerFunc(func(params stats.GetStreakCalendarByUserIDParams, new interface{}) middleware.Responder {
 		return statsHandler.GetStreakCalendar(params)
 	})
@@ -142,6 +210,26 @@ func configureAPI(api *operations.GymBadgesAPI) http.Handler {
 		return statsHandler.DeleteGymAttendance(params)
 	})
 
+	api.StatsGetAttendanceRangeHandler = stats.GetAttendanceRangeHandlerFunc(func(params stats.GetAttendanceRangeParams, new interface{}) middleware.Responder {
+		return statsHandler.GetAttendanceRange(params)
+	})
+
+	api.StatsGetTrainingTimeHandler = stats.GetTrainingTimeHandlerFunc(func(params stats.GetTrainingTimeParams, new interface{}) middleware.Responder {
+		return statsHandler.GetTrainingTime(params)
+	})
+
+	api.StatsGetStreakProtectionHandler = stats.GetStreakProtectionHandlerFunc(func(params stats.GetStreakProtectionParams, new interface{}) middleware.Responder {
+		return statsHandler.GetStreakProtection(params)
+	})
+
+	api.StatsAddVacationWeekHandler = stats.AddVacationWeekHandlerFunc(func(params stats.AddVacationWeekParams, new interface{}) middleware.Responder {
+		return statsHandler.AddVacationWeek(params)
+	})
+
+	api.StatsDeleteVacationWeekHandler = stats.DeleteVacationWeekHandlerFunc(func(params stats.DeleteVacationWeekParams, new interface{}) middleware.Responder {
+		return statsHandler.DeleteVacationWeek(params)
+	})
+
 	// *******************************************************************
 	// FRIENDS
 	// *******************************************************************
@@ -170,6 +258,10 @@ func configureAPI(api *operations.GymBadgesAPI) http.Handler {
 		return badgeHandler.GetBadgesByUserID(params)
 	})
 
+	api.BadgesGetBadgeStatsHandler = badges.GetBadgeStatsHandlerFunc(func(params badges.GetBadgeStatsParams, new interface{}) middleware.Responder {
+		return badgeHandler.GetBadgeStats(params)
+	})
+
 	api.BadgesAddBadgeHandler = badges.AddBadgeHandlerFunc(func(params badges.AddBadgeParams, new interface{}) middleware.Responder {
 		return badgeHandler.AddBadge(params)
 	})
@@ -178,6 +270,86 @@ func configureAPI(api *operations.GymBadgesAPI) http.Handler {
 		return badgeHandler.DeleteBadge(params)
 	})
 
+	api.BadgesGetBadgeTimelineHandler = badges.GetBadgeTimelineHandlerFunc(func(params badges.GetBadgeTimelineParams, new interface{}) middleware.Responder {
+		return badgeHandler.GetBadgeTimeline(params)
+	})
+
+	api.BadgesGetClosestBadgesHandler = badges.GetClosestBadgesHandlerFunc(func(params badges.GetClosestBadgesParams, new interface{}) middleware.Responder {
+		return badgeHandler.GetClosestBadges(params)
+	})
+
+	api.BadgesCreateBadgeHandler = badges.CreateBadgeHandlerFunc(func(params badges.CreateBadgeParams, new interface{}) middleware.Responder {
+		return badgeHandler.CreateBadge(params)
+	})
+
+	api.BadgesEditBadgeHandler = badges.EditBadgeHandlerFunc(func(params badges.EditBadgeParams, new interface{}) middleware.Responder {
+		return badgeHandler.EditBadge(params)
+	})
+
+	api.BadgesReparentBadgeHandler = badges.ReparentBadgeHandlerFunc(func(params badges.ReparentBadgeParams, new interface{}) middleware.Responder {
+		return badgeHandler.ReparentBadge(params)
+	})
+
+	api.BadgesRetireBadgeHandler = badges.RetireBadgeHandlerFunc(func(params badges.RetireBadgeParams, new interface{}) middleware.Responder {
+		return badgeHandler.RetireBadge(params)
+	})
+
+	api.BadgesReorderBadgesHandler = badges.ReorderBadgesHandlerFunc(func(params badges.ReorderBadgesParams, new interface{}) middleware.Responder {
+		return badgeHandler.ReorderBadges(params)
+	})
+
+	api.BadgesUploadBadgeImageHandler = badges.UploadBadgeImageHandlerFunc(func(params badges.UploadBadgeImageParams, new interface{}) middleware.Responder {
+		return badgeHandler.UploadBadgeImage(params)
+	})
+
+	api.BadgesGetBadgeAuditHandler = badges.GetBadgeAuditHandlerFunc(func(params badges.GetBadgeAuditParams, new interface{}) middleware.Responder {
+		return badgeHandler.GetBadgeAudit(params)
+	})
+
+	api.BadgesSetBadgeRuleHandler = badges.SetBadgeRuleHandlerFunc(func(params badges.SetBadgeRuleParams, new interface{}) middleware.Responder {
+		return badgeHandler.SetBadgeRule(params)
+	})
+
+	api.BadgesDeleteBadgeRuleHandler = badges.DeleteBadgeRuleHandlerFunc(func(params badges.DeleteBadgeRuleParams, new interface{}) middleware.Responder {
+		return badgeHandler.DeleteBadgeRule(params)
+	})
+
+	api.BadgesGetBadgeTranslationsHandler = badges.GetBadgeTranslationsHandlerFunc(func(params badges.GetBadgeTranslationsParams, new interface{}) middleware.Responder {
+		return badgeHandler.GetBadgeTranslations(params)
+	})
+
+	api.BadgesSetBadgeTranslationHandler = badges.SetBadgeTranslationHandlerFunc(func(params badges.SetBadgeTranslationParams, new interface{}) middleware.Responder {
+		return badgeHandler.SetBadgeTranslation(params)
+	})
+
+	api.BadgesDeleteBadgeTranslationHandler = badges.DeleteBadgeTranslationHandlerFunc(func(params badges.DeleteBadgeTranslationParams, new interface{}) middleware.Responder {
+		return badgeHandler.DeleteBadgeTranslation(params)
+	})
+
+	api.BadgesGrantBadgeHandler = badges.GrantBadgeHandlerFunc(func(params badges.GrantBadgeParams, new interface{}) middleware.Responder {
+		return badgeHandler.GrantBadge(params)
+	})
+
+	api.BadgesClaimBadgeHandler = badges.ClaimBadgeHandlerFunc(func(params badges.ClaimBadgeParams, new interface{}) middleware.Responder {
+		return badgeHandler.ClaimBadge(params)
+	})
+
+	api.BadgesGetBadgeClaimsHandler = badges.GetBadgeClaimsHandlerFunc(func(params badges.GetBadgeClaimsParams, new interface{}) middleware.Responder {
+		return badgeHandler.GetBadgeClaims(params)
+	})
+
+	api.BadgesVouchBadgeClaimHandler = badges.VouchBadgeClaimHandlerFunc(func(params badges.VouchBadgeClaimParams, new interface{}) middleware.Responder {
+		return badgeHandler.VouchBadgeClaim(params)
+	})
+
+	api.BadgesGetPendingBadgeClaimsHandler = badges.GetPendingBadgeClaimsHandlerFunc(func(params badges.GetPendingBadgeClaimsParams, new interface{}) middleware.Responder {
+		return badgeHandler.GetPendingBadgeClaims(params)
+	})
+
+	api.BadgesReviewBadgeClaimHandler = badges.ReviewBadgeClaimHandlerFunc(func(params badges.ReviewBadgeClaimParams, new interface{}) middleware.Responder {
+		return badgeHandler.ReviewBadgeClaim(params)
+	})
+
 	// *******************************************************************
 	// RANKINGS
 	// *******************************************************************
@@ -190,6 +362,132 @@ func configureAPI(api *operations.GymBadgesAPI) http.Handler {
 		return rankingsHandler.GetFriendsRanking(params)
 	})
 
+	// *******************************************************************
+	// WORKOUTS
+	// *******************************************************************
+
+	api.WorkoutsGetWorkoutHistoryHandler = workouts.GetWorkoutHistoryHandlerFunc(func(params workouts.GetWorkoutHistoryParams, new interface{}) middleware.Responder {
+		return workoutHandler.GetWorkoutHistory(params)
+	})
+
+	api.WorkoutsAddWorkoutHandler = workouts.AddWorkoutHandlerFunc(func(params workouts.AddWorkoutParams, new interface{}) middleware.Responder {
+		return workoutHandler.AddWorkout(params)
+	})
+
+	api.WorkoutsGetWorkoutHandler = workouts.GetWorkoutHandlerFunc(func(params workouts.GetWorkoutParams, new interface{}) middleware.Responder {
+		return workoutHandler.GetWorkout(params)
+	})
+
+	api.WorkoutsEditWorkoutHandler = workouts.EditWorkoutHandlerFunc(func(params workouts.EditWorkoutParams, new interface{}) middleware.Responder {
+		return workoutHandler.EditWorkout(params)
+	})
+
+	api.WorkoutsDeleteWorkoutHandler = workouts.DeleteWorkoutHandlerFunc(func(params workouts.DeleteWorkoutParams, new interface{}) middleware.Responder {
+		return workoutHandler.DeleteWorkout(params)
+	})
+
+	api.WorkoutsGetExerciseHistoryHandler = workouts.GetExerciseHistoryHandlerFunc(func(params workouts.GetExerciseHistoryParams, new interface{}) middleware.Responder {
+		return workoutHandler.GetExerciseHistory(params)
+	})
+
+	api.WorkoutsGetPersonalRecordsHandler = workouts.GetPersonalRecordsHandlerFunc(func(params workouts.GetPersonalRecordsParams, new interface{}) middleware.Responder {
+		return workoutHandler.GetPersonalRecords(params)
+	})
+
+	api.WorkoutsGetExerciseProgressionHandler = workouts.GetExerciseProgressionHandlerFunc(func(params workouts.GetExerciseProgressionParams, new interface{}) middleware.Responder {
+		return workoutHandler.GetExerciseProgression(params)
+	})
+
+	// *******************************************************************
+	// EXERCISES
+	// *******************************************************************
+
+	api.ExercisesSearchExercisesHandler = exercises.SearchExercisesHandlerFunc(func(params exercises.SearchExercisesParams, new interface{}) middleware.Responder {
+		return exerciseHandler.SearchExercises(params)
+	})
+
+	api.ExercisesAddExerciseHandler = exercises.AddExerciseHandlerFunc(func(params exercises.AddExerciseParams, new interface{}) middleware.Responder {
+		return exerciseHandler.AddExercise(params)
+	})
+
+	api.ExercisesGetExerciseHandler = exercises.GetExerciseHandlerFunc(func(params exercises.GetExerciseParams, new interface{}) middleware.Responder {
+		return exerciseHandler.GetExercise(params)
+	})
+
+	api.ExercisesDeleteExerciseHandler = exercises.DeleteExerciseHandlerFunc(func(params exercises.DeleteExerciseParams, new interface{}) middleware.Responder {
+		return exerciseHandler.DeleteExercise(params)
+	})
+
+	// *******************************************************************
+	// GOALS
+	// *******************************************************************
+
+	api.GoalsGetGoalsHandler = goals.GetGoalsHandlerFunc(func(params goals.GetGoalsParams, new interface{}) middleware.Responder {
+		return goalHandler.GetGoals(params)
+	})
+
+	api.GoalsAddGoalHandler = goals.AddGoalHandlerFunc(func(params goals.AddGoalParams, new interface{}) middleware.Responder {
+		return goalHandler.AddGoal(params)
+	})
+
+	api.GoalsGetGoalHandler = goals.GetGoalHandlerFunc(func(params goals.GetGoalParams, new interface{}) middleware.Responder {
+		return goalHandler.GetGoal(params)
+	})
+
+	api.GoalsEditGoalHandler = goals.EditGoalHandlerFunc(func(params goals.EditGoalParams, new interface{}) middleware.Responder {
+		return goalHandler.EditGoal(params)
+	})
+
+	api.GoalsDeleteGoalHandler = goals.DeleteGoalHandlerFunc(func(params goals.DeleteGoalParams, new interface{}) middleware.Responder {
+		return goalHandler.DeleteGoal(params)
+	})
+
+	// *******************************************************************
+	// IMPORTS
+	// *******************************************************************
+
+	api.ImportsImportHistoryHandler = imports.ImportHistoryHandlerFunc(func(params imports.ImportHistoryParams, new interface{}) middleware.Responder {
+		return importHandler.ImportHistory(params)
+	})
+
+	// *******************************************************************
+	// EXPORTS
+	// *******************************************************************
+
+	api.ExportsExportCSVHandler = exports.ExportCSVHandlerFunc(func(params exports.ExportCSVParams, new interface{}) middleware.Responder {
+		return exportHandler.ExportCSV(params)
+	})
+
+	api.ExportsGetCalendarSubscriptionHandler = exports.GetCalendarSubscriptionHandlerFunc(func(params exports.GetCalendarSubscriptionParams, new interface{}) middleware.Responder {
+		return exportHandler.GetCalendarSubscription(params)
+	})
+
+	api.ExportsResetCalendarSubscriptionHandler = exports.ResetCalendarSubscriptionHandlerFunc(func(params exports.ResetCalendarSubscriptionParams, new interface{}) middleware.Responder {
+		return exportHandler.ResetCalendarSubscription(params)
+	})
+
+	// The secret token of the URL authenticates the calendar apps, which cannot send the token header
+	api.ExportsGetAttendanceCalendarHandler = exports.GetAttendanceCalendarHandlerFunc(func(params exports.GetAttendanceCalendarParams) middleware.Responder {
+		return exportHandler.GetAttendanceCalendar(params)
+	})
+
+	// *******************************************************************
+	// GYMS
+	// *******************************************************************
+
+	api.GymsGetGymsHandler = gyms.GetGymsHandlerFunc(func(params gyms.GetGymsParams, new interface{}) middleware.Responder {
+		return gymHandler.GetGyms(params)
+	})
+
+	// The gym devices authenticate with the key of the gym, not with an user session
+	api.GymsGetGymQRCodeHandler = gyms.GetGymQRCodeHandlerFunc(func(params gyms.GetGymQRCodeParams) middleware.Responder {
+		return gymHandler.GetGymQRCode(params)
+	})
+
+	api.GymsCheckInHandler = gyms.CheckInHandlerFunc(func(params gyms.CheckInParams, new interface{}) middleware.Responder {
+		return gymHandler.CheckIn(params)
+	})
+
 	// Authentication Middleware
 	api.APIKeyAuthenticator = func(_ string, _ string, authentication security.TokenAuthentication) runtime.Authenticator {
 		return Authenticator{sessionService: sessionService}
@@ -202,6 +500,23 @@ func configureAPI(api *operations.GymBadgesAPI) http.Handler {
 	return setupGlobalMiddleware(api.Serve(setupMiddlewares))
 }
 
+// runPeriodically runs the job at once and then every interval, logging its failures. Not positive intervals
+// run it only once
+func runPeriodically(name string, interval time.Duration, job func(ctxLog *log.Entry) error) {
+
+	for {
+		ctxLog := toolsLogging.BuildLogger()
+		if err := job(ctxLog); err != nil {
+			ctxLog.Errorf("JOBS: %s failed: %s", name, err)
+		}
+
+		if interval <= 0 {
+			return
+		}
+		time.Sleep(interval)
+	}
+}
+
 // The TLS configuration before HTTPS server starts.
 func configureTLS(_ *tls.Config) {
 	// Make all necessary changes to the TLS configuration here.
@@ -223,7 +538,14 @@ func setupMiddlewares(handler http.Handler) http.Handler {
 // The middleware configuration happens before anything, this middleware also applies to serving the swagger.json document.
 // So this is a good place to plug in a panic handling middleware, logging and metrics.
 func setupGlobalMiddleware(handler http.Handler) http.Handler {
-	return handler
+
+	// The uploaded badge images and claim proofs are served from their directories
+	uploads := filesHandler.NewFilesHandler(
+		filesHandler.Upload{Path: configs.Basic.BadgeImagesPath, Dir: configs.Basic.BadgeImagesDir, Types: filesHandler.ImageTypes},
+		filesHandler.Upload{Path: configs.Basic.ClaimProofsPath, Dir: configs.Basic.ClaimProofsDir, Types: filesHandler.ProofTypes},
+	)
+
+	return uploads.Middleware(handler)
 }
 
 type Authenticator struct {
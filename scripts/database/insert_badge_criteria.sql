@@ -0,0 +1,65 @@
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (1, 'Push-up', 0, 5, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (2, 'Push-up', 0, 10, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (3, 'Push-up', 0, 20, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (4, 'Push-up', 0, 30, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (5, 'Push-up', 0, 50, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (6, 'Push-up', 0, 100, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (7, 'Bench press', 20, 10, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (8, 'Bench press', 30, 10, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (9, 'Bench press', 40, 10, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (10, 'Bench press', 60, 10, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (11, 'Bench press', 80, 5, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (12, 'Bench press', 100, 5, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (13, 'Bench press', 120, 5, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (14, 'Chest dip', 0, 5, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (15, 'Chest dip', 0, 10, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (16, 'Chest dip', 0, 20, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (17, 'Chest dip', 0, 30, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (18, 'Chest dip', 0, 50, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (19, 'Biceps curl', 8, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (20, 'Biceps curl', 10, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (21, 'Biceps curl', 12, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (22, 'Biceps curl', 16, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (23, 'Biceps curl', 20, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (24, 'Biceps curl', 25, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (25, 'Biceps curl', 30, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (26, 'Shoulder press', 6, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (27, 'Shoulder press', 10, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (28, 'Shoulder press', 14, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (29, 'Shoulder press', 18, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (30, 'Shoulder press', 22, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (31, 'Shoulder press', 26, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (32, 'Shoulder press', 30, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (33, 'Lateral raise', 4, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (34, 'Lateral raise', 8, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (35, 'Lateral raise', 10, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (36, 'Lateral raise', 12, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (37, 'Lateral raise', 14, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (38, 'Lateral raise', 16, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (39, 'Lateral raise', 20, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (40, 'Pull-up', 0, 5, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (41, 'Pull-up', 0, 10, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (42, 'Pull-up', 0, 20, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (43, 'Pull-up', 0, 30, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (44, 'Lat pull-down', 20, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (45, 'Lat pull-down', 30, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (46, 'Lat pull-down', 40, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (47, 'Lat pull-down', 50, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (48, 'Lat pull-down', 60, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (49, 'Lat pull-down', 70, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (50, 'Lat pull-down', 80, 1, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (51, 'Squat', 20, 10, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (52, 'Squat', 30, 10, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (53, 'Squat', 40, 10, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (54, 'Squat', 60, 10, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (55, 'Squat', 80, 10, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (56, 'Squat', 100, 5, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (57, 'Squat', 120, 5, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (58, 'Squat', 150, 5, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (59, 'Abdominal crunch', 0, 10, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (60, 'Abdominal crunch', 0, 25, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (61, 'Abdominal crunch', 0, 50, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (62, 'Loaded abdominal crunch', 10, 10, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (63, 'Loaded abdominal crunch', 20, 10, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (64, 'Loaded abdominal crunch', 30, 10, null);
+INSERT INTO badge_criteria (badge_id, exercise, min_weight, min_reps, min_bodyweight_ratio) VALUES (65, 'Loaded abdominal crunch', 50, 10, null);
@@ -0,0 +1,42 @@
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (66, 'streak', 'gte', 1, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (67, 'streak', 'gte', 4, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (68, 'streak', 'gte', 10, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (69, 'streak', 'gte', 20, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (70, 'streak', 'gte', 50, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (72, 'attendances', 'gte', 100, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (73, 'attendances', 'gte', 200, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (74, 'attendances', 'gte', 500, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (71, 'account_age_days', 'gte', 30, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (75, 'account_age_days', 'gte', 182, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (76, 'account_age_days', 'gte', 365, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (77, 'account_age_days', 'gte', 730, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (78, 'account_age_days', 'gte', 1095, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (79, 'account_age_days', 'gte', 1825, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (95, 'training_hours', 'gte', 10, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (96, 'training_hours', 'gte', 50, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (97, 'training_hours', 'gte', 100, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (98, 'training_hours', 'gte', 500, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (99, 'sessions_between', 'gte', 10, 0, 420);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (100, 'sessions_between', 'gte', 50, 0, 420);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (101, 'sessions_between', 'gte', 10, 1260, 1440);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (102, 'sessions_between', 'gte', 50, 1260, 1440);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (80, 'global_rank', 'lte', 500, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (81, 'global_rank', 'lte', 100, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (82, 'global_rank', 'lte', 50, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (83, 'global_rank', 'lte', 10, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (84, 'global_rank', 'lte', 3, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (85, 'global_rank', 'lte', 1, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (86, 'friends_count', 'gte', 1, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (87, 'friends_count', 'gte', 5, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (88, 'friends_count', 'gte', 10, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (89, 'friends_count', 'gte', 20, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (90, 'friends_rank', 'lte', 3, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (91, 'friends_rank', 'lte', 1, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (92, 'friends_rank', 'lte', 3, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (93, 'friends_rank', 'lte', 1, null, null);
+INSERT INTO badge_rule (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (94, 'friends_rank', 'lte', 1, null, null);
+INSERT INTO badge_rule_condition (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (90, 'friends_count', 'gte', 20, null, null);
+INSERT INTO badge_rule_condition (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (91, 'friends_count', 'gte', 20, null, null);
+INSERT INTO badge_rule_condition (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (92, 'friends_count', 'gte', 10, null, null);
+INSERT INTO badge_rule_condition (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (93, 'friends_count', 'gte', 10, null, null);
+INSERT INTO badge_rule_condition (badge_id, metric, comparator, threshold, from_minute, to_minute) VALUES (94, 'friends_count', 'gte', 5, null, null);
@@ -0,0 +1,116 @@
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (-1, 'en', 'Chest', 'Badges of the chest exercises.');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (-1, 'es', 'Pecho', 'Insignias de los ejercicios de pecho.');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (1, 'es', 'Haz cinco flexiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (2, 'es', 'Haz diez flexiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (3, 'es', 'Haz veinte flexiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (4, 'es', 'Haz treinta flexiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (5, 'es', 'Haz cincuenta flexiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (6, 'es', 'Haz cien flexiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (7, 'es', 'Press de banca con 20kg durante 10 repeticiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (8, 'es', 'Press de banca con 30kg durante 10 repeticiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (9, 'es', 'Press de banca con 40kg durante 10 repeticiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (10, 'es', 'Press de banca con 60kg durante 10 repeticiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (11, 'es', 'Press de banca con 80kg durante 5 repeticiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (12, 'es', 'Press de banca con 100kg durante 5 repeticiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (13, 'es', 'Press de banca con 120kg durante 5 repeticiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (14, 'es', 'Haz cinco fondos de pecho', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (15, 'es', 'Haz diez fondos de pecho', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (16, 'es', 'Haz veinte fondos de pecho', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (17, 'es', 'Haz treinta fondos de pecho', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (18, 'es', 'Haz cincuenta fondos de pecho', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (-2, 'en', 'Arms', 'Badges of the arm and shoulder exercises.');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (-2, 'es', 'Brazos', 'Insignias de los ejercicios de brazos y hombros.');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (19, 'es', 'Curl de bíceps con mancuerna de 8kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (20, 'es', 'Curl de bíceps con mancuerna de 10kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (21, 'es', 'Curl de bíceps con mancuerna de 12kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (22, 'es', 'Curl de bíceps con mancuerna de 16kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (23, 'es', 'Curl de bíceps con mancuerna de 20kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (24, 'es', 'Curl de bíceps con mancuerna de 25kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (25, 'es', 'Curl de bíceps con mancuerna de 30kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (26, 'es', 'Press de hombros con 6kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (27, 'es', 'Press de hombros con 10kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (28, 'es', 'Press de hombros con 14kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (29, 'es', 'Press de hombros con 18kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (30, 'es', 'Press de hombros con 22kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (31, 'es', 'Press de hombros con 26kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (32, 'es', 'Press de hombros con 30kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (33, 'es', 'Elevaciones laterales con 4kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (34, 'es', 'Elevaciones laterales con 8kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (35, 'es', 'Elevaciones laterales con 10kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (36, 'es', 'Elevaciones laterales con 12kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (37, 'es', 'Elevaciones laterales con 14kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (38, 'es', 'Elevaciones laterales con 16kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (39, 'es', 'Elevaciones laterales con 20kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (-3, 'en', 'Back', 'Badges of the back exercises.');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (-3, 'es', 'Espalda', 'Insignias de los ejercicios de espalda.');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (40, 'es', 'Haz cinco dominadas', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (41, 'es', 'Haz diez dominadas', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (42, 'es', 'Haz veinte dominadas', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (43, 'es', 'Haz treinta dominadas', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (44, 'es', 'Jalón al pecho con 20kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (45, 'es', 'Jalón al pecho con 30kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (46, 'es', 'Jalón al pecho con 40kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (47, 'es', 'Jalón al pecho con 50kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (48, 'es', 'Jalón al pecho con 60kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (49, 'es', 'Jalón al pecho con 70kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (50, 'es', 'Jalón al pecho con 80kg', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (-4, 'en', 'Legs', 'Badges of the leg exercises.');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (-4, 'es', 'Piernas', 'Insignias de los ejercicios de piernas.');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (51, 'es', 'Sentadilla con 20kg durante 10 repeticiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (52, 'es', 'Sentadilla con 30kg durante 10 repeticiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (53, 'es', 'Sentadilla con 40kg durante 10 repeticiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (54, 'es', 'Sentadilla con 60kg durante 10 repeticiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (55, 'es', 'Sentadilla con 80kg durante 10 repeticiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (56, 'es', 'Sentadilla con 100kg durante 5 repeticiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (57, 'es', 'Sentadilla con 120kg durante 5 repeticiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (58, 'es', 'Sentadilla con 150kg durante 5 repeticiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (-5, 'en', 'Core', 'Badges of the core exercises.');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (-5, 'es', 'Core', 'Insignias de los ejercicios de core.');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (59, 'es', 'Haz diez abdominales seguidos', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (60, 'es', 'Haz veinticinco abdominales seguidos', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (61, 'es', 'Haz cincuenta abdominales seguidos', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (62, 'es', 'Abdominales con 10kg de carga durante 10 repeticiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (63, 'es', 'Abdominales con 20kg de carga durante 10 repeticiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (64, 'es', 'Abdominales con 30kg de carga durante 10 repeticiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (65, 'es', 'Abdominales con 50kg de carga durante 10 repeticiones', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (-6, 'en', 'Consistency', 'Badges for training regularly.');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (-6, 'es', 'Constancia', 'Insignias por entrenar con regularidad.');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (66, 'es', 'La primera semana', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (67, 'es', 'Racha de 4 semanas', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (68, 'es', 'Racha de 10 semanas', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (69, 'es', 'Racha de 20 semanas', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (70, 'es', 'Racha de 50 semanas', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (71, 'es', 'Primer mes', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (72, 'es', 'Llega a 100 sesiones en el gimnasio', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (73, 'es', 'Llega a 200 sesiones en el gimnasio', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (74, 'es', 'Llega a 500 sesiones en el gimnasio', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (75, 'es', 'Seis meses', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (76, 'es', '¡Primer año!', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (77, 'es', 'Dos años', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (78, 'es', 'Tres años', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (79, 'es', 'Cinco años', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (95, 'es', '10 horas de entrenamiento', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (96, 'es', '50 horas de entrenamiento', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (97, 'es', '100 horas de entrenamiento', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (98, 'es', '500 horas de entrenamiento', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (99, 'es', 'Madrugador: 10 sesiones empezadas antes de las 7:00', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (100, 'es', 'Madrugador: 50 sesiones empezadas antes de las 7:00', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (101, 'es', 'Noctámbulo: 10 sesiones empezadas desde las 21:00', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (102, 'es', 'Noctámbulo: 50 sesiones empezadas desde las 21:00', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (-7, 'en', 'Social', 'Badges of the rankings and the friends.');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (-7, 'es', 'Social', 'Insignias de los rankings y los amigos.');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (80, 'es', 'Entra en el top 500 del Ranking Global', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (81, 'es', 'Entra en el top 100 del Ranking Global', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (82, 'es', 'Entra en el top 50 del Ranking Global', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (83, 'es', 'Entra en el top 10 del Ranking Global', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (84, 'es', 'Entra en el top 3 del Ranking Global', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (85, 'es', 'Entra en el top 1 del Ranking Global', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (86, 'es', 'Añade tu primer amigo', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (87, 'es', 'Añade cinco amigos', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (88, 'es', 'Añade diez amigos', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (89, 'es', 'Añade veinte amigos', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (90, 'es', 'Sube al podio del Ranking de Amigos con al menos 20 amigos', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (91, 'es', 'Llega a lo más alto del Ranking de Amigos con al menos 20 amigos', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (92, 'es', 'Sube al podio del Ranking de Amigos con al menos 10 amigos', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (93, 'es', 'Llega a lo más alto del Ranking de Amigos con al menos 10 amigos', '');
+INSERT INTO badge_translation (badge_id, locale, name, description) VALUES (94, 'es', 'Llega a lo más alto del Ranking de Amigos con al menos 5 amigos', '');
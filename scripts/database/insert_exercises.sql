@@ -0,0 +1,35 @@
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (1, 'Push-up', '{chest,triceps,shoulders}', 'bodyweight', -1, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (2, 'Bench press', '{chest,triceps,shoulders}', 'barbell', -1, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (3, 'Incline bench press', '{chest,shoulders,triceps}', 'barbell', -1, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (4, 'Dumbbell bench press', '{chest,triceps,shoulders}', 'dumbbell', -1, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (5, 'Chest dip', '{chest,triceps}', 'bodyweight', -1, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (6, 'Cable fly', '{chest}', 'cable', -1, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (7, 'Chest press machine', '{chest,triceps}', 'machine', -1, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (8, 'Biceps curl', '{biceps,forearms}', 'dumbbell', -2, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (9, 'Hammer curl', '{biceps,forearms}', 'dumbbell', -2, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (10, 'Barbell curl', '{biceps,forearms}', 'barbell', -2, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (11, 'Triceps pushdown', '{triceps}', 'cable', -2, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (12, 'Skull crusher', '{triceps}', 'barbell', -2, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (13, 'Shoulder press', '{shoulders,triceps}', 'dumbbell', -2, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (14, 'Lateral raise', '{shoulders}', 'dumbbell', -2, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (15, 'Pull-up', '{lats,back,biceps}', 'bodyweight', -3, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (16, 'Lat pull-down', '{lats,back,biceps}', 'cable', -3, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (17, 'Barbell row', '{back,lats,biceps}', 'barbell', -3, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (18, 'Seated cable row', '{back,lats,biceps}', 'cable', -3, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (19, 'Deadlift', '{lower_back,hamstrings,glutes,back}', 'barbell', -3, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (20, 'Face pull', '{shoulders,traps}', 'cable', -3, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (21, 'Squat', '{quadriceps,glutes,hamstrings}', 'barbell', -4, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (22, 'Front squat', '{quadriceps,glutes}', 'barbell', -4, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (23, 'Leg press', '{quadriceps,glutes}', 'machine', -4, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (24, 'Romanian deadlift', '{hamstrings,glutes,lower_back}', 'barbell', -4, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (25, 'Lunge', '{quadriceps,glutes}', 'dumbbell', -4, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (26, 'Leg extension', '{quadriceps}', 'machine', -4, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (27, 'Leg curl', '{hamstrings}', 'machine', -4, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (28, 'Calf raise', '{calves}', 'machine', -4, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (29, 'Abdominal crunch', '{abs}', 'bodyweight', -5, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (30, 'Loaded abdominal crunch', '{abs}', 'other', -5, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (31, 'Plank', '{abs,obliques}', 'bodyweight', -5, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (32, 'Hanging leg raise', '{abs}', 'bodyweight', -5, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (33, 'Russian twist', '{obliques,abs}', 'other', -5, null);
+INSERT INTO exercise (id, name, muscle_groups, equipment, badge_category_id, creator_id) VALUES (34, 'Back extension', '{lower_back,glutes}', 'bodyweight', -5, null);
+SELECT setval(pg_get_serial_sequence('exercise', 'id'), (SELECT MAX(id) FROM exercise));
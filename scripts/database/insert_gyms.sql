@@ -0,0 +1,6 @@
+-- The secrets of the gym are not shipped, pass new random ones when loading the script:
+--   psql -v qr_secret="$(openssl rand -hex 32)" -v device_key="$(openssl rand -hex 32)" -f insert_gyms.sql
+-- The QR secret signs the check-in codes and never leaves the server. The device key goes to the screen of the gym,
+-- only its SHA-256 hash is stored.
+INSERT INTO gym (id, name, latitude, longitude, radius, qr_secret, device_key_hash)
+VALUES (1, 'Gym Badges Center', 40.416775, -3.703790, 100, :'qr_secret', encode(sha256(convert_to(:'device_key', 'UTF8')), 'hex'));
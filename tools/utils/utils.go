@@ -4,6 +4,26 @@ func NewFloat32(f float32) *float32 {
 	return &f
 }
 
+func NewFloat64(f float64) *float64 {
+	return &f
+}
+
+func NewInt32(i int32) *int32 {
+	return &i
+}
+
+func NewInt64(i int64) *int64 {
+	return &i
+}
+
+func NewString(s string) *string {
+	return &s
+}
+
+func NewBool(b bool) *bool {
+	return &b
+}
+
 func CalcLevel(experience int64) int32 {
 	return int32(experience / 100)
 }